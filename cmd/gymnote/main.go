@@ -1,12 +1,23 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"gymnote/internal/app"
 )
 
 func main() {
+	migrate := flag.Bool("migrate", false, "apply database migrations and exit")
+	flag.Parse()
+
+	if *migrate {
+		if err := app.Migrate(); err != nil {
+			log.Fatalf("failed to migrate: %s", err.Error())
+		}
+		return
+	}
+
 	a, err := app.New()
 	if err != nil {
 		log.Fatalf("failed to init app: %s", err.Error())
@@ -5,6 +5,7 @@ go 1.23
 toolchain go1.23.5
 
 require (
+	github.com/alicebob/miniredis/v2 v2.35.0
 	github.com/go-echarts/go-echarts/v2 v2.5.0
 	github.com/go-echarts/snapshot-chromedp v0.0.5
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
@@ -39,6 +40,7 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	go.opentelemetry.io/otel v1.26.0 // indirect
 	go.opentelemetry.io/otel/trace v1.26.0 // indirect
 	golang.org/x/crypto v0.33.0 // indirect
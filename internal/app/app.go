@@ -2,8 +2,11 @@ package app
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -25,6 +28,8 @@ type app struct {
 
 	cfg *config.Config
 
+	metrics *http.Server
+
 	api   tg.API
 	db    repository.DB
 	cache repository.Cache
@@ -80,6 +85,12 @@ func (a *app) initDB() error {
 		return fmt.Errorf("init db error: %w", err)
 	}
 
+	if a.cfg.DB.MigrateOnStart {
+		if err := db.Migrate(a.ctx); err != nil {
+			return fmt.Errorf("migrate db error: %w", err)
+		}
+	}
+
 	a.db = db
 
 	cache, err := redis.New(a.ctx, &a.cfg.Redis)
@@ -105,6 +116,16 @@ func (a *app) initServices() error {
 
 func (a *app) Run() error {
 	go a.api.Register()
+	go a.api.RunSessionSweeper(&a.cfg.Session)
+	go a.api.RunWorkoutDelivery(a.cfg.Session.SweepInterval)
+
+	if a.cfg.MetricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		a.metrics = &http.Server{Addr: a.cfg.MetricsAddress, Handler: mux}
+
+		go a.serveMetrics()
+	}
 
 	log.Println("Server is running...")
 
@@ -116,7 +137,20 @@ func (a *app) Run() error {
 	return a.shutdown(ctx)
 }
 
+// serveMetrics отдает метрики expvar, в том числе длину очередей обновлений, на /debug/vars.
+func (a *app) serveMetrics() {
+	if err := a.metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("metrics server err: %v\n", err)
+	}
+}
+
 func (a *app) shutdown(ctx context.Context) error {
+	if a.metrics != nil {
+		if err := a.metrics.Shutdown(ctx); err != nil {
+			log.Printf("metrics server shutdown err: %v\n", err)
+		}
+	}
+
 	if err := a.db.Close(ctx); err != nil {
 		log.Printf("db close err: %v\n", err)
 	}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"gymnote/internal/config"
+	mongodb "gymnote/internal/repository/mongo"
+)
+
+// Migrate применяет миграции базы и завершается, не запуская бота.
+func Migrate() error {
+	ctx := context.Background()
+	cfg := config.MustLoad()
+
+	db, err := mongodb.New(ctx, &cfg.DB)
+	if err != nil {
+		return fmt.Errorf("init db error: %w", err)
+	}
+
+	defer func() {
+		if err := db.Close(ctx); err != nil {
+			log.Printf("db close err: %v\n", err)
+		}
+	}()
+
+	if err := db.Migrate(ctx); err != nil {
+		return fmt.Errorf("migrate db error: %w", err)
+	}
+
+	log.Println("Migrations applied")
+
+	return nil
+}
@@ -11,9 +11,11 @@ import (
 type Config struct {
 	Env             string        `env:"ENV" env-default:"local"`
 	GracefulTimeout time.Duration `env:"GRACEFUL_TIMEOUT" env-default:"10s"`
+	MetricsAddress  string        `env:"METRICS_ADDRESS" env-required:"false"`
 	DB              DBConfig
 	Redis           CacheConfig
 	Telegram        TelegramConfig
+	Session         SessionConfig
 }
 
 type DBConfig struct {
@@ -22,6 +24,8 @@ type DBConfig struct {
 	User     string `env:"DB_USER" env-required:"true"`
 	Password string `env:"DB_PASSWORD" env-required:"true"`
 	Name     string `env:"DB_NAME" env-required:"true"`
+
+	MigrateOnStart bool `env:"DB_MIGRATE_ON_START" env-default:"true"`
 }
 
 func (c *DBConfig) ConnectionString() string {
@@ -46,12 +50,21 @@ type CacheConfig struct {
 type TelegramConfig struct {
 	BotToken          string `env:"TELEGRAM_BOT_TOKEN" env-required:"true"`
 	GraphicsPath      string `env:"TELEGRAM_BOT_GRAPHICS_PATH" env-required:"true"`
+	MediaPath         string `env:"TELEGRAM_BOT_MEDIA_PATH" env-default:"media"`
 	GreetingStickerID string `env:"TELEGRAM_BOT_GREETING_STICKER_ID" env-required:"false"`
 	AuthorName        string `env:"TELEGRAM_BOT_AUTHOR_NAME" env-required:"false"`
 	Timeout           int    `env:"TELEGRAM_BOT_TIMEOUT" env-default:"60"`
+	Workers           int    `env:"TELEGRAM_BOT_WORKERS" env-default:"16"`
 	Debug             bool   `env:"TELEGRAM_BOT_DEBUG" env-default:"false"`
 }
 
+// SessionConfig настройки сборщика брошенных тренировок.
+type SessionConfig struct {
+	IdleReminder  time.Duration `env:"SESSION_IDLE_REMINDER" env-default:"3h"`
+	IdleClose     time.Duration `env:"SESSION_IDLE_CLOSE" env-default:"1h"`
+	SweepInterval time.Duration `env:"SESSION_SWEEP_INTERVAL" env-default:"5m"`
+}
+
 func MustLoad() *Config {
 	var cfg Config
 
@@ -0,0 +1,235 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CoachLinkStatus состояние связи тренера и спортсмена.
+type CoachLinkStatus string
+
+const (
+	// CoachLinkPending приглашение создано, спортсмен еще не дал согласие
+	CoachLinkPending CoachLinkStatus = "pending"
+	CoachLinkActive  CoachLinkStatus = "active"
+	CoachLinkRevoked CoachLinkStatus = "revoked"
+)
+
+type CoachLinkOption func(o *CoachLink)
+
+// CoachLink доступ тренера к тренировкам спортсмена. Создается приглашением тренера и
+// становится активным после согласия спортсмена, отозвать ее может любая из сторон.
+type CoachLink struct {
+	id          uuid.UUID
+	coachID     string
+	coachName   string
+	athleteID   string
+	athleteName string
+	status      CoachLinkStatus
+	createdAt   time.Time
+	acceptedAt  time.Time
+	revokedAt   time.Time
+}
+
+func (l *CoachLink) ID() uuid.UUID {
+	return l.id
+}
+
+func (l *CoachLink) CoachID() string {
+	return l.coachID
+}
+
+func (l *CoachLink) CoachName() string {
+	return l.coachName
+}
+
+func (l *CoachLink) AthleteID() string {
+	return l.athleteID
+}
+
+func (l *CoachLink) AthleteName() string {
+	return l.athleteName
+}
+
+func (l *CoachLink) Status() CoachLinkStatus {
+	return l.status
+}
+
+func (l *CoachLink) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+func (l *CoachLink) AcceptedAt() time.Time {
+	return l.acceptedAt
+}
+
+func (l *CoachLink) RevokedAt() time.Time {
+	return l.revokedAt
+}
+
+func (l *CoachLink) IsActive() bool {
+	return l.status == CoachLinkActive
+}
+
+// Accept привязывает спортсмена к приглашению.
+func (l *CoachLink) Accept(athleteID, athleteName string, at time.Time) {
+	l.athleteID = athleteID
+	l.athleteName = athleteName
+	l.status = CoachLinkActive
+	l.acceptedAt = at
+}
+
+func (l *CoachLink) Revoke(at time.Time) {
+	l.status = CoachLinkRevoked
+	l.revokedAt = at
+}
+
+// IsParticipant сообщает, является ли пользователь тренером или спортсменом связи.
+func (l *CoachLink) IsParticipant(userID string) bool {
+	return userID == l.coachID || (userID == l.athleteID && l.athleteID != "")
+}
+
+func NewCoachLink(opts ...CoachLinkOption) *CoachLink {
+	link := &CoachLink{}
+
+	for _, opt := range opts {
+		opt(link)
+	}
+
+	return link
+}
+
+type CoachLinkInitSpecification struct {
+	CoachID   string
+	CoachName string
+}
+
+func WithCoachLinkInitSpec(s CoachLinkInitSpecification) CoachLinkOption {
+	return func(o *CoachLink) {
+		o.id = uuid.New()
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.status = CoachLinkPending
+		o.createdAt = time.Now()
+	}
+}
+
+type CoachLinkRestoreSpecification struct {
+	ID          uuid.UUID
+	CoachID     string
+	CoachName   string
+	AthleteID   string
+	AthleteName string
+	Status      CoachLinkStatus
+	CreatedAt   time.Time
+	AcceptedAt  time.Time
+	RevokedAt   time.Time
+}
+
+func WithCoachLinkRestoreSpec(s CoachLinkRestoreSpecification) CoachLinkOption {
+	return func(o *CoachLink) {
+		o.id = s.ID
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.athleteID = s.AthleteID
+		o.athleteName = s.AthleteName
+		o.status = s.Status
+		o.createdAt = s.CreatedAt
+		o.acceptedAt = s.AcceptedAt
+		o.revokedAt = s.RevokedAt
+	}
+}
+
+type SessionCommentOption func(o *SessionComment)
+
+// SessionComment комментарий тренера к завершенной тренировке спортсмена.
+type SessionComment struct {
+	id        uuid.UUID
+	sessionID uuid.UUID
+	athleteID string
+	coachID   string
+	coachName string
+	text      string
+	createdAt time.Time
+}
+
+func (c *SessionComment) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *SessionComment) SessionID() uuid.UUID {
+	return c.sessionID
+}
+
+func (c *SessionComment) AthleteID() string {
+	return c.athleteID
+}
+
+func (c *SessionComment) CoachID() string {
+	return c.coachID
+}
+
+func (c *SessionComment) CoachName() string {
+	return c.coachName
+}
+
+func (c *SessionComment) Text() string {
+	return c.text
+}
+
+func (c *SessionComment) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func NewSessionComment(opts ...SessionCommentOption) *SessionComment {
+	comment := &SessionComment{}
+
+	for _, opt := range opts {
+		opt(comment)
+	}
+
+	return comment
+}
+
+type SessionCommentInitSpecification struct {
+	SessionID uuid.UUID
+	AthleteID string
+	CoachID   string
+	CoachName string
+	Text      string
+}
+
+func WithSessionCommentInitSpec(s SessionCommentInitSpecification) SessionCommentOption {
+	return func(o *SessionComment) {
+		o.id = uuid.New()
+		o.sessionID = s.SessionID
+		o.athleteID = s.AthleteID
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.text = s.Text
+		o.createdAt = time.Now()
+	}
+}
+
+type SessionCommentRestoreSpecification struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+	AthleteID string
+	CoachID   string
+	CoachName string
+	Text      string
+	CreatedAt time.Time
+}
+
+func WithSessionCommentRestoreSpec(s SessionCommentRestoreSpecification) SessionCommentOption {
+	return func(o *SessionComment) {
+		o.id = s.ID
+		o.sessionID = s.SessionID
+		o.athleteID = s.AthleteID
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.text = s.Text
+		o.createdAt = s.CreatedAt
+	}
+}
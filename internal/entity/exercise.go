@@ -14,6 +14,10 @@ type Exercise struct {
 	name        string
 	muscleGroup string
 	equipment   string
+	ownerID     string
+	archivedAt  *time.Time
+
+	secondaryMuscles []SecondaryMuscle
 }
 
 func (e *Exercise) ID() uuid.UUID {
@@ -36,6 +40,72 @@ func (e *Exercise) Equipment() string {
 	return e.equipment
 }
 
+// SecondaryMuscles возвращает вспомогательные мышечные группы, основная группа в них не входит.
+func (e *Exercise) SecondaryMuscles() []SecondaryMuscle {
+	return e.secondaryMuscles
+}
+
+// MuscleWeights возвращает долю нагрузки на каждую мышечную группу: 1 для основной
+// и заданный вес для вспомогательных.
+func (e *Exercise) MuscleWeights() map[string]float32 {
+	weights := map[string]float32{e.muscleGroup: 1}
+	for _, muscle := range e.secondaryMuscles {
+		if muscle.MuscleGroup == e.muscleGroup {
+			continue
+		}
+		weights[muscle.MuscleGroup] = muscle.Weight
+	}
+
+	return weights
+}
+
+// OwnerID возвращает автора личного упражнения, у общих упражнений каталога он пустой.
+func (e *Exercise) OwnerID() string {
+	return e.ownerID
+}
+
+func (e *Exercise) IsPublic() bool {
+	return e.ownerID == ""
+}
+
+func (e *Exercise) IsVisibleTo(userID string) bool {
+	return e.IsPublic() || e.ownerID == userID
+}
+
+func (e *Exercise) MakePublic() {
+	e.ownerID = ""
+}
+
+// ArchivedAt возвращает момент архивации, у активных упражнений он nil.
+func (e *Exercise) ArchivedAt() *time.Time {
+	return e.archivedAt
+}
+
+func (e *Exercise) IsArchived() bool {
+	return e.archivedAt != nil
+}
+
+func (e *Exercise) Rename(name string) {
+	e.name = name
+}
+
+func (e *Exercise) SetMuscleGroup(muscleGroup string) {
+	e.muscleGroup = muscleGroup
+}
+
+func (e *Exercise) SetSecondaryMuscles(muscles []SecondaryMuscle) {
+	e.secondaryMuscles = muscles
+}
+
+func (e *Exercise) SetEquipment(equipment string) {
+	e.equipment = equipment
+}
+
+func (e *Exercise) Archive() {
+	now := time.Now()
+	e.archivedAt = &now
+}
+
 func NewExercise(opts ...ExerciseOption) *Exercise {
 	exercise := &Exercise{}
 
@@ -47,9 +117,11 @@ func NewExercise(opts ...ExerciseOption) *Exercise {
 }
 
 type ExerciseInitSpecification struct {
-	Name        string
-	MuscleGroup string
-	Equipment   string
+	Name             string
+	MuscleGroup      string
+	SecondaryMuscles []SecondaryMuscle
+	Equipment        string
+	OwnerID          string
 }
 
 func WithExerciseInitSpec(e ExerciseInitSpecification) ExerciseOption {
@@ -59,15 +131,20 @@ func WithExerciseInitSpec(e ExerciseInitSpecification) ExerciseOption {
 		o.name = e.Name
 		o.muscleGroup = e.MuscleGroup
 		o.equipment = e.Equipment
+		o.ownerID = e.OwnerID
+		o.secondaryMuscles = e.SecondaryMuscles
 	}
 }
 
 type ExerciseRestoreSpecification struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	Name        string
-	MuscleGroup string
-	Equipment   string
+	ID               uuid.UUID
+	CreatedAt        time.Time
+	Name             string
+	MuscleGroup      string
+	SecondaryMuscles []SecondaryMuscle
+	Equipment        string
+	OwnerID          string
+	ArchivedAt       *time.Time
 }
 
 func WithExerciseRestoreSpec(e ExerciseRestoreSpecification) ExerciseOption {
@@ -77,5 +154,8 @@ func WithExerciseRestoreSpec(e ExerciseRestoreSpecification) ExerciseOption {
 		o.name = e.Name
 		o.muscleGroup = e.MuscleGroup
 		o.equipment = e.Equipment
+		o.ownerID = e.OwnerID
+		o.archivedAt = e.ArchivedAt
+		o.secondaryMuscles = e.SecondaryMuscles
 	}
 }
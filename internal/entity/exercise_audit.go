@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExerciseAuditAction string
+
+const (
+	ExerciseAuditRename      ExerciseAuditAction = "rename"
+	ExerciseAuditMerge       ExerciseAuditAction = "merge"
+	ExerciseAuditArchive     ExerciseAuditAction = "archive"
+	ExerciseAuditMuscleGroup ExerciseAuditAction = "muscle_group"
+	ExerciseAuditEquipment   ExerciseAuditAction = "equipment"
+	ExerciseAuditTechnique   ExerciseAuditAction = "technique"
+)
+
+type ExerciseAuditOption func(o *ExerciseAudit)
+
+// ExerciseAudit запись журнала изменений каталога упражнений.
+type ExerciseAudit struct {
+	id         uuid.UUID
+	exerciseID uuid.UUID
+	actorID    string
+	action     ExerciseAuditAction
+	before     string
+	after      string
+	createdAt  time.Time
+}
+
+func (a *ExerciseAudit) ID() uuid.UUID {
+	return a.id
+}
+
+func (a *ExerciseAudit) ExerciseID() uuid.UUID {
+	return a.exerciseID
+}
+
+func (a *ExerciseAudit) ActorID() string {
+	return a.actorID
+}
+
+func (a *ExerciseAudit) Action() ExerciseAuditAction {
+	return a.action
+}
+
+func (a *ExerciseAudit) Before() string {
+	return a.before
+}
+
+// After возвращает значение после изменения, для слияния это ID упражнения, в которое перенесена история.
+func (a *ExerciseAudit) After() string {
+	return a.after
+}
+
+func (a *ExerciseAudit) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func NewExerciseAudit(opts ...ExerciseAuditOption) *ExerciseAudit {
+	audit := &ExerciseAudit{}
+
+	for _, opt := range opts {
+		opt(audit)
+	}
+
+	return audit
+}
+
+type ExerciseAuditInitSpecification struct {
+	ExerciseID uuid.UUID
+	ActorID    string
+	Action     ExerciseAuditAction
+	Before     string
+	After      string
+}
+
+func WithExerciseAuditInitSpec(s ExerciseAuditInitSpecification) ExerciseAuditOption {
+	return func(o *ExerciseAudit) {
+		o.id = uuid.New()
+		o.exerciseID = s.ExerciseID
+		o.actorID = s.ActorID
+		o.action = s.Action
+		o.before = s.Before
+		o.after = s.After
+		o.createdAt = time.Now()
+	}
+}
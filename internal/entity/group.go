@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxGroupLifts сколько упражнений группа может выбрать для рейтинга e1RM.
+const MaxGroupLifts = 5
+
+// GroupMember участник группового чата, согласившийся попадать в рейтинги.
+type GroupMember struct {
+	UserID      string
+	Name        string
+	AnnouncePRs bool
+	JoinedAt    time.Time
+}
+
+// GroupLift упражнение, по которому группа соревнуется в e1RM.
+type GroupLift struct {
+	ExerciseID uuid.UUID
+	Name       string
+}
+
+type GroupOption func(o *Group)
+
+// Group групповой чат с ботом: участники и выбранные для рейтинга упражнения.
+type Group struct {
+	chatID    int64
+	title     string
+	members   []GroupMember
+	lifts     []GroupLift
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (g *Group) ChatID() int64 {
+	return g.chatID
+}
+
+func (g *Group) Title() string {
+	return g.title
+}
+
+func (g *Group) Members() []GroupMember {
+	return g.members
+}
+
+func (g *Group) Lifts() []GroupLift {
+	return g.lifts
+}
+
+func (g *Group) CreatedAt() time.Time {
+	return g.createdAt
+}
+
+func (g *Group) UpdatedAt() time.Time {
+	return g.updatedAt
+}
+
+func (g *Group) SetTitle(title string) {
+	g.title = title
+}
+
+func (g *Group) Member(userID string) (*GroupMember, bool) {
+	idx := slices.IndexFunc(g.members, func(m GroupMember) bool { return m.UserID == userID })
+	if idx < 0 {
+		return nil, false
+	}
+
+	return &g.members[idx], true
+}
+
+// Join добавляет участника, повторное вступление только обновляет имя. Возвращает false, если он уже в группе.
+func (g *Group) Join(userID, name string, at time.Time) bool {
+	if member, ok := g.Member(userID); ok {
+		member.Name = name
+		return false
+	}
+
+	g.members = append(g.members, GroupMember{UserID: userID, Name: name, JoinedAt: at})
+	g.updatedAt = at
+	return true
+}
+
+func (g *Group) Leave(userID string, at time.Time) bool {
+	before := len(g.members)
+	g.members = slices.DeleteFunc(g.members, func(m GroupMember) bool { return m.UserID == userID })
+	g.updatedAt = at
+
+	return len(g.members) != before
+}
+
+// ToggleAnnouncePRs включает или выключает объявления о рекордах участника и возвращает новое
+// значение, ok false если пользователь не участник группы.
+func (g *Group) ToggleAnnouncePRs(userID string, at time.Time) (enabled, ok bool) {
+	member, ok := g.Member(userID)
+	if !ok {
+		return false, false
+	}
+
+	member.AnnouncePRs = !member.AnnouncePRs
+	g.updatedAt = at
+	return member.AnnouncePRs, true
+}
+
+func (g *Group) SetLifts(lifts []GroupLift, at time.Time) {
+	g.lifts = lifts
+	g.updatedAt = at
+}
+
+func NewGroup(opts ...GroupOption) *Group {
+	group := &Group{}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+type GroupInitSpecification struct {
+	ChatID int64
+	Title  string
+}
+
+func WithGroupInitSpec(s GroupInitSpecification) GroupOption {
+	return func(o *Group) {
+		o.chatID = s.ChatID
+		o.title = s.Title
+		o.members = []GroupMember{}
+		o.lifts = []GroupLift{}
+		o.createdAt = time.Now()
+		o.updatedAt = o.createdAt
+	}
+}
+
+type GroupRestoreSpecification struct {
+	ChatID    int64
+	Title     string
+	Members   []GroupMember
+	Lifts     []GroupLift
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func WithGroupRestoreSpec(s GroupRestoreSpecification) GroupOption {
+	return func(o *Group) {
+		o.chatID = s.ChatID
+		o.title = s.Title
+		o.members = s.Members
+		o.lifts = s.Lifts
+		o.createdAt = s.CreatedAt
+		o.updatedAt = s.UpdatedAt
+	}
+}
+
+// LeaderboardEntry место участника в рейтинге.
+type LeaderboardEntry struct {
+	UserID string
+	Name   string
+	Value  float64
+}
+
+type LiftLeaderboard struct {
+	Lift    GroupLift
+	Entries []LeaderboardEntry
+}
+
+// Leaderboard недельные рейтинги группы, записи упорядочены по убыванию.
+type Leaderboard struct {
+	From     time.Time
+	Volume   []LeaderboardEntry
+	Sessions []LeaderboardEntry
+	Lifts    []LiftLeaderboard
+}
+
+// PersonalRecord лучший e1RM в упражнении за тренировку, превзошедший прежний.
+type PersonalRecord struct {
+	ExerciseName string
+	Weight       float32
+	Reps         uint8
+	E1RM         float64
+	Previous     float64
+}
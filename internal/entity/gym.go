@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EquipmentBodyweight упражнения с собственным весом доступны в любом зале.
+const EquipmentBodyweight = "Собственный вес"
+
+type GymOption func(o *Gym)
+
+// Gym зал пользователя и доступное в нем оборудование, значения совпадают с Exercise.Equipment.
+type Gym struct {
+	id        uuid.UUID
+	userID    string
+	name      string
+	equipment []string
+	createdAt time.Time
+}
+
+func (g *Gym) ID() uuid.UUID {
+	return g.id
+}
+
+func (g *Gym) UserID() string {
+	return g.userID
+}
+
+func (g *Gym) Name() string {
+	return g.name
+}
+
+func (g *Gym) Equipment() []string {
+	return g.equipment
+}
+
+func (g *Gym) CreatedAt() time.Time {
+	return g.createdAt
+}
+
+// Supports сообщает, можно ли выполнить упражнение с таким оборудованием в зале.
+func (g *Gym) Supports(equipment string) bool {
+	if equipment == "" || equipment == EquipmentBodyweight {
+		return true
+	}
+
+	return slices.Contains(g.equipment, equipment)
+}
+
+func NewGym(opts ...GymOption) *Gym {
+	gym := &Gym{}
+
+	for _, opt := range opts {
+		opt(gym)
+	}
+
+	return gym
+}
+
+type GymInitSpecification struct {
+	UserID    string
+	Name      string
+	Equipment []string
+}
+
+func WithGymInitSpec(s GymInitSpecification) GymOption {
+	return func(o *Gym) {
+		o.id = uuid.New()
+		o.userID = s.UserID
+		o.name = s.Name
+		o.equipment = s.Equipment
+		o.createdAt = time.Now()
+	}
+}
+
+type GymRestoreSpecification struct {
+	ID        uuid.UUID
+	UserID    string
+	Name      string
+	Equipment []string
+	CreatedAt time.Time
+}
+
+func WithGymRestoreSpec(s GymRestoreSpecification) GymOption {
+	return func(o *Gym) {
+		o.id = s.ID
+		o.userID = s.UserID
+		o.name = s.Name
+		o.equipment = s.Equipment
+		o.createdAt = s.CreatedAt
+	}
+}
@@ -0,0 +1,24 @@
+package entity
+
+// IdleSessionAction что сделать с тренировкой, брошенной без завершения.
+type IdleSessionAction string
+
+const (
+	IdleSessionFinish  IdleSessionAction = "finish"
+	IdleSessionDiscard IdleSessionAction = "discard"
+)
+
+// IdleSweepResult что сборщик сделал с брошенной тренировкой.
+type IdleSweepResult string
+
+const (
+	IdleSweepReminded  IdleSweepResult = "reminded"
+	IdleSweepFinished  IdleSweepResult = "finished"
+	IdleSweepDiscarded IdleSweepResult = "discarded"
+)
+
+// IdleSweep брошенная тренировка и результат ее обработки.
+type IdleSweep struct {
+	Session TrainingSession
+	Result  IdleSweepResult
+}
@@ -0,0 +1,68 @@
+package entity
+
+type MuscleGroupOption func(o *MuscleGroup)
+
+// MuscleGroup мышечная группа справочника. Name служит ключом и хранится в упражнениях и подходах.
+type MuscleGroup struct {
+	name   string
+	emoji  string
+	order  int
+	titles map[string]string
+}
+
+func (mg *MuscleGroup) Name() string {
+	return mg.name
+}
+
+func (mg *MuscleGroup) Emoji() string {
+	return mg.emoji
+}
+
+func (mg *MuscleGroup) Order() int {
+	return mg.order
+}
+
+// Title возвращает название группы на языке locale, если перевода нет - Name.
+func (mg *MuscleGroup) Title(locale string) string {
+	if title, ok := mg.titles[locale]; ok && title != "" {
+		return title
+	}
+
+	return mg.name
+}
+
+func (mg *MuscleGroup) Titles() map[string]string {
+	return mg.titles
+}
+
+func NewMuscleGroup(opts ...MuscleGroupOption) *MuscleGroup {
+	group := &MuscleGroup{}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+type MuscleGroupRestoreSpecification struct {
+	Name   string
+	Emoji  string
+	Order  int
+	Titles map[string]string
+}
+
+func WithMuscleGroupRestoreSpec(s MuscleGroupRestoreSpecification) MuscleGroupOption {
+	return func(o *MuscleGroup) {
+		o.name = s.Name
+		o.emoji = s.Emoji
+		o.order = s.Order
+		o.titles = s.Titles
+	}
+}
+
+// SecondaryMuscle вспомогательная мышечная группа упражнения с долей нагрузки от 0 до 1.
+type SecondaryMuscle struct {
+	MuscleGroup string
+	Weight      float32
+}
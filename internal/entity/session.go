@@ -11,13 +11,43 @@ import (
 
 type TrainingSessionOption func(o *TrainingSession)
 
+const (
+	MinSessionRating uint8 = 1
+	MaxSessionRating uint8 = 5
+)
+
 type TrainingSession struct {
 	id        uuid.UUID
 	userID    string
 	date      time.Time
 	exercises []SessionExercise
 	notes     string
+	gymID     uuid.UUID
 	createdAt time.Time
+
+	startedAt  time.Time
+	finishedAt time.Time
+	bodyweight float32
+	rating     uint8
+
+	// lastActivityAt последнее действие пользователя, remindedAt напоминание о брошенной тренировке
+	lastActivityAt time.Time
+	remindedAt     time.Time
+
+	// activeExerciseID упражнение, в которое записываются подходы
+	activeExerciseID uuid.UUID
+
+	// version версия текущей тренировки в кэше для сохранения без потери параллельных изменений
+	version uint64
+
+	// dashboardMessageID закрепленное сообщение с ходом текущей тренировки
+	dashboardMessageID int
+
+	// assignmentID план тренера, по которому идет тренировка, uuid.Nil для обычной тренировки
+	assignmentID uuid.UUID
+
+	// sharedSessionID совместная тренировка, частью которой является эта, uuid.Nil для личной
+	sharedSessionID uuid.UUID
 }
 
 func (ts *TrainingSession) ID() uuid.UUID {
@@ -40,6 +70,20 @@ func (ts *TrainingSession) TotalVolume() float32 {
 	return tv
 }
 
+// MuscleVolume распределяет объем тренировки по мышечным группам с учетом
+// вспомогательных мышц упражнений.
+func (ts *TrainingSession) MuscleVolume() map[string]float32 {
+	volume := make(map[string]float32)
+	for _, exercise := range ts.exercises {
+		exerciseVolume := exercise.TotalVolume()
+		for muscleGroup, weight := range exercise.Exercise.MuscleWeights() {
+			volume[muscleGroup] += exerciseVolume * weight
+		}
+	}
+
+	return volume
+}
+
 func (ts *TrainingSession) ExerciseCount() uint8 {
 	return uint8(len(ts.exercises))
 }
@@ -57,31 +101,347 @@ func (ts *TrainingSession) Exercises() []SessionExercise {
 	return ts.exercises
 }
 
+// GymID возвращает зал тренировки, uuid.Nil если зал не выбран.
+func (ts *TrainingSession) GymID() uuid.UUID {
+	return ts.gymID
+}
+
+func (ts *TrainingSession) SetGym(gymID uuid.UUID) {
+	ts.gymID = gymID
+}
+
 func (ts *TrainingSession) Notes() string {
 	return ts.notes
 }
 
+func (ts *TrainingSession) StartedAt() time.Time {
+	return ts.startedAt
+}
+
+func (ts *TrainingSession) FinishedAt() time.Time {
+	return ts.finishedAt
+}
+
+// Duration длительность тренировки, ноль если начало или конец неизвестны.
+func (ts *TrainingSession) Duration() time.Duration {
+	if ts.startedAt.IsZero() || ts.finishedAt.IsZero() || ts.finishedAt.Before(ts.startedAt) {
+		return 0
+	}
+
+	return ts.finishedAt.Sub(ts.startedAt)
+}
+
+func (ts *TrainingSession) Bodyweight() float32 {
+	return ts.bodyweight
+}
+
+func (ts *TrainingSession) Rating() uint8 {
+	return ts.rating
+}
+
+// LastActivityAt время последнего действия, для старых сессий - время начала.
+func (ts *TrainingSession) LastActivityAt() time.Time {
+	switch {
+	case !ts.lastActivityAt.IsZero():
+		return ts.lastActivityAt
+	case !ts.startedAt.IsZero():
+		return ts.startedAt
+	}
+
+	return ts.createdAt
+}
+
+func (ts *TrainingSession) RemindedAt() time.Time {
+	return ts.remindedAt
+}
+
+// Touch отмечает действие пользователя, после него напоминание отправляется заново.
+func (ts *TrainingSession) Touch(at time.Time) {
+	ts.lastActivityAt = at
+	ts.remindedAt = time.Time{}
+}
+
+func (ts *TrainingSession) MarkReminded(at time.Time) {
+	ts.remindedAt = at
+}
+
+func (ts *TrainingSession) Version() uint64 {
+	return ts.version
+}
+
+// SetVersion обновляет версию после сохранения тренировки в кэш.
+func (ts *TrainingSession) SetVersion(version uint64) {
+	ts.version = version
+}
+
+func (ts *TrainingSession) DashboardMessageID() int {
+	return ts.dashboardMessageID
+}
+
+func (ts *TrainingSession) SetDashboardMessageID(messageID int) {
+	ts.dashboardMessageID = messageID
+}
+
+func (ts *TrainingSession) AssignmentID() uuid.UUID {
+	return ts.assignmentID
+}
+
+func (ts *TrainingSession) SharedSessionID() uuid.UUID {
+	return ts.sharedSessionID
+}
+
+// Elapsed время с начала тренировки до at.
+func (ts *TrainingSession) Elapsed(at time.Time) time.Duration {
+	start := ts.startedAt
+	if start.IsZero() {
+		start = ts.createdAt
+	}
+	if start.IsZero() || at.Before(start) {
+		return 0
+	}
+
+	return at.Sub(start)
+}
+
+// LastSetMessageID сообщение, которым записан последний подход, 0 если подходов нет.
+func (ts *TrainingSession) LastSetMessageID() int {
+	var last *Set
+	for i := range ts.exercises {
+		for j := range ts.exercises[i].sets {
+			set := &ts.exercises[i].sets[j]
+			if set.IsFilled() && set.MessageID() != 0 && (last == nil || !set.CompletedAt().Before(last.CompletedAt())) {
+				last = set
+			}
+		}
+	}
+
+	if last == nil {
+		return 0
+	}
+
+	return last.MessageID()
+}
+
+// PruneEmptySets удаляет незаполненные подходы и оставшиеся без подходов упражнения.
+func (ts *TrainingSession) PruneEmptySets() {
+	for i := range ts.exercises {
+		ts.exercises[i].sets = slices.DeleteFunc(ts.exercises[i].sets, func(set Set) bool {
+			return !set.IsFilled()
+		})
+		for j := range ts.exercises[i].sets {
+			ts.exercises[i].sets[j].setNumber = uint8(j + 1)
+		}
+	}
+
+	ts.exercises = slices.DeleteFunc(ts.exercises, func(exercise SessionExercise) bool {
+		return len(exercise.sets) == 0
+	})
+	ts.renumberExercises()
+}
+
+// Finish отмечает время окончания. Сессии из кэша без времени начала считаются начатыми при создании.
+func (ts *TrainingSession) Finish(at time.Time) {
+	if ts.startedAt.IsZero() {
+		ts.startedAt = ts.createdAt
+	}
+	ts.finishedAt = at
+}
+
+// SetDetails задает вес тела и заметку, nil оставляет прежнее значение. Нулевой вес тела
+// означает, что он не указан.
+func (ts *TrainingSession) SetDetails(bodyweight *float32, notes *string) error {
+	if bodyweight != nil && *bodyweight < 0 {
+		return errs.ErrInvalidBodyweight
+	}
+
+	if bodyweight != nil {
+		ts.bodyweight = *bodyweight
+	}
+	if notes != nil {
+		ts.notes = *notes
+	}
+	return nil
+}
+
+// Rate ставит тренировке оценку от MinSessionRating до MaxSessionRating.
+func (ts *TrainingSession) Rate(rating uint8) error {
+	if rating < MinSessionRating || rating > MaxSessionRating {
+		return errs.ErrInvalidRating
+	}
+
+	ts.rating = rating
+	return nil
+}
+
+// ActiveExercise возвращает выбранное упражнение, а если оно не выбрано или удалено - последнее.
 func (ts *TrainingSession) ActiveExercise() *SessionExercise {
 	if len(ts.exercises) == 0 {
 		return nil
 	}
+
+	// у тренировок, начатых до выбора активного упражнения, ID упражнений пустые
+	if ts.activeExerciseID != uuid.Nil {
+		for i := range ts.exercises {
+			if ts.exercises[i].id == ts.activeExerciseID {
+				return &ts.exercises[i]
+			}
+		}
+	}
+
 	return &ts.exercises[len(ts.exercises)-1]
 }
 
-func (ts *TrainingSession) FindSetByMessageID(messageID int) *Set {
+func (ts *TrainingSession) ActiveExerciseID() uuid.UUID {
+	return ts.activeExerciseID
+}
+
+// SelectExercise делает упражнение с порядковым номером активным.
+func (ts *TrainingSession) SelectExercise(number uint8) error {
+	exercise, ok := ts.FindExercise(number)
+	if !ok {
+		return errs.ErrExerciseNotFound
+	}
+
+	ts.activeExerciseID = exercise.id
+	return nil
+}
+
+// MoveExercise сдвигает упражнение на offset позиций и перенумеровывает упражнения,
+// за границы списка упражнение не выходит.
+func (ts *TrainingSession) MoveExercise(number uint8, offset int) error {
+	from := slices.IndexFunc(ts.exercises, func(e SessionExercise) bool { return e.number == number })
+	if from < 0 {
+		return errs.ErrExerciseNotFound
+	}
+
+	to := min(max(from+offset, 0), len(ts.exercises)-1)
+	exercise := ts.exercises[from]
+	ts.exercises = slices.Insert(slices.Delete(ts.exercises, from, from+1), to, exercise)
+	ts.renumberExercises()
+
+	return nil
+}
+
+func (ts *TrainingSession) FindSetsByMessageID(messageID int) []*Set {
 	if messageID == 0 {
 		return nil
 	}
 
+	var sets []*Set
 	for i := range ts.exercises {
 		for j := range ts.exercises[i].sets {
 			if ts.exercises[i].sets[j].MessageID() == messageID {
-				return &ts.exercises[i].sets[j]
+				sets = append(sets, &ts.exercises[i].sets[j])
 			}
 		}
 	}
 
-	return nil
+	return sets
+}
+
+// SetDate переносит тренировку, время начала и окончания сдвигаются вместе с датой.
+func (ts *TrainingSession) SetDate(date time.Time) {
+	shift := date.Sub(ts.date)
+	if !ts.startedAt.IsZero() {
+		ts.startedAt = ts.startedAt.Add(shift)
+	}
+	if !ts.finishedAt.IsZero() {
+		ts.finishedAt = ts.finishedAt.Add(shift)
+	}
+	ts.date = date
+}
+
+func (ts *TrainingSession) IsEmpty() bool {
+	return ts.SetCount() == 0
+}
+
+func (ts *TrainingSession) FindExercise(number uint8) (*SessionExercise, bool) {
+	for i := range ts.exercises {
+		if ts.exercises[i].Number() == number {
+			return &ts.exercises[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// DeleteSet удаляет подход с перенумерацией, упражнение без подходов удаляется целиком.
+func (ts *TrainingSession) DeleteSet(setID uuid.UUID) error {
+	for i := range ts.exercises {
+		if !ts.exercises[i].deleteSet(setID) {
+			continue
+		}
+
+		if len(ts.exercises[i].sets) == 0 {
+			ts.deleteExerciseAt(i)
+		}
+		return nil
+	}
+
+	return errs.ErrSetNotFound
+}
+
+// DeleteExercise удаляет упражнение по порядковому номеру и перенумеровывает остальные.
+func (ts *TrainingSession) DeleteExercise(number uint8) error {
+	for i := range ts.exercises {
+		if ts.exercises[i].Number() == number {
+			ts.deleteExerciseAt(i)
+			return nil
+		}
+	}
+
+	return errs.ErrExerciseNotFound
+}
+
+// RemoveSet удаляет подход текущей тренировки с перенумерацией. Единственный подход
+// активного упражнения и подходы из плана тренера очищаются, чтобы следующий ввод снова их заполнил.
+func (ts *TrainingSession) RemoveSet(setID uuid.UUID) error {
+	for i := range ts.exercises {
+		for j := range ts.exercises[i].sets {
+			if set := &ts.exercises[i].sets[j]; set.id == setID && set.IsPlanned() {
+				set.clear()
+				return nil
+			}
+		}
+	}
+
+	if active := ts.ActiveExercise(); active != nil && len(active.sets) == 1 && active.sets[0].id == setID {
+		active.sets[0].clear()
+		return nil
+	}
+
+	return ts.DeleteSet(setID)
+}
+
+// UndoSets отменяет подходы, записанные одним сообщением, и возвращает их.
+func (ts *TrainingSession) UndoSets(messageID int) ([]Set, error) {
+	var undone []Set
+	for _, set := range ts.FindSetsByMessageID(messageID) {
+		undone = append(undone, *set)
+	}
+
+	if len(undone) == 0 {
+		return nil, errs.ErrSetNotFound
+	}
+
+	for _, set := range undone {
+		if err := ts.RemoveSet(set.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	return undone, nil
+}
+
+func (ts *TrainingSession) deleteExerciseAt(i int) {
+	ts.exercises = slices.Delete(ts.exercises, i, i+1)
+	ts.renumberExercises()
+}
+
+func (ts *TrainingSession) renumberExercises() {
+	for j := range ts.exercises {
+		ts.exercises[j].number = uint8(j + 1)
+	}
 }
 
 func (ts *TrainingSession) DeleteLastExercise(exerciseID uuid.UUID) error {
@@ -98,8 +458,10 @@ func (ts *TrainingSession) CreatedAt() time.Time {
 	return ts.createdAt
 }
 
+// AddExercise добавляет упражнение в конец тренировки и делает его активным.
 func (ts *TrainingSession) AddExercise(exercise *SessionExercise) {
 	ts.exercises = append(ts.exercises, *exercise)
+	ts.activeExerciseID = exercise.id
 }
 
 func NewTrainingSession(opts ...TrainingSessionOption) *TrainingSession {
@@ -117,6 +479,10 @@ type TrainingSessionInitSpecification struct {
 	Date      time.Time
 	Exercises []SessionExercise
 	Notes     string
+	GymID     uuid.UUID
+	StartedAt time.Time
+
+	AssignmentID uuid.UUID
 }
 
 func WithTrainingSessionInitSpec(spec TrainingSessionInitSpecification) TrainingSessionOption {
@@ -129,7 +495,13 @@ func WithTrainingSessionInitSpec(spec TrainingSessionInitSpecification) Training
 		ts.date = spec.Date
 		ts.exercises = copiedExercises
 		ts.notes = spec.Notes
+		ts.gymID = spec.GymID
+		ts.startedAt = spec.StartedAt
 		ts.createdAt = time.Now()
+		ts.assignmentID = spec.AssignmentID
+		if len(copiedExercises) > 0 {
+			ts.activeExerciseID = copiedExercises[0].id
+		}
 	}
 }
 
@@ -139,7 +511,26 @@ type TrainingSessionRestoreSpecification struct {
 	Date      time.Time
 	Exercises []SessionExercise
 	Notes     string
+	GymID     uuid.UUID
 	CreatedAt time.Time
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Bodyweight float32
+	Rating     uint8
+
+	LastActivityAt time.Time
+	RemindedAt     time.Time
+
+	ActiveExerciseID uuid.UUID
+
+	Version uint64
+
+	DashboardMessageID int
+
+	AssignmentID uuid.UUID
+
+	SharedSessionID uuid.UUID
 }
 
 func WithTrainingSessionRestoreSpec(spec TrainingSessionRestoreSpecification) TrainingSessionOption {
@@ -152,6 +543,18 @@ func WithTrainingSessionRestoreSpec(spec TrainingSessionRestoreSpecification) Tr
 		ts.date = spec.Date
 		ts.exercises = copiedExercises
 		ts.notes = spec.Notes
+		ts.gymID = spec.GymID
 		ts.createdAt = spec.CreatedAt
+		ts.startedAt = spec.StartedAt
+		ts.finishedAt = spec.FinishedAt
+		ts.bodyweight = spec.Bodyweight
+		ts.rating = spec.Rating
+		ts.lastActivityAt = spec.LastActivityAt
+		ts.remindedAt = spec.RemindedAt
+		ts.activeExerciseID = spec.ActiveExerciseID
+		ts.version = spec.Version
+		ts.dashboardMessageID = spec.DashboardMessageID
+		ts.assignmentID = spec.AssignmentID
+		ts.sharedSessionID = spec.SharedSessionID
 	}
 }
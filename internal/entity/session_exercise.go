@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
 
 type SessionExerciseOption func(o *SessionExercise)
 
@@ -35,10 +39,46 @@ func (se *SessionExercise) LastSet() *Set {
 	return &se.sets[len(se.sets)-1]
 }
 
+// FirstEmptySet возвращает первый подход без результата, nil если все подходы записаны.
+func (se *SessionExercise) FirstEmptySet() *Set {
+	for i := range se.sets {
+		if !se.sets[i].IsFilled() {
+			return &se.sets[i]
+		}
+	}
+
+	return nil
+}
+
 func (ts *SessionExercise) AddSet(set *Set) {
 	ts.sets = append(ts.sets, *set)
 }
 
+func (se *SessionExercise) FindSet(number uint8) (*Set, bool) {
+	for i := range se.sets {
+		if se.sets[i].Number() == number {
+			return &se.sets[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// deleteSet удаляет подход и перенумеровывает оставшиеся по порядку.
+func (se *SessionExercise) deleteSet(setID uuid.UUID) bool {
+	for i := range se.sets {
+		if se.sets[i].id == setID {
+			se.sets = slices.Delete(se.sets, i, i+1)
+			for j := range se.sets {
+				se.sets[j].setNumber = uint8(j + 1)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
 func (se *SessionExercise) TotalVolume() float32 {
 	totalVolume := float32(0)
 	for _, set := range se.sets {
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActiveExerciseWithoutIDs(t *testing.T) {
+	exercises := make([]SessionExercise, 0, 2)
+	for number := uint8(1); number <= 2; number++ {
+		exercise := NewExercise(WithExerciseInitSpec(ExerciseInitSpecification{Name: "Жим лежа"}))
+		exercises = append(exercises, *NewSessionExercise(exercise, nil, WithSessionExerciseRestoreSpec(SessionExerciseRestoreSpecification{
+			Number: number,
+		})))
+	}
+
+	session := NewTrainingSession(WithTrainingSessionRestoreSpec(TrainingSessionRestoreSpecification{
+		UserID:    "1",
+		Date:      time.Now(),
+		Exercises: exercises,
+	}))
+
+	active := session.ActiveExercise()
+	if active == nil || active.Number() != 2 {
+		t.Fatalf("active exercise = %v, want the last one", active)
+	}
+}
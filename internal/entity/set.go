@@ -19,6 +19,13 @@ type Set struct {
 	notes      string
 	messageID  int
 	createdAt  time.Time
+
+	// completedAt время записи результата, у заготовки и загруженных подходов пустое
+	completedAt time.Time
+
+	// plannedWeight и plannedReps подход из плана тренера, нули если подход не запланирован
+	plannedWeight float32
+	plannedReps   uint8
 }
 
 func (s *Set) ID() uuid.UUID {
@@ -61,6 +68,33 @@ func (s *Set) CreatedAt() time.Time {
 	return s.createdAt
 }
 
+func (s *Set) CompletedAt() time.Time {
+	return s.completedAt
+}
+
+func (s *Set) PlannedWeight() float32 {
+	return s.plannedWeight
+}
+
+func (s *Set) PlannedReps() uint8 {
+	return s.plannedReps
+}
+
+// IsPlanned сообщает, что подход взят из плана тренера.
+func (s *Set) IsPlanned() bool {
+	return s.plannedWeight != 0 || s.plannedReps != 0
+}
+
+// Complete отмечает время, когда подход был выполнен и записан.
+func (s *Set) Complete(at time.Time) {
+	s.completedAt = at
+}
+
+// IsFilled сообщает, что в подходе записан результат, а не пустая заготовка.
+func (s *Set) IsFilled() bool {
+	return s.weight != 0 || s.reps != 0
+}
+
 func (s *Set) SetWeight(weight float32) {
 	s.weight = weight
 }
@@ -81,6 +115,24 @@ func (s *Set) SetDifficulty(difficulty string) {
 	}
 }
 
+// Update заменяет результат подхода целиком, в отличие от SetNotes пустая заметка ее стирает.
+func (s *Set) Update(weight float32, reps uint8, difficulty, notes string) {
+	s.weight = weight
+	s.reps = reps
+	s.difficulty = difficulty
+	s.notes = notes
+}
+
+// clear возвращает подход в состояние заготовки, которую заполнит следующий ввод.
+func (s *Set) clear() {
+	s.weight = 0
+	s.reps = 0
+	s.difficulty = ""
+	s.notes = ""
+	s.messageID = 0
+	s.completedAt = time.Time{}
+}
+
 func (s *Set) SetMessageID(messageID int) {
 	s.messageID = messageID
 }
@@ -104,6 +156,11 @@ type SetInitSpecification struct {
 	Difficulty string
 	Notes      string
 	MessageID  int
+
+	CompletedAt time.Time
+
+	PlannedWeight float32
+	PlannedReps   uint8
 }
 
 func WithSetInitSpec(s SetInitSpecification) SetOption {
@@ -118,6 +175,9 @@ func WithSetInitSpec(s SetInitSpecification) SetOption {
 		o.notes = s.Notes
 		o.messageID = s.MessageID
 		o.createdAt = time.Now()
+		o.completedAt = s.CompletedAt
+		o.plannedWeight = s.PlannedWeight
+		o.plannedReps = s.PlannedReps
 	}
 }
 
@@ -132,6 +192,11 @@ type SetRestoreSpecification struct {
 	Notes      string
 	CreatedAt  time.Time
 	MessageID  int
+
+	CompletedAt time.Time
+
+	PlannedWeight float32
+	PlannedReps   uint8
 }
 
 func WithSetRestoreSpec(s SetRestoreSpecification) SetOption {
@@ -146,5 +211,8 @@ func WithSetRestoreSpec(s SetRestoreSpecification) SetOption {
 		o.notes = s.Notes
 		o.createdAt = s.CreatedAt
 		o.messageID = s.MessageID
+		o.completedAt = s.CompletedAt
+		o.plannedWeight = s.PlannedWeight
+		o.plannedReps = s.PlannedReps
 	}
 }
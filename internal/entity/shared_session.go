@@ -0,0 +1,340 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/errs"
+)
+
+// MaxSessionParticipants сколько человек может тренироваться в одной совместной тренировке.
+const MaxSessionParticipants = 4
+
+// SessionParticipant участник совместной тренировки и его состояние, которое у каждого свое.
+type SessionParticipant struct {
+	UserID string
+	Name   string
+	// SessionID тренировка участника, под этим ID она сохранится после завершения
+	SessionID    uuid.UUID
+	GymID        uuid.UUID
+	AssignmentID uuid.UUID
+	CreatedAt    time.Time
+	StartedAt    time.Time
+
+	LastActivityAt time.Time
+	RemindedAt     time.Time
+
+	ActiveExerciseID   uuid.UUID
+	DashboardMessageID int
+}
+
+type SharedSessionOption func(o *SharedSession)
+
+// SharedSession совместная тренировка: упражнения выбираются один раз для всех, а подходы
+// у каждого участника свои и различаются по UserID подхода.
+type SharedSession struct {
+	id           uuid.UUID
+	ownerID      string
+	date         time.Time
+	exercises    []SessionExercise
+	participants []SessionParticipant
+	createdAt    time.Time
+
+	// version версия в кэше, общая для всех участников
+	version uint64
+}
+
+func (ss *SharedSession) ID() uuid.UUID {
+	return ss.id
+}
+
+func (ss *SharedSession) OwnerID() string {
+	return ss.ownerID
+}
+
+func (ss *SharedSession) Date() time.Time {
+	return ss.date
+}
+
+func (ss *SharedSession) Exercises() []SessionExercise {
+	return ss.exercises
+}
+
+func (ss *SharedSession) Participants() []SessionParticipant {
+	return ss.participants
+}
+
+func (ss *SharedSession) CreatedAt() time.Time {
+	return ss.createdAt
+}
+
+func (ss *SharedSession) Version() uint64 {
+	return ss.version
+}
+
+func (ss *SharedSession) SetVersion(version uint64) {
+	ss.version = version
+}
+
+func (ss *SharedSession) IsEmpty() bool {
+	return len(ss.participants) == 0
+}
+
+func (ss *SharedSession) Participant(userID string) (*SessionParticipant, bool) {
+	idx := slices.IndexFunc(ss.participants, func(p SessionParticipant) bool { return p.UserID == userID })
+	if idx < 0 {
+		return nil, false
+	}
+
+	return &ss.participants[idx], true
+}
+
+// Partners возвращает остальных участников тренировки.
+func (ss *SharedSession) Partners(userID string) []SessionParticipant {
+	partners := make([]SessionParticipant, 0, len(ss.participants))
+	for _, p := range ss.participants {
+		if p.UserID != userID {
+			partners = append(partners, p)
+		}
+	}
+
+	return partners
+}
+
+// Join добавляет участника, его подходы начнутся с уже выбранных упражнений. Возвращает false,
+// если пользователь уже участвует.
+func (ss *SharedSession) Join(userID, name string, gymID uuid.UUID, at time.Time) (bool, error) {
+	if _, ok := ss.Participant(userID); ok {
+		return false, nil
+	}
+
+	if len(ss.participants) >= MaxSessionParticipants {
+		return false, errs.ErrSharedSessionFull
+	}
+
+	participant := SessionParticipant{
+		UserID:         userID,
+		Name:           name,
+		SessionID:      uuid.New(),
+		GymID:          gymID,
+		CreatedAt:      at,
+		StartedAt:      at,
+		LastActivityAt: at,
+	}
+	if len(ss.exercises) > 0 {
+		participant.ActiveExerciseID = ss.exercises[len(ss.exercises)-1].id
+	}
+
+	ss.participants = append(ss.participants, participant)
+	return true, nil
+}
+
+// Leave убирает участника вместе с его подходами. Упражнения остаются для остальных.
+func (ss *SharedSession) Leave(userID string) bool {
+	before := len(ss.participants)
+	ss.participants = slices.DeleteFunc(ss.participants, func(p SessionParticipant) bool { return p.UserID == userID })
+	if len(ss.participants) == before {
+		return false
+	}
+
+	for i := range ss.exercises {
+		ss.exercises[i].sets = slices.DeleteFunc(ss.exercises[i].sets, func(set Set) bool { return set.userID == userID })
+	}
+
+	return true
+}
+
+// View собирает тренировку участника: общие упражнения только с его подходами. В упражнении,
+// где у участника нет подходов, появляется пустой подход, который заполнит следующий ввод.
+func (ss *SharedSession) View(userID string) (*TrainingSession, bool) {
+	participant, ok := ss.Participant(userID)
+	if !ok {
+		return nil, false
+	}
+
+	exercises := make([]SessionExercise, 0, len(ss.exercises))
+	for _, exercise := range ss.exercises {
+		sets := make([]Set, 0, len(exercise.sets))
+		for _, set := range exercise.sets {
+			if set.userID == userID {
+				sets = append(sets, set)
+			}
+		}
+
+		if len(sets) == 0 {
+			sets = append(sets, *NewSet(WithSetInitSpec(SetInitSpecification{
+				UserID:     userID,
+				ExerciseID: exercise.Exercise.ID(),
+				Number:     1,
+			})))
+		}
+
+		exercises = append(exercises, *NewSessionExercise(exercise.Exercise, sets, WithSessionExerciseRestoreSpec(SessionExerciseRestoreSpecification{
+			ID:     exercise.id,
+			Number: exercise.number,
+		})))
+	}
+
+	return NewTrainingSession(WithTrainingSessionRestoreSpec(TrainingSessionRestoreSpecification{
+		ID:        participant.SessionID,
+		UserID:    userID,
+		Date:      ss.date,
+		Exercises: exercises,
+		GymID:     participant.GymID,
+		CreatedAt: participant.CreatedAt,
+		StartedAt: participant.StartedAt,
+
+		LastActivityAt: participant.LastActivityAt,
+		RemindedAt:     participant.RemindedAt,
+
+		ActiveExerciseID: participant.ActiveExerciseID,
+
+		Version: ss.version,
+
+		DashboardMessageID: participant.DashboardMessageID,
+
+		AssignmentID: participant.AssignmentID,
+
+		SharedSessionID: ss.id,
+	})), true
+}
+
+// Merge переносит изменения из тренировки участника: его подходы, порядок упражнений и новые
+// упражнения, которые становятся активными у всех. Убранное участником упражнение остается,
+// если в нем есть подходы других участников.
+func (ss *SharedSession) Merge(view *TrainingSession) bool {
+	userID := view.UserID()
+	participant, ok := ss.Participant(userID)
+	if !ok {
+		return false
+	}
+
+	participant.GymID = view.gymID
+	participant.StartedAt = view.startedAt
+	participant.LastActivityAt = view.lastActivityAt
+	participant.RemindedAt = view.remindedAt
+	participant.ActiveExerciseID = view.activeExerciseID
+	participant.DashboardMessageID = view.dashboardMessageID
+
+	othersSets := func(exercise SessionExercise) []Set {
+		return slices.DeleteFunc(slices.Clone(exercise.sets), func(set Set) bool { return set.userID == userID })
+	}
+
+	previous := make(map[uuid.UUID]SessionExercise, len(ss.exercises))
+	for _, exercise := range ss.exercises {
+		previous[exercise.id] = exercise
+	}
+
+	merged := make([]SessionExercise, 0, len(view.exercises))
+	added := uuid.Nil
+	for _, exercise := range view.exercises {
+		var sets []Set
+		if old, ok := previous[exercise.id]; ok {
+			sets = othersSets(old)
+			delete(previous, exercise.id)
+		} else {
+			added = exercise.id
+		}
+		sets = append(sets, exercise.sets...)
+
+		merged = append(merged, *NewSessionExercise(exercise.Exercise, sets, WithSessionExerciseRestoreSpec(SessionExerciseRestoreSpecification{
+			ID:     exercise.id,
+			Number: exercise.number,
+		})))
+	}
+
+	for i, exercise := range ss.exercises {
+		if _, removed := previous[exercise.id]; !removed {
+			continue
+		}
+		if sets := othersSets(exercise); len(sets) > 0 {
+			exercise.sets = sets
+			merged = slices.Insert(merged, min(i, len(merged)), exercise)
+		}
+	}
+
+	ss.exercises = merged
+	for i := range ss.exercises {
+		ss.exercises[i].number = uint8(i + 1)
+	}
+
+	if added != uuid.Nil {
+		for i := range ss.participants {
+			ss.participants[i].ActiveExerciseID = added
+		}
+	}
+
+	return true
+}
+
+func NewSharedSession(opts ...SharedSessionOption) *SharedSession {
+	session := &SharedSession{}
+
+	for _, opt := range opts {
+		opt(session)
+	}
+
+	return session
+}
+
+// SharedSessionInitSpecification текущая тренировка, которую владелец открывает для партнеров.
+type SharedSessionInitSpecification struct {
+	Session   *TrainingSession
+	OwnerName string
+}
+
+func WithSharedSessionInitSpec(spec SharedSessionInitSpecification) SharedSessionOption {
+	return func(ss *SharedSession) {
+		session := spec.Session
+
+		ss.id = uuid.New()
+		ss.ownerID = session.userID
+		ss.date = session.date
+		ss.createdAt = time.Now()
+		ss.version = session.version
+
+		ss.exercises = make([]SessionExercise, 0, len(session.exercises))
+		for _, exercise := range session.exercises {
+			exercise.sets = slices.Clone(exercise.sets)
+			ss.exercises = append(ss.exercises, exercise)
+		}
+
+		ss.participants = []SessionParticipant{{
+			UserID:             session.userID,
+			Name:               spec.OwnerName,
+			SessionID:          session.id,
+			GymID:              session.gymID,
+			AssignmentID:       session.assignmentID,
+			CreatedAt:          session.createdAt,
+			StartedAt:          session.startedAt,
+			LastActivityAt:     session.lastActivityAt,
+			RemindedAt:         session.remindedAt,
+			ActiveExerciseID:   session.activeExerciseID,
+			DashboardMessageID: session.dashboardMessageID,
+		}}
+	}
+}
+
+type SharedSessionRestoreSpecification struct {
+	ID           uuid.UUID
+	OwnerID      string
+	Date         time.Time
+	Exercises    []SessionExercise
+	Participants []SessionParticipant
+	CreatedAt    time.Time
+	Version      uint64
+}
+
+func WithSharedSessionRestoreSpec(spec SharedSessionRestoreSpecification) SharedSessionOption {
+	return func(ss *SharedSession) {
+		ss.id = spec.ID
+		ss.ownerID = spec.OwnerID
+		ss.date = spec.Date
+		ss.exercises = spec.Exercises
+		ss.participants = spec.Participants
+		ss.createdAt = spec.CreatedAt
+		ss.version = spec.Version
+	}
+}
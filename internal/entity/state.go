@@ -11,4 +11,11 @@ const (
 	StateAwaitingExerciseProgression UserState = "awaiting_exercise_progression"
 	StateAwaitingExerciseHistory     UserState = "awaiting_exercise_history"
 	StateAwaitingOneRMInput          UserState = "awaiting_one_rm_input"
+	StateAwaitingPromoteExercise     UserState = "awaiting_promote_exercise_input"
+	StateAwaitingManageExercise      UserState = "awaiting_manage_exercise_input"
+	StateAwaitingExerciseEditInput   UserState = "awaiting_exercise_edit_input"
+	StateAwaitingGymInput            UserState = "awaiting_gym_input"
+	StateAwaitingTrainingEditInput   UserState = "awaiting_training_edit_input"
+	StateAwaitingCoachComment        UserState = "awaiting_coach_comment"
+	StateAwaitingWorkoutPlan         UserState = "awaiting_workout_plan"
 )
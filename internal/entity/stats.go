@@ -8,3 +8,144 @@ type ExerciseProgression struct {
 	Weight       float32
 	Reps         uint8
 }
+
+// ExerciseUsage как часто и как давно пользователь выполнял упражнение.
+type ExerciseUsage struct {
+	Sessions   int
+	LastUsedAt time.Time
+}
+
+// SessionsSummary сводка по метаданным тренировок: учитываются только тренировки, где они указаны.
+type SessionsSummary struct {
+	Sessions        int
+	AvgDuration     time.Duration
+	AvgRating       float32
+	FirstBodyweight float32
+	LastBodyweight  float32
+}
+
+// SummarizeSessions считает сводку по тренировкам, упорядоченным по дате.
+func SummarizeSessions(sessions []TrainingSession) SessionsSummary {
+	summary := SessionsSummary{Sessions: len(sessions)}
+
+	var (
+		totalDuration time.Duration
+		timed, rated  int
+		totalRating   int
+	)
+
+	for _, session := range sessions {
+		if d := session.Duration(); d > 0 {
+			totalDuration += d
+			timed++
+		}
+
+		if session.Rating() > 0 {
+			totalRating += int(session.Rating())
+			rated++
+		}
+
+		if session.Bodyweight() > 0 {
+			if summary.FirstBodyweight == 0 {
+				summary.FirstBodyweight = session.Bodyweight()
+			}
+			summary.LastBodyweight = session.Bodyweight()
+		}
+	}
+
+	if timed > 0 {
+		summary.AvgDuration = totalDuration / time.Duration(timed)
+	}
+	if rated > 0 {
+		summary.AvgRating = float32(totalRating) / float32(rated)
+	}
+
+	return summary
+}
+
+// ExerciseRest отдых между подходами одного упражнения тренировки.
+type ExerciseRest struct {
+	Number    uint8
+	Name      string
+	Intervals []time.Duration
+}
+
+func (r ExerciseRest) Avg() time.Duration {
+	return avgDuration(r.Intervals)
+}
+
+// SessionPace темп тренировки по времени выполнения подходов.
+type SessionPace struct {
+	// TimeUnderSession от начала тренировки до последнего подхода
+	TimeUnderSession time.Duration
+	AvgRest          time.Duration
+	// Density объем в кг за минуту TimeUnderSession
+	Density   float32
+	Exercises []ExerciseRest
+}
+
+// Pace считает отдых и плотность по подходам с известным временем выполнения. Подходы,
+// записанные одним сообщением, имеют одно время и интервала отдыха между ними нет.
+func (ts *TrainingSession) Pace() SessionPace {
+	var (
+		pace      SessionPace
+		all       []time.Duration
+		first     time.Time
+		lastSetAt time.Time
+	)
+
+	for _, exercise := range ts.exercises {
+		rest := ExerciseRest{Number: exercise.Number(), Name: exercise.Name()}
+
+		var prev time.Time
+		for _, set := range exercise.Sets() {
+			at := set.CompletedAt()
+			if at.IsZero() {
+				continue
+			}
+
+			if first.IsZero() || at.Before(first) {
+				first = at
+			}
+			if at.After(lastSetAt) {
+				lastSetAt = at
+			}
+
+			if !prev.IsZero() && at.After(prev) {
+				rest.Intervals = append(rest.Intervals, at.Sub(prev))
+			}
+			prev = at
+		}
+
+		all = append(all, rest.Intervals...)
+		pace.Exercises = append(pace.Exercises, rest)
+	}
+
+	start := ts.startedAt
+	if start.IsZero() || start.After(first) {
+		start = first
+	}
+
+	if !lastSetAt.IsZero() {
+		pace.TimeUnderSession = lastSetAt.Sub(start)
+	}
+	if minutes := pace.TimeUnderSession.Minutes(); minutes >= 1 {
+		pace.Density = ts.TotalVolume() / float32(minutes)
+	}
+	pace.AvgRest = avgDuration(all)
+
+	return pace
+}
+
+func avgDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	return total / time.Duration(len(durations))
+}
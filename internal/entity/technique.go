@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxTechniqueMedia ограничивает число вложений в карточке техники.
+const MaxTechniqueMedia = 10
+
+type MediaType string
+
+const (
+	MediaPhoto     MediaType = "photo"
+	MediaAnimation MediaType = "animation"
+	MediaVideo     MediaType = "video"
+)
+
+// TechniqueMedia вложение карточки: file_id Telegram или путь к загруженному файлу.
+type TechniqueMedia struct {
+	Type   MediaType
+	FileID string
+	Path   string
+}
+
+type TechniqueOption func(o *Technique)
+
+// Technique карточка техники выполнения упражнения.
+type Technique struct {
+	exerciseID  uuid.UUID
+	description string
+	cues        []string
+	mistakes    []string
+	media       []TechniqueMedia
+	updatedAt   time.Time
+}
+
+func (t *Technique) ExerciseID() uuid.UUID {
+	return t.exerciseID
+}
+
+func (t *Technique) Description() string {
+	return t.description
+}
+
+func (t *Technique) Cues() []string {
+	return t.cues
+}
+
+func (t *Technique) Mistakes() []string {
+	return t.mistakes
+}
+
+func (t *Technique) Media() []TechniqueMedia {
+	return t.media
+}
+
+func (t *Technique) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *Technique) IsEmpty() bool {
+	return t.description == "" && len(t.cues) == 0 && len(t.mistakes) == 0 && len(t.media) == 0
+}
+
+func (t *Technique) SetText(description string, cues, mistakes []string) {
+	t.description = description
+	t.cues = cues
+	t.mistakes = mistakes
+	t.updatedAt = time.Now()
+}
+
+func (t *Technique) AddMedia(media TechniqueMedia) bool {
+	if len(t.media) >= MaxTechniqueMedia {
+		return false
+	}
+
+	t.media = append(t.media, media)
+	t.updatedAt = time.Now()
+
+	return true
+}
+
+func NewTechnique(opts ...TechniqueOption) *Technique {
+	technique := &Technique{}
+
+	for _, opt := range opts {
+		opt(technique)
+	}
+
+	return technique
+}
+
+type TechniqueInitSpecification struct {
+	ExerciseID uuid.UUID
+}
+
+func WithTechniqueInitSpec(s TechniqueInitSpecification) TechniqueOption {
+	return func(o *Technique) {
+		o.exerciseID = s.ExerciseID
+		o.updatedAt = time.Now()
+	}
+}
+
+type TechniqueRestoreSpecification struct {
+	ExerciseID  uuid.UUID
+	Description string
+	Cues        []string
+	Mistakes    []string
+	Media       []TechniqueMedia
+	UpdatedAt   time.Time
+}
+
+func WithTechniqueRestoreSpec(s TechniqueRestoreSpecification) TechniqueOption {
+	return func(o *Technique) {
+		o.exerciseID = s.ExerciseID
+		o.description = s.Description
+		o.cues = s.Cues
+		o.mistakes = s.Mistakes
+		o.media = s.Media
+		o.updatedAt = s.UpdatedAt
+	}
+}
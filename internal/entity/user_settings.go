@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserSettingsOption func(o *UserSettings)
+
+type UserSettings struct {
+	userID    string
+	locale    string
+	favorites []uuid.UUID
+	gymID     uuid.UUID
+	byGym     bool
+	updatedAt time.Time
+
+	idleAction IdleSessionAction
+}
+
+func (us *UserSettings) UserID() string {
+	return us.userID
+}
+
+// Locale возвращает выбранный вручную язык, пустая строка означает язык клиента Telegram.
+func (us *UserSettings) Locale() string {
+	return us.locale
+}
+
+// FavoriteExercises возвращает закрепленные упражнения в порядке закрепления.
+func (us *UserSettings) FavoriteExercises() []uuid.UUID {
+	return us.favorites
+}
+
+func (us *UserSettings) IsFavorite(exerciseID uuid.UUID) bool {
+	return slices.Contains(us.favorites, exerciseID)
+}
+
+func (us *UserSettings) PinExercise(exerciseID uuid.UUID) {
+	if us.IsFavorite(exerciseID) {
+		return
+	}
+	us.favorites = append(us.favorites, exerciseID)
+	us.updatedAt = time.Now()
+}
+
+func (us *UserSettings) UnpinExercise(exerciseID uuid.UUID) {
+	us.favorites = slices.DeleteFunc(us.favorites, func(id uuid.UUID) bool {
+		return id == exerciseID
+	})
+	us.updatedAt = time.Now()
+}
+
+// GymID возвращает текущий зал пользователя, uuid.Nil если зал не выбран.
+func (us *UserSettings) GymID() uuid.UUID {
+	return us.gymID
+}
+
+func (us *UserSettings) SetGym(gymID uuid.UUID) {
+	us.gymID = gymID
+	us.updatedAt = time.Now()
+}
+
+func (us *UserSettings) AnalyticsByGym() bool {
+	return us.byGym
+}
+
+func (us *UserSettings) SetAnalyticsByGym(enabled bool) {
+	us.byGym = enabled
+	us.updatedAt = time.Now()
+}
+
+// AnalyticsGymID возвращает зал, которым ограничена статистика, uuid.Nil - все залы.
+func (us *UserSettings) AnalyticsGymID() uuid.UUID {
+	if !us.byGym {
+		return uuid.Nil
+	}
+
+	return us.gymID
+}
+
+// IdleSessionAction что делать с брошенной тренировкой, по умолчанию она завершается.
+func (us *UserSettings) IdleSessionAction() IdleSessionAction {
+	if us.idleAction == "" {
+		return IdleSessionFinish
+	}
+
+	return us.idleAction
+}
+
+func (us *UserSettings) SetIdleSessionAction(action IdleSessionAction) {
+	us.idleAction = action
+	us.updatedAt = time.Now()
+}
+
+func (us *UserSettings) UpdatedAt() time.Time {
+	return us.updatedAt
+}
+
+func (us *UserSettings) SetLocale(locale string) {
+	us.locale = locale
+	us.updatedAt = time.Now()
+}
+
+func NewUserSettings(opts ...UserSettingsOption) *UserSettings {
+	settings := &UserSettings{}
+
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	return settings
+}
+
+type UserSettingsInitSpecification struct {
+	UserID string
+}
+
+func WithUserSettingsInitSpec(s UserSettingsInitSpecification) UserSettingsOption {
+	return func(o *UserSettings) {
+		o.userID = s.UserID
+		o.updatedAt = time.Now()
+	}
+}
+
+type UserSettingsRestoreSpecification struct {
+	UserID            string
+	Locale            string
+	FavoriteExercises []uuid.UUID
+	GymID             uuid.UUID
+	AnalyticsByGym    bool
+	UpdatedAt         time.Time
+
+	IdleSessionAction IdleSessionAction
+}
+
+func WithUserSettingsRestoreSpec(s UserSettingsRestoreSpecification) UserSettingsOption {
+	return func(o *UserSettings) {
+		o.userID = s.UserID
+		o.locale = s.Locale
+		o.favorites = s.FavoriteExercises
+		o.gymID = s.GymID
+		o.byGym = s.AnalyticsByGym
+		o.updatedAt = s.UpdatedAt
+		o.idleAction = s.IdleSessionAction
+	}
+}
@@ -0,0 +1,236 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssignedWorkoutStatus состояние тренировки, назначенной тренером.
+type AssignedWorkoutStatus string
+
+const (
+	// AssignedWorkoutPending назначена, спортсмен еще не начинал
+	AssignedWorkoutPending   AssignedWorkoutStatus = "assigned"
+	AssignedWorkoutStarted   AssignedWorkoutStatus = "started"
+	AssignedWorkoutCompleted AssignedWorkoutStatus = "completed"
+)
+
+type PlannedSet struct {
+	Weight float32
+	Reps   uint8
+}
+
+type PlannedExercise struct {
+	ExerciseID uuid.UUID
+	Name       string
+	Sets       []PlannedSet
+}
+
+// WorkoutPlan тренировка, которую тренер расписал на дату, до назначения спортсменам.
+type WorkoutPlan struct {
+	Date      time.Time
+	Exercises []PlannedExercise
+}
+
+type AssignedWorkoutOption func(o *AssignedWorkout)
+
+// AssignedWorkout план тренировки от тренера спортсмену на конкретный день.
+type AssignedWorkout struct {
+	id          uuid.UUID
+	coachID     string
+	coachName   string
+	athleteID   string
+	date        time.Time
+	exercises   []PlannedExercise
+	status      AssignedWorkoutStatus
+	sessionID   uuid.UUID
+	createdAt   time.Time
+	deliveredAt time.Time
+	completedAt time.Time
+}
+
+func (w *AssignedWorkout) ID() uuid.UUID {
+	return w.id
+}
+
+func (w *AssignedWorkout) CoachID() string {
+	return w.coachID
+}
+
+func (w *AssignedWorkout) CoachName() string {
+	return w.coachName
+}
+
+func (w *AssignedWorkout) AthleteID() string {
+	return w.athleteID
+}
+
+func (w *AssignedWorkout) Date() time.Time {
+	return w.date
+}
+
+func (w *AssignedWorkout) Exercises() []PlannedExercise {
+	return w.exercises
+}
+
+func (w *AssignedWorkout) Status() AssignedWorkoutStatus {
+	return w.status
+}
+
+// SessionID тренировка спортсмена по плану, uuid.Nil пока он ее не начал.
+func (w *AssignedWorkout) SessionID() uuid.UUID {
+	return w.sessionID
+}
+
+func (w *AssignedWorkout) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// DeliveredAt когда спортсмен получил кнопку начала тренировки.
+func (w *AssignedWorkout) DeliveredAt() time.Time {
+	return w.deliveredAt
+}
+
+func (w *AssignedWorkout) CompletedAt() time.Time {
+	return w.completedAt
+}
+
+func (w *AssignedWorkout) IsCompleted() bool {
+	return w.status == AssignedWorkoutCompleted
+}
+
+func (w *AssignedWorkout) MarkDelivered(at time.Time) {
+	w.deliveredAt = at
+}
+
+// Start привязывает к плану начатую спортсменом тренировку. Брошенную тренировку по плану можно начать заново.
+func (w *AssignedWorkout) Start(sessionID uuid.UUID) {
+	w.status = AssignedWorkoutStarted
+	w.sessionID = sessionID
+}
+
+func (w *AssignedWorkout) Complete(sessionID uuid.UUID, at time.Time) {
+	w.status = AssignedWorkoutCompleted
+	w.sessionID = sessionID
+	w.completedAt = at
+}
+
+// SetComparison подход плана и фактический подход, любой из них может отсутствовать.
+type SetComparison struct {
+	Planned *PlannedSet
+	Actual  *Set
+}
+
+type ExerciseComparison struct {
+	Name string
+	Sets []SetComparison
+}
+
+// Compare сопоставляет план с выполненной тренировкой: упражнения по порядку плана и
+// номеру подхода, упражнения вне плана идут в конце.
+func (w *AssignedWorkout) Compare(session TrainingSession) []ExerciseComparison {
+	used := make([]bool, len(session.exercises))
+	comparisons := make([]ExerciseComparison, 0, len(w.exercises))
+
+	for i := range w.exercises {
+		planned := &w.exercises[i]
+		comparison := ExerciseComparison{Name: planned.Name}
+
+		var actual []Set
+		for j := range session.exercises {
+			if !used[j] && session.exercises[j].Exercise.ID() == planned.ExerciseID {
+				used[j] = true
+				actual = session.exercises[j].sets
+				break
+			}
+		}
+
+		for k := 0; k < max(len(planned.Sets), len(actual)); k++ {
+			var set SetComparison
+			if k < len(planned.Sets) {
+				set.Planned = &planned.Sets[k]
+			}
+			if k < len(actual) {
+				set.Actual = &actual[k]
+			}
+			comparison.Sets = append(comparison.Sets, set)
+		}
+
+		comparisons = append(comparisons, comparison)
+	}
+
+	for j := range session.exercises {
+		if used[j] {
+			continue
+		}
+
+		comparison := ExerciseComparison{Name: session.exercises[j].Name()}
+		for k := range session.exercises[j].sets {
+			comparison.Sets = append(comparison.Sets, SetComparison{Actual: &session.exercises[j].sets[k]})
+		}
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons
+}
+
+func NewAssignedWorkout(opts ...AssignedWorkoutOption) *AssignedWorkout {
+	workout := &AssignedWorkout{}
+
+	for _, opt := range opts {
+		opt(workout)
+	}
+
+	return workout
+}
+
+type AssignedWorkoutInitSpecification struct {
+	CoachID   string
+	CoachName string
+	AthleteID string
+	Plan      WorkoutPlan
+}
+
+func WithAssignedWorkoutInitSpec(s AssignedWorkoutInitSpecification) AssignedWorkoutOption {
+	return func(o *AssignedWorkout) {
+		o.id = uuid.New()
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.athleteID = s.AthleteID
+		o.date = s.Plan.Date
+		o.exercises = s.Plan.Exercises
+		o.status = AssignedWorkoutPending
+		o.createdAt = time.Now()
+	}
+}
+
+type AssignedWorkoutRestoreSpecification struct {
+	ID          uuid.UUID
+	CoachID     string
+	CoachName   string
+	AthleteID   string
+	Date        time.Time
+	Exercises   []PlannedExercise
+	Status      AssignedWorkoutStatus
+	SessionID   uuid.UUID
+	CreatedAt   time.Time
+	DeliveredAt time.Time
+	CompletedAt time.Time
+}
+
+func WithAssignedWorkoutRestoreSpec(s AssignedWorkoutRestoreSpecification) AssignedWorkoutOption {
+	return func(o *AssignedWorkout) {
+		o.id = s.ID
+		o.coachID = s.CoachID
+		o.coachName = s.CoachName
+		o.athleteID = s.AthleteID
+		o.date = s.Date
+		o.exercises = s.Exercises
+		o.status = s.Status
+		o.sessionID = s.SessionID
+		o.createdAt = s.CreatedAt
+		o.deliveredAt = s.DeliveredAt
+		o.completedAt = s.CompletedAt
+	}
+}
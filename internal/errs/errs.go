@@ -5,11 +5,44 @@ import (
 )
 
 var (
-	ErrExerciseAlreadyExists = fmt.Errorf("exercise already exists")
-	ErrInvalidEventData      = fmt.Errorf("invalid event data: missing UserID or Text")
-	ErrTrainingStarted       = fmt.Errorf("training is already started")
-	ErrSessionNotFound       = fmt.Errorf("session not found")
-	ErrExerciseNotFound      = fmt.Errorf("exercise not found")
-	ErrSetNotFound           = fmt.Errorf("set not found")
-	ErrFailedToInsertData    = fmt.Errorf("failed to insert training data")
+	ErrExerciseAlreadyExists   = fmt.Errorf("exercise already exists")
+	ErrInvalidEventData        = fmt.Errorf("invalid event data: missing UserID or Text")
+	ErrTrainingStarted         = fmt.Errorf("training is already started")
+	ErrSessionNotFound         = fmt.Errorf("session not found")
+	ErrSessionConflict         = fmt.Errorf("session was modified concurrently")
+	ErrExerciseNotFound        = fmt.Errorf("exercise not found")
+	ErrExerciseAlreadyPublic   = fmt.Errorf("exercise is already public")
+	ErrExerciseArchived        = fmt.Errorf("exercise is archived")
+	ErrInvalidExerciseMerge    = fmt.Errorf("exercises cannot be merged")
+	ErrUnknownMuscleGroup      = fmt.Errorf("unknown muscle group")
+	ErrSetNotFound             = fmt.Errorf("set not found")
+	ErrGymNotFound             = fmt.Errorf("gym not found")
+	ErrTechniqueNotFound       = fmt.Errorf("technique not found")
+	ErrTechniqueMediaLimit     = fmt.Errorf("too many technique media")
+	ErrUnknownEquipment        = fmt.Errorf("unknown equipment")
+	ErrInvalidSetFormat        = fmt.Errorf("invalid set format")
+	ErrInvalidRating           = fmt.Errorf("invalid session rating")
+	ErrInvalidBodyweight       = fmt.Errorf("invalid bodyweight")
+	ErrUnknownIdleAction       = fmt.Errorf("unknown idle session action")
+	ErrCoachLinkNotFound       = fmt.Errorf("coach link not found")
+	ErrCoachLinkExists         = fmt.Errorf("athlete is already linked to the coach")
+	ErrCoachSelfLink           = fmt.Errorf("cannot coach yourself")
+	ErrInvalidComment          = fmt.Errorf("invalid comment")
+	ErrAssignedWorkoutNotFound = fmt.Errorf("assigned workout not found")
+	ErrWorkoutCompleted        = fmt.Errorf("assigned workout is already completed")
+	ErrNoAthletesSelected      = fmt.Errorf("no athletes selected")
+	ErrWorkoutDateInPast       = fmt.Errorf("workout date is in the past")
+	ErrGroupNotFound           = fmt.Errorf("group not found")
+	ErrNotGroupMember          = fmt.Errorf("user is not a group member")
+	ErrTooManyGroupLifts       = fmt.Errorf("too many group lifts")
+	ErrSharedSessionNotFound   = fmt.Errorf("shared session not found")
+	ErrSharedSessionFull       = fmt.Errorf("shared session is full")
+	ErrUserSettingsNotFound    = fmt.Errorf("user settings not found")
+	ErrUnsupportedLocale       = fmt.Errorf("unsupported locale")
+	ErrFailedToInsertData      = fmt.Errorf("failed to insert training data")
+	ErrUnsupportedFile         = fmt.Errorf("unsupported file type")
+	ErrMigrationLocked         = fmt.Errorf("migrations are locked by another process")
+	ErrFileTooLarge            = fmt.Errorf("file is too large")
+	ErrNoTrainingData          = fmt.Errorf("no trainings to upload")
+	ErrSearchQueryTooShort     = fmt.Errorf("search query is too short")
 )
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"gymnote/internal/entity"
 )
 
@@ -16,11 +18,13 @@ func New() *formatter {
 	return &formatter{}
 }
 
-func (f *formatter) FormatTrainingLogs(sessions []entity.TrainingSession) string {
+// FormatTrainingLogs выводит тренировки, gymNames - названия залов по ID для строки с деталями.
+func (f *formatter) FormatTrainingLogs(sessions []entity.TrainingSession, gymNames map[uuid.UUID]string) string {
 	var sb strings.Builder
 
 	for _, session := range sessions {
 		sb.WriteString(fmt.Sprintf("%s\n", session.Date().Format(time.DateOnly)))
+		sb.WriteString(formatSessionDetails(session, gymNames[session.GymID()]))
 
 		for _, ex := range session.Exercises() {
 			setStrings := []string{}
@@ -41,6 +45,48 @@ func (f *formatter) FormatTrainingLogs(sessions []entity.TrainingSession) string
 	return sb.String()
 }
 
+// formatSessionDetails выводит зал, время, вес тела, оценку и заметку строками-комментариями,
+// чтобы текст тренировки можно было загрузить обратно через /upload_training.
+func formatSessionDetails(session entity.TrainingSession, gymName string) string {
+	var details []string
+
+	if gymName != "" {
+		details = append(details, "📍 "+gymName)
+	}
+	if d := session.Duration(); d > 0 {
+		details = append(details, fmt.Sprintf("🕒 %s-%s (%s)",
+			session.StartedAt().Format("15:04"), session.FinishedAt().Format("15:04"), FormatDuration(d)))
+	}
+	if session.Bodyweight() > 0 {
+		details = append(details, "⚖️ "+formatWeight(session.Bodyweight()))
+	}
+	if session.Rating() > 0 {
+		details = append(details, fmt.Sprintf("⭐ %d/%d", session.Rating(), entity.MaxSessionRating))
+	}
+
+	var sb strings.Builder
+	if len(details) > 0 {
+		sb.WriteString("# " + strings.Join(details, " · ") + "\n")
+	}
+	if session.Notes() != "" {
+		sb.WriteString("# 📝 " + strings.ReplaceAll(session.Notes(), "\n", " ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// FormatDuration выводит длительность в виде ч:мм.
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// FormatRest выводит интервал отдыха в виде м:сс.
+func FormatRest(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
 func FormatWeightFloat(v float64) string {
 	if math.Mod(v, 1) == 0 {
 		return fmt.Sprintf("%.0f", v)
@@ -12,33 +12,103 @@ import (
 	"gymnote/internal/chart"
 	"gymnote/internal/config"
 	"gymnote/internal/entity"
+	"gymnote/internal/i18n"
 )
 
 type CommandHandler func(*tgbotapi.Message)
 type CallbackHandler func(*tgbotapi.CallbackQuery)
 
 type Formatter interface {
-	FormatTrainingLogs(sessions []entity.TrainingSession) string
+	FormatTrainingLogs(sessions []entity.TrainingSession, gymNames map[uuid.UUID]string) string
 	FormatLastSets(sessions []entity.ExerciseProgression) string
 }
 type ChartService interface {
 	GenerateLinearChart(config chart.LinearChartConfig) error
 }
 type TrainingService interface {
-	ParseTraining(ctx context.Context, e entity.Event) (*entity.TrainingSession, error)
+	ParseTrainings(ctx context.Context, e entity.Event, onProgress func(done, total int)) ([]entity.TrainingSession, error)
 	GetExerciseProgression(ctx context.Context, userID string, exerciseID uuid.UUID) ([]entity.ExerciseProgression, error)
 	GetTrainingSessions(ctx context.Context, userID string, fromDate, toDate *time.Time) ([]entity.TrainingSession, error)
 	GetLastSetsForExercise(ctx context.Context, userID string, exerciseID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error)
 	DeleteExercise(ctx context.Context, userID string, exerciseID uuid.UUID) error
-	CreateExercise(ctx context.Context, name string, muscleGroup string, equipment string) error
+	CreateExercise(ctx context.Context, ownerID string, name string, muscleGroup string, equipment string, secondary []entity.SecondaryMuscle) error
+	GetPrivateExercisesByName(ctx context.Context, name string) ([]entity.Exercise, error)
+	PromoteExercise(ctx context.Context, exerciseID uuid.UUID) (*entity.Exercise, error)
+	GetExercise(ctx context.Context, exerciseID uuid.UUID) (*entity.Exercise, error)
+	RenameExercise(ctx context.Context, actorID string, exerciseID uuid.UUID, name string) (*entity.Exercise, error)
+	ChangeExerciseMuscleGroup(ctx context.Context, actorID string, exerciseID uuid.UUID, muscleGroup string) (*entity.Exercise, error)
+	ChangeExerciseEquipment(ctx context.Context, actorID string, exerciseID uuid.UUID, equipment string) (*entity.Exercise, error)
+	ArchiveExercise(ctx context.Context, actorID string, exerciseID uuid.UUID) (*entity.Exercise, error)
+	MergeExercises(ctx context.Context, actorID string, sourceID, targetID uuid.UUID) (*entity.Exercise, error)
 	StartTraining(ctx context.Context, userID string) (*entity.TrainingSession, error)
 	AddTrainingExercise(ctx context.Context, userID string, exerciseID uuid.UUID) error
-	AddOrUpdateSet(ctx context.Context, userID string, messageID int, weight float32, reps uint8, notes string) error
+	AddOrUpdateSet(ctx context.Context, userID string, messageID int, input string) ([]entity.Set, error)
+	UndoSets(ctx context.Context, userID string, messageID int) ([]entity.Set, error)
+	DeleteSessionSet(ctx context.Context, userID string, setID uuid.UUID) (*entity.TrainingSession, error)
+	SelectSessionExercise(ctx context.Context, userID string, number uint8) (*entity.TrainingSession, error)
+	MoveSessionExercise(ctx context.Context, userID string, number uint8, offset int) (*entity.TrainingSession, error)
+	DeleteSessionExercise(ctx context.Context, userID string, number uint8) (*entity.TrainingSession, error)
+	SweepIdleSessions(ctx context.Context, remindAfter, closeAfter time.Duration) ([]entity.IdleSweep, error)
+	KeepSession(ctx context.Context, userID string) error
+	SetIdleSessionAction(ctx context.Context, userID string, action entity.IdleSessionAction) error
 	EndSession(ctx context.Context, userID string) (*entity.TrainingSession, error)
 	GetCurrentSession(ctx context.Context, userID string) (*entity.TrainingSession, error)
-	ClearSession(ctx context.Context, userID string) error
-	GetExercisesByMuscleGroup(ctx context.Context, muscleGroup string) ([]entity.Exercise, error)
-	UpdateSetFromMessage(ctx context.Context, userID string, messageID int, weight float32, reps uint8, notes string) error
+	SetDashboardMessage(ctx context.Context, userID string, messageID int) error
+	ClearSession(ctx context.Context, userID string) (*entity.TrainingSession, error)
+	GetMuscleGroups(ctx context.Context) ([]entity.MuscleGroup, error)
+	GetExercisesByMuscleGroup(ctx context.Context, userID string, muscleGroup string) ([]entity.Exercise, error)
+	SearchExercises(ctx context.Context, userID string, query string) ([]entity.Exercise, error)
+	GetFavoriteExercises(ctx context.Context, userID string) ([]entity.Exercise, error)
+	ToggleFavoriteExercise(ctx context.Context, userID string, exerciseID uuid.UUID) (bool, error)
+	UpdateSetFromMessage(ctx context.Context, userID string, messageID int, input string) error
+	GetUserSettings(ctx context.Context, userID string) (*entity.UserSettings, error)
+	SetUserLocale(ctx context.Context, userID string, locale string) error
+	GetEquipmentTypes(ctx context.Context) ([]string, error)
+	GetGyms(ctx context.Context, userID string) ([]entity.Gym, error)
+	GetCurrentGym(ctx context.Context, userID string) (*entity.Gym, error)
+	CreateGym(ctx context.Context, userID, name string, equipment []string) (*entity.Gym, error)
+	DeleteGym(ctx context.Context, userID string, gymID uuid.UUID) error
+	SelectGym(ctx context.Context, userID string, gymID uuid.UUID) error
+	SetAnalyticsByGym(ctx context.Context, userID string, enabled bool) error
+	FilterExercisesByGym(ctx context.Context, userID string, exercises []entity.Exercise) ([]entity.Exercise, error)
+	GetTechnique(ctx context.Context, exerciseID uuid.UUID) (*entity.Technique, error)
+	UpdateTechniqueText(ctx context.Context, actorID string, exerciseID uuid.UUID, description string, cues, mistakes []string) (*entity.Technique, error)
+	AddTechniqueMedia(ctx context.Context, actorID string, exerciseID uuid.UUID, media entity.TechniqueMedia) (*entity.Technique, error)
+	GetTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) (*entity.TrainingSession, error)
+	UpdateTrainingSet(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber, setNumber uint8, input string) (*entity.TrainingSession, error)
+	DeleteTrainingSet(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber, setNumber uint8) (*entity.TrainingSession, error)
+	DeleteTrainingExercise(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber uint8) (*entity.TrainingSession, error)
+	ChangeTrainingDate(ctx context.Context, userID string, sessionID uuid.UUID, date time.Time) (*entity.TrainingSession, error)
+	DeleteTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) error
+	RateTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID, rating uint8) (*entity.TrainingSession, error)
+	UpdateTrainingDetails(ctx context.Context, userID string, sessionID uuid.UUID, bodyweight *float32, notes *string) (*entity.TrainingSession, error)
+	CreateCoachInvite(ctx context.Context, coachID, coachName string) (*entity.CoachLink, error)
+	GetCoachInvite(ctx context.Context, inviteID uuid.UUID) (*entity.CoachLink, error)
+	AcceptCoachInvite(ctx context.Context, inviteID uuid.UUID, athleteID, athleteName string) (*entity.CoachLink, error)
+	RevokeCoachLink(ctx context.Context, userID string, linkID uuid.UUID) (*entity.CoachLink, error)
+	GetCoachLinks(ctx context.Context, userID string) ([]entity.CoachLink, error)
+	GetAthleteTrainingSessions(ctx context.Context, coachID, athleteID string, fromDate, toDate *time.Time) ([]entity.TrainingSession, error)
+	GetAthleteExerciseProgression(ctx context.Context, coachID, athleteID string, exerciseID uuid.UUID) ([]entity.ExerciseProgression, error)
+	GetAthleteTrainingSession(ctx context.Context, coachID string, sessionID uuid.UUID) (*entity.TrainingSession, error)
+	AddSessionComment(ctx context.Context, coachID string, sessionID uuid.UUID, text string) (*entity.SessionComment, error)
+	GetSessionComments(ctx context.Context, userID string, sessionID uuid.UUID) ([]entity.SessionComment, error)
+	ParseWorkoutPlans(ctx context.Context, coachID, text string) ([]entity.WorkoutPlan, error)
+	AssignWorkouts(ctx context.Context, coachID string, plans []entity.WorkoutPlan, athleteIDs []string) ([]entity.AssignedWorkout, error)
+	DeliverDueWorkouts(ctx context.Context) ([]entity.AssignedWorkout, error)
+	GetAssignedWorkout(ctx context.Context, userID string, workoutID uuid.UUID) (*entity.AssignedWorkout, error)
+	StartAssignedWorkout(ctx context.Context, athleteID string, workoutID uuid.UUID) (*entity.TrainingSession, error)
+	JoinGroup(ctx context.Context, chatID int64, title, userID, name string) (*entity.Group, bool, error)
+	LeaveGroup(ctx context.Context, chatID int64, userID string) error
+	ToggleGroupPRAnnouncements(ctx context.Context, chatID int64, userID string) (bool, error)
+	SetGroupLifts(ctx context.Context, chatID int64, title string, names []string) (*entity.Group, error)
+	GetGroup(ctx context.Context, chatID int64) (*entity.Group, error)
+	GetGroupLeaderboard(ctx context.Context, chatID int64) (*entity.Leaderboard, error)
+	GetPersonalRecords(ctx context.Context, session *entity.TrainingSession) ([]entity.PersonalRecord, error)
+	GetAnnouncingGroups(ctx context.Context, userID string) ([]entity.Group, error)
+	ShareSession(ctx context.Context, userID, name string) (*entity.SharedSession, error)
+	GetSharedSession(ctx context.Context, sharedID uuid.UUID) (*entity.SharedSession, error)
+	JoinSharedSession(ctx context.Context, sharedID uuid.UUID, userID, name string) (*entity.SharedSession, error)
+	GetSessionPartners(ctx context.Context, userID string) ([]entity.SessionParticipant, error)
 }
 
 type API struct {
@@ -49,9 +119,15 @@ type API struct {
 	chartService     ChartService
 	trainingService  TrainingService
 	commandHandlers  map[string]CommandHandler
+	groupHandlers    map[string]CommandHandler
 	stateHandlers    map[entity.UserState]func(*tgbotapi.Message)
 	callbackHandlers map[string]CallbackHandler
 	userStates       map[string]entity.UserState
+	userLocales      map[string]string
+	exerciseEdits    map[string]exerciseEdit
+	trainingEdits    map[string]trainingEdit
+	coachComments    map[string]coachComment
+	workoutDrafts    map[string]workoutDraft
 	mu               sync.Mutex
 }
 
@@ -71,9 +147,15 @@ func NewAPI(ctx context.Context, cfg *config.TelegramConfig, formatter Formatter
 		chartService:     chartService,
 		trainingService:  trainingService,
 		commandHandlers:  make(map[string]CommandHandler),
+		groupHandlers:    make(map[string]CommandHandler),
 		callbackHandlers: make(map[string]CallbackHandler),
 		stateHandlers:    make(map[entity.UserState]func(*tgbotapi.Message)),
 		userStates:       make(map[string]entity.UserState),
+		userLocales:      make(map[string]string),
+		exerciseEdits:    make(map[string]exerciseEdit),
+		trainingEdits:    make(map[string]trainingEdit),
+		coachComments:    make(map[string]coachComment),
+		workoutDrafts:    make(map[string]workoutDraft),
 		mu:               sync.Mutex{},
 	}
 
@@ -95,6 +177,27 @@ func (a *API) registerHandlers() {
 		getExerciseProgressionCommand: a.StartExerciseProgressionChartHandler,
 		getExerciseHistoryCommand:     a.StartExerciseHistoryHandler,
 		oneRMCommand:                  a.StartOneRMHandler,
+		languageCommand:               a.LanguageHandler,
+		promoteExerciseCommand:        a.StartPromoteExerciseHandler,
+		manageExerciseCommand:         a.StartManageExerciseHandler,
+		gymCommand:                    a.GymHandler,
+		currentCommand:                a.CurrentHandler,
+		autoCloseCommand:              a.AutoCloseHandler,
+		coachCommand:                  a.CoachHandler,
+		partnerCommand:                a.PartnerHandler,
+		joinGroupCommand:              a.GroupOnlyHandler,
+		leaveGroupCommand:             a.GroupOnlyHandler,
+		leaderboardCommand:            a.GroupOnlyHandler,
+		groupLiftsCommand:             a.GroupOnlyHandler,
+	}
+
+	a.groupHandlers = map[string]CommandHandler{
+		startCommand:       a.GroupHelpHandler,
+		helpCommand:        a.GroupHelpHandler,
+		joinGroupCommand:   a.GroupJoinHandler,
+		leaveGroupCommand:  a.GroupLeaveHandler,
+		leaderboardCommand: a.GroupLeaderboardHandler,
+		groupLiftsCommand:  a.GroupLiftsHandler,
 	}
 
 	a.stateHandlers = map[entity.UserState]func(*tgbotapi.Message){
@@ -103,6 +206,13 @@ func (a *API) registerHandlers() {
 		entity.StateAwaitingTrainingInput:     a.UploadTrainingHandler,
 		entity.StateAwaitingGetTrainingsInput: a.GetTrainingsHandler,
 		entity.StateAwaitingOneRMInput:        a.OneRMHandler,
+		entity.StateAwaitingPromoteExercise:   a.PromoteExerciseSearchHandler,
+		entity.StateAwaitingManageExercise:    a.ManageExerciseSearchHandler,
+		entity.StateAwaitingExerciseEditInput: a.ExerciseEditInputHandler,
+		entity.StateAwaitingGymInput:          a.CreateGymHandler,
+		entity.StateAwaitingTrainingEditInput: a.TrainingEditInputHandler,
+		entity.StateAwaitingCoachComment:      a.CoachCommentInputHandler,
+		entity.StateAwaitingWorkoutPlan:       a.WorkoutPlanInputHandler,
 	}
 
 	a.callbackHandlers = map[string]CallbackHandler{
@@ -115,24 +225,129 @@ func (a *API) registerHandlers() {
 		startGetExerciseProgressionPrefix: a.ExerciseProgressionChartHandler,
 		startGetExerciseHistoryPrefix:     a.ExerciseHistoryHandler,
 		backToMuscleGroups:                a.BackToMuscleGroupsHandler,
+		languagePrefix:                    a.SelectLanguageHandler,
+		promoteExercisePrefix:             a.PromoteExerciseHandler,
+		manageExercisePrefix:              a.ManageExerciseHandler,
+		favoritePrefix:                    a.FavoriteExerciseHandler,
+		exerciseEditPrefix:                a.ExerciseEditActionHandler,
+		exerciseEditMusclePrefix:          a.ExerciseEditMuscleGroupHandler,
+		exerciseEditMergePrefix:           a.ExerciseEditMergeTargetHandler,
+		exerciseEditConfirmPrefix:         a.ConfirmExerciseEditHandler,
+		exerciseEditCancelPrefix:          a.CancelExerciseEditHandler,
+		gymPrefix:                         a.GymActionHandler,
+		techniquePrefix:                   a.TechniqueHandler,
+		techniqueClosePrefix:              a.CloseTechniqueHandler,
+		trainingPrefix:                    a.TrainingHandler,
+		trainingExercisePrefix:            a.TrainingExerciseHandler,
+		trainingExerciseDeletePrefix:      a.TrainingExerciseDeleteHandler,
+		trainingSetPrefix:                 a.TrainingSetHandler,
+		trainingSetDeletePrefix:           a.TrainingSetDeleteHandler,
+		trainingDatePrefix:                a.TrainingDateHandler,
+		trainingDeletePrefix:              a.TrainingDeleteHandler,
+		trainingDeleteConfirmPrefix:       a.TrainingDeleteConfirmHandler,
+		trainingRatePrefix:                a.TrainingRateHandler,
+		trainingDetailsPrefix:             a.TrainingDetailsHandler,
+		restReportPrefix:                  a.RestReportHandler,
+		idleKeepPrefix:                    a.IdleKeepHandler,
+		idleActionPrefix:                  a.IdleActionHandler,
+		undoSetPrefix:                     a.UndoSetHandler,
+		activeSetsPrefix:                  a.ActiveSetsHandler,
+		activeSetDeletePrefix:             a.ActiveSetDeleteHandler,
+		currentPrefix:                     a.CurrentActionHandler,
+		coachInvitePrefix:                 a.CoachInviteHandler,
+		coachAcceptPrefix:                 a.CoachAcceptHandler,
+		coachDeclinePrefix:                a.CoachDeclineHandler,
+		coachRevokePrefix:                 a.CoachRevokeHandler,
+		coachAthletePrefix:                a.CoachAthleteHandler,
+		coachTrainingsPrefix:              a.CoachTrainingsHandler,
+		coachSessionPrefix:                a.CoachSessionHandler,
+		coachCommentPrefix:                a.CoachCommentHandler,
+		coachProgressPrefix:               a.CoachProgressHandler,
+		coachChartPrefix:                  a.CoachChartHandler,
+		workoutPlanPrefix:                 a.WorkoutPlanHandler,
+		workoutAthletePrefix:              a.WorkoutAthleteHandler,
+		workoutAssignPrefix:               a.WorkoutAssignHandler,
+		workoutStartPrefix:                a.WorkoutStartHandler,
+		groupPRPrefix:                     a.GroupPRHandler,
+		partnerJoinPrefix:                 a.PartnerJoinHandler,
 	}
 }
 
 func (a *API) setBotCommands() {
-	commands := []tgbotapi.BotCommand{
-		{Command: startCommand, Description: "Запустить бота"},
-		{Command: startTrainingCommand, Description: "Начать тренировку"},
-		{Command: uploadTrainingCommand, Description: "Загрузить тренировку"},
-		{Command: getTrainingsCommand, Description: "Посмотреть историю тренировок"},
-		{Command: getExerciseProgressionCommand, Description: "Посмотреть прогрессию весов по упражнению"},
-		{Command: getExerciseHistoryCommand, Description: "Посмотреть историю конкретного упражнения"},
-		{Command: createExerciseCommand, Description: "Создать новое упражнение"},
-		{Command: clearTrainingCommand, Description: "Сбросить текущую тренировку"},
-		{Command: oneRMCommand, Description: "Рассчитать одноповторный максимум"},
-		{Command: helpCommand, Description: "Помощь и команды"},
+	commands := []struct {
+		command     string
+		description string
+	}{
+		{command: startCommand, description: startCommandDescription},
+		{command: startTrainingCommand, description: startTrainingCommandDescription},
+		{command: uploadTrainingCommand, description: uploadTrainingCommandDescription},
+		{command: getTrainingsCommand, description: getTrainingsCommandDescription},
+		{command: getExerciseProgressionCommand, description: getExerciseProgressionCommandDescription},
+		{command: getExerciseHistoryCommand, description: getExerciseHistoryCommandDescription},
+		{command: createExerciseCommand, description: createExerciseCommandDescription},
+		{command: clearTrainingCommand, description: clearTrainingCommandDescription},
+		{command: oneRMCommand, description: oneRMCommandDescription},
+		{command: currentCommand, description: currentCommandDescription},
+		{command: partnerCommand, description: partnerCommandDescription},
+		{command: autoCloseCommand, description: autoCloseCommandDescription},
+		{command: gymCommand, description: gymCommandDescription},
+		{command: coachCommand, description: coachCommandDescription},
+		{command: languageCommand, description: languageCommandDescription},
+		{command: helpCommand, description: helpCommandDescription},
+	}
+
+	localized := func(locale i18n.Locale) []tgbotapi.BotCommand {
+		botCommands := make([]tgbotapi.BotCommand, 0, len(commands))
+		for _, c := range commands {
+			botCommands = append(botCommands, tgbotapi.BotCommand{Command: c.command, Description: a.text(locale, c.description)})
+		}
+		return botCommands
 	}
 
-	if _, err := a.bot.Request(tgbotapi.NewSetMyCommands(commands...)); err != nil {
+	if _, err := a.bot.Request(tgbotapi.NewSetMyCommands(localized(i18n.DefaultLocale)...)); err != nil {
 		log.Printf("Set commands error %v", err)
 	}
+
+	for _, locale := range i18n.SupportedLocales {
+		config := tgbotapi.NewSetMyCommandsWithScopeAndLanguage(tgbotapi.NewBotCommandScopeDefault(), string(locale), localized(locale)...)
+		if _, err := a.bot.Request(config); err != nil {
+			log.Printf("Set commands error for locale %s: %v", locale, err)
+		}
+	}
+
+	a.setGroupBotCommands()
+}
+
+// setGroupBotCommands показывает в групповых чатах только групповые команды.
+func (a *API) setGroupBotCommands() {
+	commands := []struct {
+		command     string
+		description string
+	}{
+		{command: joinGroupCommand, description: joinGroupCommandDescription},
+		{command: leaveGroupCommand, description: leaveGroupCommandDescription},
+		{command: leaderboardCommand, description: leaderboardCommandDescription},
+		{command: groupLiftsCommand, description: groupLiftsCommandDescription},
+		{command: helpCommand, description: helpCommandDescription},
+	}
+
+	localized := func(locale i18n.Locale) []tgbotapi.BotCommand {
+		botCommands := make([]tgbotapi.BotCommand, 0, len(commands))
+		for _, c := range commands {
+			botCommands = append(botCommands, tgbotapi.BotCommand{Command: c.command, Description: a.text(locale, c.description)})
+		}
+		return botCommands
+	}
+
+	scope := tgbotapi.NewBotCommandScopeAllGroupChats()
+	if _, err := a.bot.Request(tgbotapi.NewSetMyCommandsWithScope(scope, localized(i18n.DefaultLocale)...)); err != nil {
+		log.Printf("Set group commands error %v", err)
+	}
+
+	for _, locale := range i18n.SupportedLocales {
+		config := tgbotapi.NewSetMyCommandsWithScopeAndLanguage(scope, string(locale), localized(locale)...)
+		if _, err := a.bot.Request(config); err != nil {
+			log.Printf("Set group commands error for locale %s: %v", locale, err)
+		}
+	}
 }
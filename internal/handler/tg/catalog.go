@@ -0,0 +1,375 @@
+package tg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+// exerciseEdit изменение каталога, ожидающее подтверждения администратором.
+type exerciseEdit struct {
+	action     entity.ExerciseAuditAction
+	exerciseID uuid.UUID
+	value      string
+}
+
+func (a *API) getExerciseEdit(userID string) (exerciseEdit, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	edit, ok := a.exerciseEdits[userID]
+	return edit, ok
+}
+
+func (a *API) setExerciseEdit(userID string, edit exerciseEdit) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.exerciseEdits[userID] = edit
+}
+
+func (a *API) popExerciseEdit(userID string) (exerciseEdit, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	edit, ok := a.exerciseEdits[userID]
+	delete(a.exerciseEdits, userID)
+	return edit, ok
+}
+
+func (a *API) StartManageExerciseHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	if !a.isAdmin(message.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	a.setUserState(userID, entity.StateAwaitingManageExercise)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, startManageExerciseText)))
+}
+
+func (a *API) ManageExerciseSearchHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	defer a.clearUserState(userID)
+
+	buttons, err := a.exerciseSearchButtons(userID, message.Text, manageExercisePrefix, "")
+	if errors.Is(err, errs.ErrSearchQueryTooShort) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errSearchTooShort)))
+		return
+	}
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+	if len(buttons) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errNoExercises)))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, manageExerciseSelectText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) ManageExerciseHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	locale := a.userLocale(callback.From)
+
+	if !a.isAdmin(callback.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	exerciseID, err := uuid.Parse(strings.TrimPrefix(callback.Data, manageExercisePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	exercise, err := a.trainingService.GetExercise(a.ctx, exerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+
+	actions := []struct {
+		action  entity.ExerciseAuditAction
+		textKey string
+	}{
+		{action: entity.ExerciseAuditRename, textKey: exerciseEditRenameButtonText},
+		{action: entity.ExerciseAuditMuscleGroup, textKey: exerciseEditMuscleGroupButtonText},
+		{action: entity.ExerciseAuditEquipment, textKey: exerciseEditEquipmentButtonText},
+		{action: entity.ExerciseAuditMerge, textKey: exerciseEditMergeButtonText},
+		{action: entity.ExerciseAuditArchive, textKey: exerciseEditArchiveButtonText},
+	}
+
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	for _, action := range actions {
+		data := exerciseEditPrefix + string(action.action) + ":" + exercise.ID().String()
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(a.text(locale, action.textKey), data)))
+	}
+
+	text := a.textf(locale, manageExerciseText, exercise.Name(), a.muscleGroupName(locale, exercise.MuscleGroup()), exercise.Equipment())
+	editMsg := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, tgbotapi.NewInlineKeyboardMarkup(buttons...))
+
+	_, _ = a.bot.Send(editMsg)
+}
+
+func (a *API) ExerciseEditActionHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	if !a.isAdmin(callback.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	args := strings.SplitN(strings.TrimPrefix(callback.Data, exerciseEditPrefix), ":", 2)
+	if len(args) != 2 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	exerciseID, err := uuid.Parse(args[1])
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	edit := exerciseEdit{action: entity.ExerciseAuditAction(args[0]), exerciseID: exerciseID}
+	a.setExerciseEdit(userID, edit)
+
+	switch edit.action {
+	case entity.ExerciseAuditRename:
+		a.setUserState(userID, entity.StateAwaitingExerciseEditInput)
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, exerciseEditRenameText)))
+	case entity.ExerciseAuditEquipment:
+		a.setUserState(userID, entity.StateAwaitingExerciseEditInput)
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, exerciseEditEquipmentText)))
+	case entity.ExerciseAuditMerge:
+		a.setUserState(userID, entity.StateAwaitingExerciseEditInput)
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, exerciseEditMergeText)))
+	case entity.ExerciseAuditMuscleGroup:
+		markup := tgbotapi.NewInlineKeyboardMarkup(a.muscleGroupButtonsWithPrefix(locale, exerciseEditMusclePrefix)...)
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, a.text(locale, muscleGroupSelectText), markup))
+	case entity.ExerciseAuditArchive:
+		a.sendExerciseEditConfirmation(chatID, locale, edit)
+	default:
+		a.popExerciseEdit(userID)
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+	}
+}
+
+func (a *API) ExerciseEditInputHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+	input := strings.TrimSpace(message.Text)
+
+	defer a.clearUserState(userID)
+
+	edit, ok := a.getExerciseEdit(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	if input == "" {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, exerciseEditEmptyValueText)))
+		return
+	}
+
+	if edit.action != entity.ExerciseAuditMerge {
+		edit.value = input
+		a.setExerciseEdit(userID, edit)
+		a.sendExerciseEditConfirmation(chatID, locale, edit)
+		return
+	}
+
+	buttons, err := a.exerciseSearchButtons(userID, input, exerciseEditMergePrefix, edit.exerciseID.String())
+	if errors.Is(err, errs.ErrSearchQueryTooShort) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errSearchTooShort)))
+		return
+	}
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+	if len(buttons) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errNoExercises)))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, exerciseEditMergeSelectText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) ExerciseEditMuscleGroupHandler(callback *tgbotapi.CallbackQuery) {
+	a.completeExerciseEdit(callback, strings.TrimPrefix(callback.Data, exerciseEditMusclePrefix))
+}
+
+func (a *API) ExerciseEditMergeTargetHandler(callback *tgbotapi.CallbackQuery) {
+	a.completeExerciseEdit(callback, strings.TrimPrefix(callback.Data, exerciseEditMergePrefix))
+}
+
+func (a *API) ConfirmExerciseEditHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	if !a.isAdmin(callback.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	edit, ok := a.popExerciseEdit(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, exerciseEditExpiredText)))
+		return
+	}
+
+	var (
+		exercise *entity.Exercise
+		err      error
+	)
+
+	switch edit.action {
+	case entity.ExerciseAuditRename:
+		exercise, err = a.trainingService.RenameExercise(a.ctx, userID, edit.exerciseID, edit.value)
+	case entity.ExerciseAuditMuscleGroup:
+		exercise, err = a.trainingService.ChangeExerciseMuscleGroup(a.ctx, userID, edit.exerciseID, edit.value)
+	case entity.ExerciseAuditEquipment:
+		exercise, err = a.trainingService.ChangeExerciseEquipment(a.ctx, userID, edit.exerciseID, edit.value)
+	case entity.ExerciseAuditArchive:
+		exercise, err = a.trainingService.ArchiveExercise(a.ctx, userID, edit.exerciseID)
+	case entity.ExerciseAuditMerge:
+		var targetID uuid.UUID
+		targetID, err = uuid.Parse(edit.value)
+		if err == nil {
+			exercise, err = a.trainingService.MergeExercises(a.ctx, userID, edit.exerciseID, targetID)
+		}
+	default:
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, errInternal)))
+		return
+	}
+
+	if err != nil {
+		text := a.text(locale, errEditExercise)
+		switch {
+		case errors.Is(err, errs.ErrExerciseAlreadyExists):
+			text = a.textf(locale, exerciseWithNameAlreadyExistsText, edit.value)
+		case errors.Is(err, errs.ErrExerciseArchived):
+			text = a.text(locale, errExerciseArchived)
+		case errors.Is(err, errs.ErrInvalidExerciseMerge):
+			text = a.text(locale, errInvalidExerciseMerge)
+		}
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, text))
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, exerciseEditDoneText, exercise.Name())))
+}
+
+func (a *API) CancelExerciseEditHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	a.popExerciseEdit(userID)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, exerciseEditCanceledText)))
+}
+
+// completeExerciseEdit запоминает выбранное кнопкой значение и просит подтвердить изменение.
+func (a *API) completeExerciseEdit(callback *tgbotapi.CallbackQuery, value string) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	edit, ok := a.getExerciseEdit(userID)
+	if !ok || !a.isAdmin(callback.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, exerciseEditExpiredText)))
+		return
+	}
+
+	edit.value = value
+	a.setExerciseEdit(userID, edit)
+	a.sendExerciseEditConfirmation(chatID, locale, edit)
+}
+
+func (a *API) sendExerciseEditConfirmation(chatID int64, locale i18n.Locale, edit exerciseEdit) {
+	exercise, err := a.trainingService.GetExercise(a.ctx, edit.exerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+
+	var text string
+	switch edit.action {
+	case entity.ExerciseAuditRename:
+		text = a.textf(locale, confirmRenameExerciseText, exercise.Name(), edit.value)
+	case entity.ExerciseAuditMuscleGroup:
+		text = a.textf(locale, confirmMuscleGroupExerciseText, exercise.Name(), a.muscleGroupName(locale, exercise.MuscleGroup()), a.muscleGroupName(locale, edit.value))
+	case entity.ExerciseAuditEquipment:
+		text = a.textf(locale, confirmEquipmentExerciseText, exercise.Name(), exercise.Equipment(), edit.value)
+	case entity.ExerciseAuditArchive:
+		text = a.textf(locale, confirmArchiveExerciseText, exercise.Name())
+	case entity.ExerciseAuditMerge:
+		targetID, err := uuid.Parse(edit.value)
+		if err != nil {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+			return
+		}
+		target, err := a.trainingService.GetExercise(a.ctx, targetID)
+		if err != nil {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+			return
+		}
+		text = a.textf(locale, confirmMergeExerciseText, exercise.Name(), target.Name())
+	}
+
+	yesButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerYes), exerciseEditConfirmPrefix)
+	noButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerNo), exerciseEditCancelPrefix)
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(yesButton, noButton))
+
+	_, _ = a.bot.Send(msg)
+}
+
+// exerciseSearchButtons ищет упражнения по названию и строит кнопки с ID упражнения в данных,
+// exceptID исключает упражнение из выдачи.
+func (a *API) exerciseSearchButtons(userID string, query string, prefix string, exceptID string) ([][]tgbotapi.InlineKeyboardButton, error) {
+	exercises, err := a.trainingService.SearchExercises(a.ctx, userID, strings.TrimSpace(query))
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	for _, exercise := range exercises {
+		if exercise.ID().String() == exceptID {
+			continue
+		}
+		button := tgbotapi.NewInlineKeyboardButtonData(exercise.Name(), prefix+exercise.ID().String())
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
+	}
+
+	return buttons, nil
+}
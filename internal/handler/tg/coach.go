@@ -0,0 +1,497 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+const (
+	// coachInvitePayload параметр /start из ссылки-приглашения тренера
+	coachInvitePayload = "coach_"
+
+	coachTrainingsDays = 30
+	coachProgressDays  = 90
+)
+
+// coachComment тренировка, к которой тренер пишет комментарий.
+type coachComment struct {
+	sessionID uuid.UUID
+	date      time.Time
+}
+
+func (a *API) setCoachComment(userID string, comment coachComment) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.coachComments[userID] = comment
+}
+
+func (a *API) popCoachComment(userID string) (coachComment, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	comment, ok := a.coachComments[userID]
+	delete(a.coachComments, userID)
+	return comment, ok
+}
+
+// CoachHandler показывает спортсменов пользователя, его тренеров и кнопку приглашения.
+func (a *API) CoachHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	links, err := a.trainingService.GetCoachLinks(a.ctx, userID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCoach)))
+		return
+	}
+
+	text, markup := a.coachView(locale, userID, links)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) coachView(locale i18n.Locale, userID string, links []entity.CoachLink) (string, tgbotapi.InlineKeyboardMarkup) {
+	var sb strings.Builder
+	sb.WriteString(a.text(locale, coachTitleText))
+
+	var athletes, coaches []entity.CoachLink
+	for _, link := range links {
+		if link.CoachID() == userID {
+			athletes = append(athletes, link)
+		} else {
+			coaches = append(coaches, link)
+		}
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	if len(athletes) == 0 {
+		sb.WriteString(a.text(locale, coachNoAthletesText))
+	}
+	for _, link := range athletes {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🏃 "+link.AthleteName(), coachAthletePrefix+link.AthleteID()),
+		))
+	}
+
+	if len(coaches) > 0 {
+		sb.WriteString(a.text(locale, coachYourCoachesText))
+	}
+	for _, link := range coaches {
+		sb.WriteString("\n• " + link.CoachName())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.textf(locale, coachRevokeButtonText, link.CoachName()), coachRevokePrefix+link.ID().String()),
+		))
+	}
+
+	if len(athletes) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, workoutPlanButtonText), workoutPlanPrefix),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachInviteButtonText), coachInvitePrefix),
+	))
+
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// CoachInviteHandler создает одноразовую ссылку, открыв которую спортсмен дает доступ к тренировкам.
+func (a *API) CoachInviteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	invite, err := a.trainingService.CreateCoachInvite(a.ctx, userID, callback.From.String())
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCoach)))
+		return
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%s", a.bot.Self.UserName, coachInvitePayload, invite.ID())
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, coachInviteText, link)))
+}
+
+// coachInviteConsent спрашивает согласие спортсмена, открывшего ссылку-приглашение.
+func (a *API) coachInviteConsent(message *tgbotapi.Message, payload string) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	inviteID, err := uuid.Parse(strings.TrimPrefix(payload, coachInvitePayload))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, coachInviteInvalidText)))
+		return
+	}
+
+	invite, err := a.trainingService.GetCoachInvite(a.ctx, inviteID)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	if invite.CoachID() == userID {
+		a.sendCoachError(chatID, locale, errs.ErrCoachSelfLink)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.textf(locale, coachConsentText, invite.CoachName()))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachAcceptButtonText), coachAcceptPrefix+inviteID.String()),
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachDeclineButtonText), coachDeclinePrefix+inviteID.String()),
+	))
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) CoachAcceptHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	inviteID, err := uuid.Parse(strings.TrimPrefix(callback.Data, coachAcceptPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	link, err := a.trainingService.AcceptCoachInvite(a.ctx, inviteID, userID, callback.From.String())
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, coachAcceptedText, link.CoachName())))
+
+	a.notifyUser(link.CoachID(), func(locale i18n.Locale) string {
+		return a.textf(locale, coachAthleteJoinedText, link.AthleteName())
+	})
+}
+
+func (a *API) CoachDeclineHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	locale := a.userLocale(callback.From)
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, coachDeclinedText)))
+}
+
+// CoachRevokeHandler отзывает доступ со стороны тренера или спортсмена и сообщает второй стороне.
+func (a *API) CoachRevokeHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	linkID, err := uuid.Parse(strings.TrimPrefix(callback.Data, coachRevokePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	link, err := a.trainingService.RevokeCoachLink(a.ctx, userID, linkID)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	otherID, otherName := link.AthleteID(), link.AthleteName()
+	if userID == link.AthleteID() {
+		otherID, otherName = link.CoachID(), link.CoachName()
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, coachRevokedText, otherName)))
+
+	a.notifyUser(otherID, func(locale i18n.Locale) string {
+		name := link.CoachName()
+		if otherID == link.CoachID() {
+			name = link.AthleteName()
+		}
+		return a.textf(locale, coachRevokedByOtherText, name)
+	})
+}
+
+// CoachAthleteHandler показывает действия тренера со спортсменом.
+func (a *API) CoachAthleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	athleteID := strings.TrimPrefix(callback.Data, coachAthletePrefix)
+
+	links, err := a.trainingService.GetCoachLinks(a.ctx, userID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCoach)))
+		return
+	}
+
+	idx := slices.IndexFunc(links, func(link entity.CoachLink) bool {
+		return link.CoachID() == userID && link.AthleteID() == athleteID
+	})
+	if idx < 0 {
+		a.sendCoachError(chatID, locale, errs.ErrCoachLinkNotFound)
+		return
+	}
+	link := links[idx]
+
+	msg := tgbotapi.NewMessage(chatID, a.textf(locale, coachAthleteText, link.AthleteName()))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachTrainingsButtonText), coachTrainingsPrefix+athleteID),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachProgressButtonText), coachProgressPrefix+athleteID),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.textf(locale, coachRevokeButtonText, link.AthleteName()), coachRevokePrefix+link.ID().String()),
+		),
+	)
+
+	_, _ = a.bot.Send(msg)
+}
+
+// CoachTrainingsHandler присылает тренировки спортсмена за последние coachTrainingsDays дней со статистикой.
+func (a *API) CoachTrainingsHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	athleteID := strings.TrimPrefix(callback.Data, coachTrainingsPrefix)
+
+	from := time.Now().AddDate(0, 0, -coachTrainingsDays)
+	trainings, err := a.trainingService.GetAthleteTrainingSessions(a.ctx, userID, athleteID, &from, nil)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+	if len(trainings) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
+		return
+	}
+
+	for _, chunk := range splitMessage(a.formatTrainingLogs(trainings), maxTgMessageLength) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, chunk))
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.formatTrainingsSummary(locale, entity.SummarizeSessions(trainings))))
+
+	recent := slices.Clone(trainings)
+	slices.Reverse(recent)
+	if len(recent) > maxEditableTrainings {
+		recent = recent[:maxEditableTrainings]
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, training := range recent {
+		label := a.textf(locale, trainingButtonText, training.Date().Format(time.DateOnly), training.ExerciseCount())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, coachSessionPrefix+training.ID().String()),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, coachSelectTrainingText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+// CoachSessionHandler показывает тренировку спортсмена с комментариями и кнопкой нового комментария.
+func (a *API) CoachSessionHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, coachSessionPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.GetAthleteTrainingSession(a.ctx, userID, sessionID)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	text := strings.TrimSpace(a.formatTrainingLogs([]entity.TrainingSession{*session}))
+	text += a.sessionCommentsText(locale, userID, sessionID)
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, coachCommentButtonText), coachCommentPrefix+sessionID.String()),
+	))
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) CoachCommentHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, coachCommentPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.GetAthleteTrainingSession(a.ctx, userID, sessionID)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	a.setCoachComment(userID, coachComment{sessionID: sessionID, date: session.Date()})
+	a.setUserState(userID, entity.StateAwaitingCoachComment)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, coachCommentPromptText, session.Date().Format(time.DateOnly))))
+}
+
+// CoachCommentInputHandler сохраняет комментарий тренера и присылает его спортсмену.
+func (a *API) CoachCommentInputHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	defer a.clearUserState(userID)
+
+	pending, ok := a.popCoachComment(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	comment, err := a.trainingService.AddSessionComment(a.ctx, userID, pending.sessionID, message.Text)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, coachCommentSentText)))
+
+	a.notifyUser(comment.AthleteID(), func(locale i18n.Locale) string {
+		return a.textf(locale, coachCommentNotificationText, comment.CoachName(), pending.date.Format(time.DateOnly), comment.Text())
+	})
+}
+
+// CoachProgressHandler предлагает упражнения спортсмена за последние coachProgressDays дней для графика.
+func (a *API) CoachProgressHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	athleteID := strings.TrimPrefix(callback.Data, coachProgressPrefix)
+
+	from := time.Now().AddDate(0, 0, -coachProgressDays)
+	trainings, err := a.trainingService.GetAthleteTrainingSessions(a.ctx, userID, athleteID, &from, nil)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, training := range trainings {
+		for _, exercise := range training.Exercises() {
+			if seen[exercise.Exercise.ID()] {
+				continue
+			}
+			seen[exercise.Exercise.ID()] = true
+
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(exercise.Name(), coachChartPrefix+athleteID+":"+exercise.Exercise.ID().String()),
+			))
+		}
+	}
+
+	if len(rows) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, coachSelectExerciseText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) CoachChartHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	athleteID, rawExerciseID, _ := strings.Cut(strings.TrimPrefix(callback.Data, coachChartPrefix), ":")
+	exerciseID, err := uuid.Parse(rawExerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	data, err := a.trainingService.GetAthleteExerciseProgression(a.ctx, userID, athleteID, exerciseID)
+	if err != nil {
+		a.sendCoachError(chatID, locale, err)
+		return
+	}
+	if len(data) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
+		return
+	}
+
+	a.sendProgressionChart(chatID, locale, userID+"-"+athleteID, data)
+}
+
+// sessionCommentsText перечисляет комментарии тренеров к тренировке, пустая строка если их нет.
+func (a *API) sessionCommentsText(locale i18n.Locale, userID string, sessionID uuid.UUID) string {
+	comments, err := a.trainingService.GetSessionComments(a.ctx, userID, sessionID)
+	if err != nil || len(comments) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(a.text(locale, sessionCommentsTitleText))
+	for _, comment := range comments {
+		sb.WriteString(a.textf(locale, sessionCommentText, comment.CoachName(), comment.Text()))
+	}
+
+	return sb.String()
+}
+
+// notifyUser отправляет сообщение в личный чат пользователя на его языке.
+func (a *API) notifyUser(userID string, text func(locale i18n.Locale) string) {
+	chatID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	locale := a.userLocale(&tgbotapi.User{ID: chatID})
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text(locale)))
+}
+
+func (a *API) sendCoachError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errCoach)
+	switch {
+	case errors.Is(err, errs.ErrCoachLinkNotFound):
+		text = a.text(locale, coachInviteInvalidText)
+	case errors.Is(err, errs.ErrCoachLinkExists):
+		text = a.text(locale, errCoachLinkExists)
+	case errors.Is(err, errs.ErrCoachSelfLink):
+		text = a.text(locale, errCoachSelfLink)
+	case errors.Is(err, errs.ErrSessionNotFound):
+		text = a.text(locale, notFoundTrainingsText)
+	case errors.Is(err, errs.ErrInvalidComment):
+		text = a.text(locale, errInvalidComment)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
@@ -0,0 +1,132 @@
+package tg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+const (
+	currentSelectAction = "select"
+	currentUpAction     = "up"
+	currentDownAction   = "down"
+	currentDeleteAction = "delete"
+)
+
+// CurrentHandler показывает упражнения текущей тренировки с выбором активного и порядком.
+func (a *API) CurrentHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	session, err := a.trainingService.GetCurrentSession(a.ctx, userID)
+	if err != nil {
+		a.sendCurrentError(chatID, locale, err)
+		return
+	}
+
+	text, markup := a.currentView(locale, session)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) CurrentActionHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	action, rawNumber, _ := strings.Cut(strings.TrimPrefix(callback.Data, currentPrefix), ":")
+
+	number, err := strconv.ParseUint(rawNumber, 10, 8)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	var session *entity.TrainingSession
+	switch action {
+	case currentSelectAction:
+		session, err = a.trainingService.SelectSessionExercise(a.ctx, userID, uint8(number))
+	case currentUpAction:
+		session, err = a.trainingService.MoveSessionExercise(a.ctx, userID, uint8(number), -1)
+	case currentDownAction:
+		session, err = a.trainingService.MoveSessionExercise(a.ctx, userID, uint8(number), 1)
+	case currentDeleteAction:
+		session, err = a.trainingService.DeleteSessionExercise(a.ctx, userID, uint8(number))
+	default:
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	if err != nil {
+		a.sendCurrentError(chatID, locale, err)
+		return
+	}
+
+	if action == currentSelectAction {
+		a.setUserState(userID, entity.StateAwaitingSetInput)
+
+		text := a.textf(locale, currentExerciseSelectedText, session.ActiveExercise().Name())
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, a.setActionsMarkup(locale, 0)))
+	} else {
+		text, markup := a.currentView(locale, session)
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+	}
+
+	a.refreshDashboard(chatID, locale, userID)
+}
+
+func (a *API) currentView(locale i18n.Locale, session *entity.TrainingSession) (string, tgbotapi.InlineKeyboardMarkup) {
+	if len(session.Exercises()) == 0 {
+		return a.text(locale, noCurrentExercisesText), tgbotapi.NewInlineKeyboardMarkup()
+	}
+
+	active := session.ActiveExercise()
+
+	var sb strings.Builder
+	sb.WriteString(a.text(locale, currentTrainingText))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, exercise := range session.Exercises() {
+		marker := ""
+		if exercise.ID() == active.ID() {
+			marker = "▶️ "
+		}
+
+		logged := 0
+		for _, set := range exercise.Sets() {
+			if set.IsFilled() {
+				logged++
+			}
+		}
+
+		sb.WriteString(a.textf(locale, currentExerciseLineText, marker, exercise.Number(), exercise.Name(), logged))
+
+		number := strconv.Itoa(int(exercise.Number()))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(marker+number+". "+exercise.Name(), currentPrefix+currentSelectAction+":"+number),
+			tgbotapi.NewInlineKeyboardButtonData("⬆️", currentPrefix+currentUpAction+":"+number),
+			tgbotapi.NewInlineKeyboardButtonData("⬇️", currentPrefix+currentDownAction+":"+number),
+			tgbotapi.NewInlineKeyboardButtonData("🗑", currentPrefix+currentDeleteAction+":"+number),
+		))
+	}
+
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func (a *API) sendCurrentError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errCurrentTraining)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		text = a.text(locale, errNoTraining)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
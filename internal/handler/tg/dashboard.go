@@ -0,0 +1,108 @@
+package tg
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// errMessageNotModified ответ Telegram на редактирование без изменений.
+const errMessageNotModified = "message is not modified"
+
+// refreshDashboard обновляет закрепленное сообщение текущей тренировки. Если его нет или
+// его больше нельзя отредактировать, отправляется и закрепляется новое.
+func (a *API) refreshDashboard(chatID int64, locale i18n.Locale, userID string) {
+	session, err := a.trainingService.GetCurrentSession(a.ctx, userID)
+	if err != nil || session == nil {
+		return
+	}
+
+	text, markup := a.dashboardView(locale, session)
+
+	if messageID := session.DashboardMessageID(); messageID != 0 {
+		_, err := a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+		if err == nil || strings.Contains(err.Error(), errMessageNotModified) {
+			return
+		}
+		log.Printf("Error editing dashboard %d for user '%s', sending a new one: %v\n", messageID, userID, err)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	sent, err := a.bot.Send(msg)
+	if err != nil {
+		log.Printf("Error sending dashboard for user '%s': %v\n", userID, err)
+		return
+	}
+
+	if _, err := a.bot.Request(tgbotapi.PinChatMessageConfig{ChatID: chatID, MessageID: sent.MessageID, DisableNotification: true}); err != nil {
+		log.Printf("Error pinning dashboard for user '%s': %v\n", userID, err)
+	}
+
+	if err := a.trainingService.SetDashboardMessage(a.ctx, userID, sent.MessageID); err != nil {
+		log.Printf("Error saving dashboard message for user '%s': %v\n", userID, err)
+	}
+}
+
+// closeDashboard открепляет сообщение завершенной тренировки и убирает с него кнопки.
+func (a *API) closeDashboard(chatID int64, session *entity.TrainingSession) {
+	messageID := session.DashboardMessageID()
+	if messageID == 0 {
+		return
+	}
+
+	_, _ = a.bot.Request(tgbotapi.UnpinChatMessageConfig{ChatID: chatID, MessageID: messageID})
+	_, _ = a.bot.Request(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	}))
+}
+
+// dashboardView показывает упражнения и подходы текущей тренировки, объем и прошедшее время.
+func (a *API) dashboardView(locale i18n.Locale, session *entity.TrainingSession) (string, tgbotapi.InlineKeyboardMarkup) {
+	var sb strings.Builder
+
+	sb.WriteString(a.textf(locale, dashboardTitleText,
+		formatter.FormatDuration(session.Elapsed(time.Now())),
+		formatter.FormatWeightFloat(float64(session.TotalVolume())),
+	))
+
+	if len(session.Exercises()) == 0 {
+		sb.WriteString(a.text(locale, dashboardEmptyText))
+	}
+
+	active := session.ActiveExercise()
+	for _, exercise := range session.Exercises() {
+		marker := ""
+		if exercise.ID() == active.ID() {
+			marker = "▶️ "
+		}
+
+		var sets []string
+		for _, set := range exercise.Sets() {
+			switch {
+			case set.IsPlanned() && set.IsFilled():
+				sets = append(sets, formatSetValue(set.Weight(), set.Reps())+" ("+a.textf(locale, dashboardPlannedSetText, formatSetValue(set.PlannedWeight(), set.PlannedReps()))+")")
+			case set.IsPlanned():
+				sets = append(sets, "⏳ "+a.textf(locale, dashboardPlannedSetText, formatSetValue(set.PlannedWeight(), set.PlannedReps())))
+			case set.IsFilled():
+				sets = append(sets, formatSetValue(set.Weight(), set.Reps()))
+			}
+		}
+
+		line := a.text(locale, dashboardNoSetsText)
+		if len(sets) > 0 {
+			line = strings.Join(sets, ", ")
+		}
+
+		sb.WriteString(a.textf(locale, dashboardExerciseText, marker, exercise.Number(), exercise.Name(), line))
+	}
+
+	return sb.String(), a.setActionsMarkup(locale, session.LastSetMessageID())
+}
@@ -0,0 +1,133 @@
+package tg
+
+import (
+	"expvar"
+	"log"
+	"runtime/debug"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// dispatcherMetrics метрики очередей обновлений, доступны через expvar.
+var (
+	dispatcherMetrics = expvar.NewMap("telegram_dispatcher")
+
+	queuedUpdates    = new(expvar.Int)
+	activeUpdates    = new(expvar.Int)
+	processedUpdates = new(expvar.Int)
+	activeQueues     = new(expvar.Int)
+)
+
+func init() {
+	dispatcherMetrics.Set("queued", queuedUpdates)
+	dispatcherMetrics.Set("active", activeUpdates)
+	dispatcherMetrics.Set("processed", processedUpdates)
+	dispatcherMetrics.Set("queues", activeQueues)
+}
+
+// dispatcher обрабатывает обновления одного пользователя строго по порядку, а разных
+// пользователей параллельно, но не больше workers обработчиков одновременно.
+type dispatcher struct {
+	handle  func(tgbotapi.Update)
+	workers chan struct{}
+
+	mu     sync.Mutex
+	queues map[int64][]tgbotapi.Update
+}
+
+func newDispatcher(workers int, handle func(tgbotapi.Update)) *dispatcher {
+	d := &dispatcher{
+		handle:  handle,
+		workers: make(chan struct{}, max(workers, 1)),
+		queues:  make(map[int64][]tgbotapi.Update),
+	}
+
+	dispatcherMetrics.Set("max_queue_depth", expvar.Func(func() any {
+		return d.MaxQueueDepth()
+	}))
+
+	return d
+}
+
+// Dispatch ставит обновление в очередь пользователя и запускает ее обработку, если очередь была пуста.
+func (d *dispatcher) Dispatch(update tgbotapi.Update) {
+	key := updateUserID(update)
+
+	d.mu.Lock()
+	queue, running := d.queues[key]
+	d.queues[key] = append(queue, update)
+	d.mu.Unlock()
+
+	queuedUpdates.Add(1)
+
+	if !running {
+		activeQueues.Add(1)
+		go d.run(key)
+	}
+}
+
+// MaxQueueDepth длина самой большой очереди пользователя, включая обрабатываемое обновление.
+func (d *dispatcher) MaxQueueDepth() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	depth := 0
+	for _, queue := range d.queues {
+		depth = max(depth, len(queue))
+	}
+
+	return depth
+}
+
+// run обрабатывает очередь пользователя, пока она не опустеет. Опустевшая очередь удаляется
+// вместе с массивом, а обработанные обновления обнуляются, чтобы не держать их в памяти.
+func (d *dispatcher) run(key int64) {
+	for {
+		d.mu.Lock()
+		update := d.queues[key][0]
+		d.mu.Unlock()
+
+		d.workers <- struct{}{}
+		queuedUpdates.Add(-1)
+		activeUpdates.Add(1)
+
+		d.safeHandle(update)
+
+		activeUpdates.Add(-1)
+		processedUpdates.Add(1)
+		<-d.workers
+
+		// обновление убирается из очереди только после обработки, чтобы новые ждали его
+		d.mu.Lock()
+		queue := d.queues[key]
+		queue[0] = tgbotapi.Update{}
+		if len(queue) == 1 {
+			delete(d.queues, key)
+			d.mu.Unlock()
+			activeQueues.Add(-1)
+			return
+		}
+		d.queues[key] = queue[1:]
+		d.mu.Unlock()
+	}
+}
+
+func (d *dispatcher) safeHandle(update tgbotapi.Update) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovering from panic: %v\nStack trace: %s", r, debug.Stack())
+		}
+	}()
+
+	d.handle(update)
+}
+
+// updateUserID пользователь, от которого пришло обновление, 0 для обновлений без отправителя.
+func updateUserID(update tgbotapi.Update) int64 {
+	if user := update.SentFrom(); user != nil {
+		return user.ID
+	}
+
+	return 0
+}
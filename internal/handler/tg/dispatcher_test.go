@@ -0,0 +1,91 @@
+package tg
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestDispatcherOrderAndLimit(t *testing.T) {
+	const (
+		workers        = 2
+		users          = 4
+		updatesPerUser = 25
+	)
+
+	var (
+		mu          sync.Mutex
+		received    = make(map[int64][]int)
+		active      int
+		maxActive   int
+		activeUsers = make(map[int64]bool)
+		wg          sync.WaitGroup
+	)
+
+	d := newDispatcher(workers, func(update tgbotapi.Update) {
+		defer wg.Done()
+		userID := update.Message.From.ID
+
+		mu.Lock()
+		if activeUsers[userID] {
+			t.Errorf("updates of user %d are handled concurrently", userID)
+		}
+		activeUsers[userID] = true
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		received[userID] = append(received[userID], update.UpdateID)
+		activeUsers[userID] = false
+		active--
+		mu.Unlock()
+	})
+
+	wg.Add(users * updatesPerUser)
+	for i := range updatesPerUser {
+		for userID := range int64(users) {
+			d.Dispatch(tgbotapi.Update{
+				UpdateID: i,
+				Message:  &tgbotapi.Message{From: &tgbotapi.User{ID: userID + 1}},
+			})
+		}
+	}
+	wg.Wait()
+
+	if maxActive > workers {
+		t.Errorf("max concurrent handlers = %d, want at most %d", maxActive, workers)
+	}
+
+	for userID := range int64(users) {
+		got := received[userID+1]
+		if len(got) != updatesPerUser {
+			t.Fatalf("user %d: handled %d updates, want %d", userID+1, len(got), updatesPerUser)
+		}
+		for i, updateID := range got {
+			if updateID != i {
+				t.Fatalf("user %d: update %d handled at position %d", userID+1, updateID, i)
+			}
+		}
+	}
+
+	// опустевшие очереди удаляются сразу после обработки последнего обновления
+	deadline := time.Now().Add(time.Second)
+	for d.queueCount() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d drained queues are kept in memory", d.queueCount())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (d *dispatcher) queueCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.queues)
+}
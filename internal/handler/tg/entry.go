@@ -2,35 +2,44 @@ package tg
 
 import (
 	"log"
-	"runtime/debug"
 	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// Register читает обновления и передает их диспетчеру: обновления одного пользователя
+// обрабатываются по порядку, разных пользователей параллельно.
 func (a *API) Register() {
+	d := newDispatcher(a.cfg.Workers, a.handleUpdate)
+
 	for update := range a.bot.GetUpdatesChan(tgbotapi.UpdateConfig{}) {
-		go func(update tgbotapi.Update) {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("Recovering from panic: %v\nStack trace: %s", r, debug.Stack())
-				}
-			}()
+		d.Dispatch(update)
+	}
+}
 
-			switch {
-			case update.EditedMessage != nil:
-				a.handleEditedMessage(update.EditedMessage)
-			case update.Message != nil && update.Message.IsCommand():
-				a.handleCommand(update.Message)
-			case update.Message != nil:
-				a.handleState(update.Message)
-			case update.CallbackQuery != nil:
-				a.handleCallbackQuery(update.CallbackQuery)
-			default:
-				log.Printf("Unknown update type: %+v\n", update)
-			}
-		}(update)
+func (a *API) handleUpdate(update tgbotapi.Update) {
+	switch {
+	case update.EditedMessage != nil:
+		a.handleEditedMessage(update.EditedMessage)
+	case update.InlineQuery != nil:
+		a.InlineQueryHandler(update.InlineQuery)
+	case update.ChosenInlineResult != nil:
+		a.ChosenInlineResultHandler(update.ChosenInlineResult)
+	case update.Message != nil && !update.Message.Chat.IsPrivate():
+		a.handleGroupMessage(update.Message)
+	case update.Message != nil && update.Message.ViaBot != nil:
+		// сообщение с выбранным inline-результатом, упражнение уже добавлено в ChosenInlineResultHandler
+	case update.Message != nil && a.isTechniqueReply(update.Message):
+		a.TechniqueReplyHandler(update.Message)
+	case update.Message != nil && update.Message.IsCommand():
+		a.handleCommand(update.Message)
+	case update.Message != nil:
+		a.handleState(update.Message)
+	case update.CallbackQuery != nil:
+		a.handleCallbackQuery(update.CallbackQuery)
+	default:
+		log.Printf("Unknown update type: %+v\n", update)
 	}
 }
 
@@ -65,32 +74,18 @@ func (a *API) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 }
 
 func (a *API) handleEditedMessage(message *tgbotapi.Message) {
-	if message == nil || message.From == nil {
+	if message == nil || message.From == nil || !message.Chat.IsPrivate() {
 		return
 	}
 
 	userID := strconv.FormatInt(message.From.ID, 10)
-	input := message.Text
-	if input == "" {
-		return
-	}
-
-	parts := strings.SplitN(input, "\n", 2)
-	setData := strings.Split(parts[0], ",")
-	if len(setData) != 2 {
+	if message.Text == "" {
 		return
 	}
 
-	weight, errWeight := strconv.ParseFloat(setData[0], 64)
-	reps, errReps := strconv.Atoi(setData[1])
-	if errWeight != nil || errReps != nil {
+	if err := a.trainingService.UpdateSetFromMessage(a.ctx, userID, message.MessageID, message.Text); err != nil {
 		return
 	}
 
-	var notes string
-	if len(parts) > 1 {
-		notes = strings.TrimSpace(parts[1])
-	}
-
-	_ = a.trainingService.UpdateSetFromMessage(a.ctx, userID, message.MessageID, float32(weight), uint8(reps), notes)
+	a.refreshDashboard(message.Chat.ID, a.userLocale(message.From), userID)
 }
@@ -0,0 +1,93 @@
+package tg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (a *API) isAdmin(user *tgbotapi.User) bool {
+	return a.cfg.AuthorName == "" || (user != nil && user.UserName == a.cfg.AuthorName)
+}
+
+func (a *API) StartPromoteExerciseHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	if !a.isAdmin(message.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	a.setUserState(userID, entity.StateAwaitingPromoteExercise)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, startPromoteExerciseText)))
+}
+
+func (a *API) PromoteExerciseSearchHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	defer a.clearUserState(userID)
+
+	exercises, err := a.trainingService.GetPrivateExercisesByName(a.ctx, strings.TrimSpace(message.Text))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+	if len(exercises) == 0 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errNoPrivateExercises)))
+		return
+	}
+
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	for _, exercise := range exercises {
+		label := a.textf(locale, promoteExerciseButtonText, exercise.Name(), a.muscleGroupName(locale, exercise.MuscleGroup()), exercise.OwnerID())
+		button := tgbotapi.NewInlineKeyboardButtonData(label, promoteExercisePrefix+exercise.ID().String())
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.textf(locale, promoteExerciseSelectText, len(exercises)))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) PromoteExerciseHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	locale := a.userLocale(callback.From)
+
+	if !a.isAdmin(callback.From) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, adminOnlyText)))
+		return
+	}
+
+	exerciseID, err := uuid.Parse(strings.TrimPrefix(callback.Data, promoteExercisePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	exercise, err := a.trainingService.PromoteExercise(a.ctx, exerciseID)
+	if err != nil {
+		text := a.text(locale, errPromoteExercise)
+		switch {
+		case errors.Is(err, errs.ErrExerciseAlreadyPublic):
+			text = a.text(locale, errExerciseAlreadyPublic)
+		case errors.Is(err, errs.ErrExerciseAlreadyExists):
+			text = a.textf(locale, errGeneral, err)
+		}
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, exercisePromotedText, exercise.Name())))
+}
@@ -0,0 +1,59 @@
+package tg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/i18n"
+)
+
+// FavoriteExerciseHandler закрепляет упражнение в избранном или снимает закрепление.
+func (a *API) FavoriteExerciseHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	args := strings.SplitN(strings.TrimPrefix(callback.Data, favoritePrefix), ":", 2)
+	if len(args) != 2 {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	muscleGroup := args[0]
+	exerciseID, err := uuid.Parse(args[1])
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	pinned, err := a.trainingService.ToggleFavoriteExercise(a.ctx, userID, exerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errFavoriteExercise)))
+		return
+	}
+
+	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, a.exerciseScreenMarkup(locale, muscleGroup, exerciseID, pinned))
+	_, _ = a.bot.Send(editMarkup)
+}
+
+// exerciseScreenMarkup кнопки экрана только что выбранного упражнения.
+func (a *API) exerciseScreenMarkup(locale i18n.Locale, muscleGroup string, exerciseID uuid.UUID, pinned bool) tgbotapi.InlineKeyboardMarkup {
+	favoriteText := pinExerciseText
+	if pinned {
+		favoriteText = unpinExerciseText
+	}
+
+	favoriteButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, favoriteText), fmt.Sprintf("%s%s:%s", favoritePrefix, muscleGroup, exerciseID))
+	techniqueButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, techniqueButtonText), techniquePrefix+exerciseID.String())
+	backButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, backToExercisesText), fmt.Sprintf("%s%s:%s:0:%s", musclePrefix, muscleGroup, nextDirection, exerciseID))
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(favoriteButton, techniqueButton),
+		tgbotapi.NewInlineKeyboardRow(backButton),
+	)
+}
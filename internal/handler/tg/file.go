@@ -0,0 +1,76 @@
+package tg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/errs"
+)
+
+const (
+	maxUploadFileSize   = 1 << 20
+	maxMediaFileSize    = 20 << 20
+	downloadFileTimeout = 30 * time.Second
+	textFileMimeType    = "text/plain"
+	textFileExtension   = ".txt"
+)
+
+func (a *API) downloadTextDocument(doc *tgbotapi.Document) (string, error) {
+	if doc.MimeType != textFileMimeType && !strings.HasSuffix(strings.ToLower(doc.FileName), textFileExtension) {
+		return "", errs.ErrUnsupportedFile
+	}
+
+	data, err := a.downloadFile(doc.FileID, doc.FileSize, maxUploadFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// downloadFile скачивает файл Telegram размером не больше limit байт.
+func (a *API) downloadFile(fileID string, size int, limit int) ([]byte, error) {
+	if size > limit {
+		return nil, errs.ErrFileTooLarge
+	}
+
+	url, err := a.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("get file url error: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, downloadFileTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create download request error: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download file error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download file error: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("read file error: %w", err)
+	}
+
+	if len(data) > limit {
+		return nil, errs.ErrFileTooLarge
+	}
+
+	return data, nil
+}
@@ -0,0 +1,296 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// handleGroupMessage обрабатывает сообщения групповых чатов: бот отвечает только на свои
+// групповые команды, остальная переписка участников игнорируется.
+func (a *API) handleGroupMessage(message *tgbotapi.Message) {
+	if message.From == nil || !message.IsCommand() {
+		return
+	}
+
+	// команда другому боту в той же группе
+	if command := message.CommandWithAt(); strings.Contains(command, "@") && !strings.HasSuffix(command, "@"+a.bot.Self.UserName) {
+		return
+	}
+
+	if handler, exists := a.groupHandlers[message.Command()]; exists {
+		handler(message)
+		return
+	}
+
+	if _, exists := a.commandHandlers[message.Command()]; exists {
+		a.PrivateOnlyHandler(message)
+	}
+}
+
+// PrivateOnlyHandler отвечает на личные команды в группе ссылкой на чат с ботом.
+func (a *API) PrivateOnlyHandler(message *tgbotapi.Message) {
+	locale := a.userLocale(message.From)
+	link := fmt.Sprintf("https://t.me/%s", a.bot.Self.UserName)
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, a.textf(locale, groupPrivateOnlyText, link))
+	msg.ReplyToMessageID = message.MessageID
+	_, _ = a.bot.Send(msg)
+}
+
+// GroupOnlyHandler отвечает на групповые команды в личном чате.
+func (a *API) GroupOnlyHandler(message *tgbotapi.Message) {
+	locale := a.userLocale(message.From)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(message.Chat.ID, a.text(locale, groupOnlyText)))
+}
+
+func (a *API) GroupHelpHandler(message *tgbotapi.Message) {
+	locale := a.userLocale(message.From)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(message.Chat.ID, a.text(locale, groupHelpText)))
+}
+
+// GroupJoinHandler добавляет автора команды в рейтинги группы.
+func (a *API) GroupJoinHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+	name := message.From.String()
+
+	_, joined, err := a.trainingService.JoinGroup(a.ctx, chatID, message.Chat.Title, userID, name)
+	if err != nil {
+		a.sendGroupError(chatID, locale, err)
+		return
+	}
+
+	text := a.textf(locale, groupJoinedText, name)
+	if !joined {
+		text = a.textf(locale, groupAlreadyJoinedText, name)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyToMessageID = message.MessageID
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, groupPRButtonText), groupPRPrefix),
+	))
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) GroupLeaveHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	if err := a.trainingService.LeaveGroup(a.ctx, chatID, userID); err != nil {
+		a.sendGroupError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, groupLeftText, message.From.String())))
+}
+
+// GroupPRHandler переключает объявления о рекордах нажавшего кнопку участника.
+func (a *API) GroupPRHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	enabled, err := a.trainingService.ToggleGroupPRAnnouncements(a.ctx, chatID, userID)
+	if err != nil {
+		text := a.text(locale, errGroup)
+		if errors.Is(err, errs.ErrNotGroupMember) {
+			text = a.text(locale, errNotGroupMember)
+		}
+		_, _ = a.bot.Request(tgbotapi.NewCallbackWithAlert(callback.ID, text))
+		return
+	}
+
+	text := a.text(locale, groupPRDisabledText)
+	if enabled {
+		text = a.text(locale, groupPREnabledText)
+	}
+	_, _ = a.bot.Request(tgbotapi.NewCallback(callback.ID, text))
+}
+
+// GroupLeaderboardHandler показывает недельные рейтинги группы.
+func (a *API) GroupLeaderboardHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	locale := a.userLocale(message.From)
+
+	leaderboard, err := a.trainingService.GetGroupLeaderboard(a.ctx, chatID)
+	if err != nil {
+		if errors.Is(err, errs.ErrGroupNotFound) {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, groupNoMembersText)))
+			return
+		}
+		a.sendGroupError(chatID, locale, err)
+		return
+	}
+
+	for _, part := range splitMessage(a.formatLeaderboard(locale, leaderboard), maxTgMessageLength) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
+
+// GroupLiftsHandler задает упражнения для рейтинга e1RM, менять их могут только администраторы.
+func (a *API) GroupLiftsHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	locale := a.userLocale(message.From)
+
+	var names []string
+	for _, name := range strings.Split(message.CommandArguments(), ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		a.sendGroupLifts(chatID, locale)
+		return
+	}
+
+	if !a.isChatAdmin(chatID, message.From.ID) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, groupAdminOnlyText)))
+		return
+	}
+
+	group, err := a.trainingService.SetGroupLifts(a.ctx, chatID, message.Chat.Title, names)
+	if err != nil {
+		a.sendGroupError(chatID, locale, err)
+		return
+	}
+
+	lifts := make([]string, 0, len(group.Lifts()))
+	for _, lift := range group.Lifts() {
+		lifts = append(lifts, lift.Name)
+	}
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, groupLiftsSavedText, strings.Join(lifts, ", "))))
+}
+
+func (a *API) sendGroupLifts(chatID int64, locale i18n.Locale) {
+	text := a.textf(locale, groupLiftsUsageText, entity.MaxGroupLifts)
+
+	group, err := a.trainingService.GetGroup(a.ctx, chatID)
+	if err == nil && len(group.Lifts()) > 0 {
+		lifts := make([]string, 0, len(group.Lifts()))
+		for _, lift := range group.Lifts() {
+			lifts = append(lifts, lift.Name)
+		}
+		text = a.textf(locale, groupLiftsCurrentText, strings.Join(lifts, ", ")) + text
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+func (a *API) isChatAdmin(chatID, userID int64) bool {
+	member, err := a.bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		return false
+	}
+
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+func (a *API) formatLeaderboard(locale i18n.Locale, leaderboard *entity.Leaderboard) string {
+	var sb strings.Builder
+
+	sb.WriteString(a.textf(locale, groupLeaderboardTitleText, leaderboard.From.Format(time.DateOnly)))
+
+	if len(leaderboard.Sessions) == 0 {
+		sb.WriteString(a.text(locale, groupLeaderboardEmptyText))
+		return sb.String()
+	}
+
+	sb.WriteString(a.text(locale, groupLeaderboardVolumeText))
+	for i, entry := range leaderboard.Volume {
+		sb.WriteString(a.textf(locale, groupLeaderboardVolumeEntryText, i+1, entry.Name, formatter.FormatWeightFloat(entry.Value)))
+	}
+
+	sb.WriteString(a.text(locale, groupLeaderboardSessionsText))
+	for i, entry := range leaderboard.Sessions {
+		sb.WriteString(a.textf(locale, groupLeaderboardEntryText, i+1, entry.Name, strconv.Itoa(int(entry.Value))))
+	}
+
+	for _, lift := range leaderboard.Lifts {
+		sb.WriteString(a.textf(locale, groupLeaderboardLiftText, lift.Lift.Name))
+		if len(lift.Entries) == 0 {
+			sb.WriteString(a.text(locale, groupLeaderboardNoLiftText))
+			continue
+		}
+		for i, entry := range lift.Entries {
+			sb.WriteString(a.textf(locale, groupLeaderboardEntryText, i+1, entry.Name, fmt.Sprintf("%.2f×", entry.Value)))
+		}
+	}
+
+	return sb.String()
+}
+
+// onSessionFinished рассылает уведомления о завершенной тренировке: тренеру и в группы.
+func (a *API) onSessionFinished(session *entity.TrainingSession) {
+	a.notifyCoachAboutWorkout(session)
+	a.announcePersonalRecords(session)
+}
+
+// announcePersonalRecords объявляет рекорды тренировки в группах, где участник это разрешил.
+func (a *API) announcePersonalRecords(session *entity.TrainingSession) {
+	if session.IsEmpty() {
+		return
+	}
+
+	groups, err := a.trainingService.GetAnnouncingGroups(a.ctx, session.UserID())
+	if err != nil || len(groups) == 0 {
+		return
+	}
+
+	records, err := a.trainingService.GetPersonalRecords(a.ctx, session)
+	if err != nil || len(records) == 0 {
+		return
+	}
+
+	userID, err := strconv.ParseInt(session.UserID(), 10, 64)
+	if err != nil {
+		return
+	}
+	locale := a.userLocale(&tgbotapi.User{ID: userID})
+
+	for _, group := range groups {
+		member, _ := group.Member(session.UserID())
+
+		var sb strings.Builder
+		sb.WriteString(a.textf(locale, groupPRTitleText, member.Name))
+		for _, record := range records {
+			sb.WriteString(a.textf(locale, groupPRText,
+				record.ExerciseName,
+				formatSetValue(record.Weight, record.Reps),
+				formatter.FormatWeightFloat(record.E1RM),
+				formatter.FormatWeightFloat(record.Previous),
+			))
+		}
+
+		_, _ = a.bot.Send(tgbotapi.NewMessage(group.ChatID(), sb.String()))
+	}
+}
+
+func (a *API) sendGroupError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errGroup)
+	switch {
+	case errors.Is(err, errs.ErrNotGroupMember):
+		text = a.text(locale, errNotGroupMember)
+	case errors.Is(err, errs.ErrTooManyGroupLifts):
+		text = a.textf(locale, errTooManyGroupLifts, entity.MaxGroupLifts)
+	case errors.Is(err, errs.ErrExerciseNotFound):
+		text = a.text(locale, errGroupLiftNotFound)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
@@ -0,0 +1,223 @@
+package tg
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+const (
+	gymSelectAction    = "select:"
+	gymDeleteAction    = "delete:"
+	gymNewAction       = "new"
+	gymAnalyticsAction = "analytics:"
+
+	analyticsOn  = "on"
+	analyticsOff = "off"
+)
+
+// GymHandler показывает текущий зал, список залов пользователя и настройку статистики.
+func (a *API) GymHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	text, markup, err := a.gymScreen(locale, userID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGym)))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) GymActionHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	action := strings.TrimPrefix(callback.Data, gymPrefix)
+
+	var err error
+	switch {
+	case action == gymNewAction:
+		a.startCreateGym(chatID, userID, locale)
+		return
+	case strings.HasPrefix(action, gymSelectAction):
+		gymID := uuid.Nil
+		if rawID := strings.TrimPrefix(action, gymSelectAction); rawID != "" {
+			gymID, err = uuid.Parse(rawID)
+		}
+		if err == nil {
+			err = a.trainingService.SelectGym(a.ctx, userID, gymID)
+		}
+	case strings.HasPrefix(action, gymDeleteAction):
+		var gymID uuid.UUID
+		gymID, err = uuid.Parse(strings.TrimPrefix(action, gymDeleteAction))
+		if err == nil {
+			err = a.trainingService.DeleteGym(a.ctx, userID, gymID)
+		}
+	case strings.HasPrefix(action, gymAnalyticsAction):
+		enabled := strings.TrimPrefix(action, gymAnalyticsAction) == analyticsOn
+		err = a.trainingService.SetAnalyticsByGym(a.ctx, userID, enabled)
+	default:
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	if err != nil {
+		text := a.text(locale, errGym)
+		if errors.Is(err, errs.ErrGymNotFound) {
+			text = a.text(locale, errGymNotFound)
+		}
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+		return
+	}
+
+	text, markup, err := a.gymScreen(locale, userID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGym)))
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+}
+
+func (a *API) startCreateGym(chatID int64, userID string, locale i18n.Locale) {
+	equipment, err := a.trainingService.GetEquipmentTypes(a.ctx)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGym)))
+		return
+	}
+
+	a.setUserState(userID, entity.StateAwaitingGymInput)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, startCreateGymText, strings.Join(equipment, ", "))))
+}
+
+// CreateGymHandler ожидает название зала в первой строке и оборудование через запятую во второй.
+func (a *API) CreateGymHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	lines := strings.SplitN(strings.TrimSpace(message.Text), "\n", 2)
+	name := strings.TrimSpace(lines[0])
+	if len(lines) != 2 || name == "" {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidFormat)))
+		return
+	}
+
+	known, err := a.trainingService.GetEquipmentTypes(a.ctx)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGym)))
+		return
+	}
+
+	equipment, ok := parseEquipment(lines[1], known)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errUnknownEquipment, strings.Join(known, ", "))))
+		return
+	}
+
+	gym, err := a.trainingService.CreateGym(a.ctx, userID, name, equipment)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGym)))
+		return
+	}
+
+	a.clearUserState(userID)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, gymCreatedText, gym.Name())))
+}
+
+func (a *API) gymScreen(locale i18n.Locale, userID string) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	gyms, err := a.trainingService.GetGyms(a.ctx, userID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	settings, err := a.trainingService.GetUserSettings(a.ctx, userID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := a.text(locale, noGymText)
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	for _, gym := range gyms {
+		label := gym.Name()
+		if gym.ID() == settings.GymID() {
+			label = "✅ " + label
+			text = a.textf(locale, currentGymText, gym.Name(), strings.Join(gym.Equipment(), ", "))
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, gymPrefix+gymSelectAction+gym.ID().String()),
+			tgbotapi.NewInlineKeyboardButtonData("🗑", gymPrefix+gymDeleteAction+gym.ID().String()),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, newGymButtonText), gymPrefix+gymNewAction),
+	))
+
+	if settings.GymID() != uuid.Nil {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, noGymButtonText), gymPrefix+gymSelectAction),
+		))
+	}
+
+	if settings.AnalyticsByGym() {
+		text += a.text(locale, analyticsByGymText)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, analyticsAllGymsButtonText), gymPrefix+gymAnalyticsAction+analyticsOff),
+		))
+	} else {
+		text += a.text(locale, analyticsAllGymsText)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, analyticsByGymButtonText), gymPrefix+gymAnalyticsAction+analyticsOn),
+		))
+	}
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// parseEquipment сопоставляет введенное оборудование с каталогом без учета регистра.
+func parseEquipment(input string, known []string) ([]string, bool) {
+	var equipment []string
+	candidates := append(slices.Clone(known), entity.EquipmentBodyweight)
+
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		matched := ""
+		for _, k := range candidates {
+			if strings.EqualFold(item, k) {
+				matched = k
+				break
+			}
+		}
+		if matched == "" {
+			return nil, false
+		}
+
+		equipment = append(equipment, matched)
+	}
+
+	return equipment, len(equipment) > 0
+}
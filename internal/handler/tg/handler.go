@@ -3,7 +3,7 @@ package tg
 import (
 	"errors"
 	"fmt"
-	"slices"
+	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -15,7 +15,9 @@ import (
 	"gymnote/internal/entity"
 	"gymnote/internal/errs"
 	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
 	"gymnote/internal/onerm"
+	"gymnote/internal/parser"
 )
 
 var (
@@ -28,15 +30,26 @@ var (
 
 func (a *API) StartHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
+	locale := a.userLocale(message.From)
+
+	if payload := message.CommandArguments(); strings.HasPrefix(payload, coachInvitePayload) {
+		a.coachInviteConsent(message, payload)
+		return
+	}
+
+	if payload := message.CommandArguments(); strings.HasPrefix(payload, partnerInvitePayload) {
+		a.partnerInviteConsent(message, payload)
+		return
+	}
 
 	if a.cfg.GreetingStickerID != "" {
 		sticker := tgbotapi.NewSticker(chatID, tgbotapi.FileID(a.cfg.GreetingStickerID))
 		_, _ = a.bot.Send(sticker)
 	}
 
-	text := startText
+	text := a.text(locale, startText)
 	if a.cfg.AuthorName != "" {
-		text += fmt.Sprintf(donateAuthorText, a.cfg.AuthorName)
+		text += a.textf(locale, donateAuthorText, a.cfg.AuthorName)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
@@ -44,20 +57,22 @@ func (a *API) StartHandler(message *tgbotapi.Message) {
 }
 
 func (a *API) HelpHandler(message *tgbotapi.Message) {
-	msg := tgbotapi.NewMessage(message.Chat.ID, helpText)
+	locale := a.userLocale(message.From)
+	msg := tgbotapi.NewMessage(message.Chat.ID, a.text(locale, helpText))
 	_, _ = a.bot.Send(msg)
 }
 
 func (a *API) OneRMHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 	defer a.clearUserState(userID)
 
 	input := strings.TrimSpace(message.Text)
 	parts := strings.SplitN(input, "\n", 2)
 	setData := strings.Split(parts[0], ",")
 	if len(setData) != 2 {
-		msg := tgbotapi.NewMessage(chatID, errInvalidFormat)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInvalidFormat))
 		_, _ = a.bot.Send(msg)
 		return
 	}
@@ -65,46 +80,46 @@ func (a *API) OneRMHandler(message *tgbotapi.Message) {
 	weight, errWeight := strconv.ParseFloat(strings.TrimSpace(setData[0]), 64)
 	reps, errReps := strconv.Atoi(strings.TrimSpace(setData[1]))
 	if errWeight != nil || errReps != nil || weight <= 0 || reps <= 0 {
-		msg := tgbotapi.NewMessage(chatID, errParseData)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errParseData))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
 	summary := onerm.Calculate(weight, reps)
 	if len(summary.Results) == 0 || summary.Average <= 0 {
-		msg := tgbotapi.NewMessage(chatID, errGeneral)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInternal))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
 	formulaNames := map[onerm.Formula]string{
-		onerm.FormulaEpley:    "Эпли",
-		onerm.FormulaBrzycki:  "Бжицки",
-		onerm.FormulaLander:   "Лэндер",
-		onerm.FormulaLombardi: "Ломбарди",
-		onerm.FormulaMayhew:   "Мэйхью",
-		onerm.FormulaOConner:  "О'Коннор",
-		onerm.FormulaWathan:   "Ватан",
+		onerm.FormulaEpley:    formulaEpleyText,
+		onerm.FormulaBrzycki:  formulaBrzyckiText,
+		onerm.FormulaLander:   formulaLanderText,
+		onerm.FormulaLombardi: formulaLombardiText,
+		onerm.FormulaMayhew:   formulaMayhewText,
+		onerm.FormulaOConner:  formulaOConnerText,
+		onerm.FormulaWathan:   formulaWathanText,
 	}
 
 	var sb strings.Builder
-	sb.WriteString("📈 Расчёт одноповторного максимума\n\n")
-	sb.WriteString(fmt.Sprintf("Исходные данные: %s кг x %d\n\n", formatter.FormatWeightFloat(weight), reps))
-	sb.WriteString("Формулы:\n\n")
+	sb.WriteString(a.text(locale, oneRMTitleText))
+	sb.WriteString(a.textf(locale, oneRMInputText, formatter.FormatWeightFloat(weight), reps))
+	sb.WriteString(a.text(locale, oneRMFormulasText))
 	for _, r := range summary.Results {
-		name := formulaNames[r.Formula]
-		if name == "" {
-			name = string(r.Formula)
+		name := string(r.Formula)
+		if key, ok := formulaNames[r.Formula]; ok {
+			name = a.text(locale, key)
 		}
-		sb.WriteString(fmt.Sprintf("• *%s*: %s кг\n", name, formatter.FormatWeightFloat(r.Value)))
+		sb.WriteString(a.textf(locale, oneRMFormulaText, name, formatter.FormatWeightFloat(r.Value)))
 	}
 
-	sb.WriteString(fmt.Sprintf("\nСредний 1ПМ: %s кг\n\n", formatter.FormatWeightFloat(summary.Average)))
-	sb.WriteString("Проценты от 1ПМ:\n")
+	sb.WriteString(a.textf(locale, oneRMAverageText, formatter.FormatWeightFloat(summary.Average)))
+	sb.WriteString(a.text(locale, oneRMPercentagesText))
 	percentages := []int{50, 60, 70, 75, 80, 85, 90, 95, 100}
 	for _, p := range percentages {
 		val := summary.Average * float64(p) / 100
-		sb.WriteString(fmt.Sprintf("• %d%%: %s кг\n", p, formatter.FormatWeightFloat(val)))
+		sb.WriteString(a.textf(locale, oneRMPercentageText, p, formatter.FormatWeightFloat(val)))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, sb.String())
@@ -115,24 +130,21 @@ func (a *API) OneRMHandler(message *tgbotapi.Message) {
 func (a *API) StartOneRMHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	a.setUserState(userID, entity.StateAwaitingOneRMInput)
-	msg := tgbotapi.NewMessage(chatID, startOneRMText)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, startOneRMText))
 	_, _ = a.bot.Send(msg)
 }
 
 func (a *API) StartExerciseProgressionChartHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, group := range muscleGroupsWithSmiles {
-		plainGroup := strings.TrimLeft(group, muscleGroupSmilePrefix)
-		button := tgbotapi.NewInlineKeyboardButtonData(group, musclePrefix+plainGroup)
-		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
-	}
+	buttons := a.muscleGroupButtons(locale)
 
-	msg := tgbotapi.NewMessage(chatID, startProgressionMuscleGroupSelectText)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, startProgressionMuscleGroupSelectText))
 	msg.ParseMode = parseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
@@ -146,29 +158,35 @@ func (a *API) ExerciseProgressionChartHandler(callback *tgbotapi.CallbackQuery)
 	userID := strconv.FormatInt(callback.From.ID, 10)
 	messageID := callback.Message.MessageID
 	exerciseIDStr := strings.TrimPrefix(callback.Data, startGetExerciseProgressionPrefix)
+	locale := a.userLocale(callback.From)
 
 	defer a.clearUserState(userID)
 
 	exerciseID, err := uuid.Parse(exerciseIDStr)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, errInvalidExerciseID)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	loadingMsg := tgbotapi.NewEditMessageText(chatID, messageID, loadingProgressionText)
+	loadingMsg := tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, loadingProgressionText))
 	_, _ = a.bot.Send(loadingMsg)
 
 	data, err := a.trainingService.GetExerciseProgression(a.ctx, userID, exerciseID)
 	if err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errGetTrainings))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGetTrainings)))
 		return
 	}
 	if len(data) == 0 {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, notFoundTrainingsText))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
 		return
 	}
 
+	a.sendProgressionChart(chatID, locale, userID, data)
+}
+
+// sendProgressionChart рисует график рабочего веса, fileKey отделяет файлы разных пользователей.
+func (a *API) sendProgressionChart(chatID int64, locale i18n.Locale, fileKey string, data []entity.ExerciseProgression) {
 	var xValues []string
 	var yValues []float32
 
@@ -180,15 +198,15 @@ func (a *API) ExerciseProgressionChartHandler(callback *tgbotapi.CallbackQuery)
 	exerciseName := data[0].ExerciseName
 	cfg := chart.LinearChartConfig{
 		Title:    exerciseName,
-		XName:    "Дата",
-		YName:    "Вес (кг)",
+		XName:    a.text(locale, chartDateText),
+		YName:    a.text(locale, chartWeightText),
 		YValues:  yValues,
 		XValues:  xValues,
-		FileName: fmt.Sprintf("%s/%s-%s.png", a.cfg.GraphicsPath, userID, time.Now().Format(time.DateOnly)),
+		FileName: fmt.Sprintf("%s/%s-%s.png", a.cfg.GraphicsPath, fileKey, time.Now().Format(time.DateOnly)),
 	}
 
-	if err = a.chartService.GenerateLinearChart(cfg); err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errProgression))
+	if err := a.chartService.GenerateLinearChart(cfg); err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errProgression)))
 		return
 	}
 
@@ -199,15 +217,11 @@ func (a *API) ExerciseProgressionChartHandler(callback *tgbotapi.CallbackQuery)
 func (a *API) StartExerciseHistoryHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, group := range muscleGroupsWithSmiles {
-		plainGroup := strings.TrimLeft(group, muscleGroupSmilePrefix)
-		button := tgbotapi.NewInlineKeyboardButtonData(group, musclePrefix+plainGroup)
-		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
-	}
+	buttons := a.muscleGroupButtons(locale)
 
-	msg := tgbotapi.NewMessage(chatID, startExerciseHistoryMuscleGroupSelectText)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, startExerciseHistoryMuscleGroupSelectText))
 	msg.ParseMode = parseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
@@ -221,31 +235,32 @@ func (a *API) ExerciseHistoryHandler(callback *tgbotapi.CallbackQuery) {
 	userID := strconv.FormatInt(callback.From.ID, 10)
 	messageID := callback.Message.MessageID
 	exerciseIDStr := strings.TrimPrefix(callback.Data, startGetExerciseHistoryPrefix)
+	locale := a.userLocale(callback.From)
 
 	defer a.clearUserState(userID)
 
 	exerciseID, err := uuid.Parse(exerciseIDStr)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, errInvalidExerciseID)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
 	sets, err := a.trainingService.GetLastSetsForExercise(a.ctx, userID, exerciseID, daysForExerciseHistory)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, errInternal)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInternal))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
 	if len(sets) == 0 {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, notFoundTrainingsText))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
 		return
 	}
 
 	lastSets := a.formatter.FormatLastSets(sets)
 
-	msgText := fmt.Sprintf(lastSetsText, lastSets)
+	msgText := a.textf(locale, lastSetsText, lastSets)
 
 	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, msgText)
 	editMsg.ParseMode = parseMode
@@ -256,14 +271,16 @@ func (a *API) ExerciseHistoryHandler(callback *tgbotapi.CallbackQuery) {
 func (a *API) StartGetTrainingsHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	a.setUserState(userID, entity.StateAwaitingGetTrainingsInput)
-	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, startGetTrainingsText))
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, startGetTrainingsText)))
 }
 
 func (a *API) GetTrainingsHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	defer a.clearUserState(userID)
 
@@ -285,136 +302,218 @@ func (a *API) GetTrainingsHandler(message *tgbotapi.Message) {
 
 	trainings, err := a.trainingService.GetTrainingSessions(a.ctx, userID, fromDate, toDate)
 	if err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errGetTrainings))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errGetTrainings)))
 		return
 	}
 	if len(trainings) == 0 {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, notFoundTrainingsText))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, notFoundTrainingsText)))
 		return
 	}
 
-	text := a.formatter.FormatTrainingLogs(trainings)
+	text := a.formatTrainingLogs(trainings)
 	chunks := splitMessage(text, maxTgMessageLength)
 
 	for _, chunk := range chunks {
 		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, chunk))
 	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.formatTrainingsSummary(locale, entity.SummarizeSessions(trainings))))
+
+	a.sendTrainingPicker(chatID, locale, trainings)
 }
 
 func (a *API) UnknownCommandHandler(message *tgbotapi.Message) {
-	msg := tgbotapi.NewMessage(message.Chat.ID, unknownCommandText)
+	locale := a.userLocale(message.From)
+	msg := tgbotapi.NewMessage(message.Chat.ID, a.text(locale, unknownCommandText))
 	_, _ = a.bot.Send(msg)
 }
 
 func (a *API) StartCreateExerciseHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
-
-	if a.cfg.AuthorName != "" && message.From.UserName != a.cfg.AuthorName {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, adminOnlyText))
-		return
-	}
+	locale := a.userLocale(message.From)
 
 	a.setUserState(userID, entity.StateAwaitingExerciseInput)
-	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, startCreateExerciseText))
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, startCreateExerciseText)))
 }
 
 func (a *API) CreateExerciseHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	defer a.clearUserState(userID)
 
 	lines := strings.Split(strings.TrimSpace(message.Text), "\n")
 	if len(lines) < 3 {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, startCreateExerciseText)))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, a.text(locale, startCreateExerciseText))))
 		return
 	}
 
-	name, muscleGroup, equipment := strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1]), strings.TrimSpace(lines[2])
+	name, muscleGroupInput, equipment := strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1]), strings.TrimSpace(lines[2])
 	if name == "" {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, emptyExerciseNameText)))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, a.text(locale, emptyExerciseNameText))))
 		return
 	}
-	if !slices.Contains(availableMuscleGroups, muscleGroup) {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, fmt.Sprintf(unknownMuscleGroupText, availableMuscleGroups))))
+	muscleGroup, ok := a.findMuscleGroup(muscleGroupInput)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, a.textf(locale, unknownMuscleGroupText, a.availableMuscleGroups(locale)))))
 		return
 	}
 
-	err := a.trainingService.CreateExercise(a.ctx, name, muscleGroup, equipment)
+	var secondary []entity.SecondaryMuscle
+	if len(lines) > 3 {
+		secondary, ok = a.parseSecondaryMuscles(lines[3])
+		if !ok {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errSecondaryMuscles, a.availableMuscleGroups(locale))))
+			return
+		}
+	}
+
+	ownerID := userID
+	if a.isAdmin(message.From) {
+		ownerID = ""
+	}
+
+	err := a.trainingService.CreateExercise(a.ctx, ownerID, name, muscleGroup, equipment, secondary)
 	if err != nil {
-		if errors.Is(err, errs.ErrExerciseAlreadyExists) {
-			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, fmt.Sprintf(exerciseWithNameAlreadyExistsText, name))))
-		} else {
-			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errCreateExercise))
+		switch {
+		case errors.Is(err, errs.ErrExerciseAlreadyExists):
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, a.textf(locale, exerciseWithNameAlreadyExistsText, name))))
+		case errors.Is(err, errs.ErrUnknownMuscleGroup):
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, a.textf(locale, unknownMuscleGroupText, a.availableMuscleGroups(locale)))))
+		default:
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCreateExercise)))
 		}
 		return
 	}
 
-	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(exerciseCreatedText, name, muscleGroup)))
+	createdText := exerciseCreatedText
+	if ownerID != "" {
+		createdText = privateExerciseCreatedText
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, createdText, name, a.muscleGroupName(locale, muscleGroup))))
 }
 
 func (a *API) StartUploadTrainingHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	a.setUserState(userID, entity.StateAwaitingTrainingInput)
-	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, startUploadTrainingText))
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, startUploadTrainingText)))
 }
 
 func (a *API) UploadTrainingHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	defer a.clearUserState(userID)
 
-	session, err := a.trainingService.ParseTraining(a.ctx, entity.Event{UserID: userID, Text: message.Text})
+	text := message.Text
+	if message.Document != nil {
+		content, err := a.downloadTextDocument(message.Document)
+		if err != nil {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errUploadFile)))
+			return
+		}
+		text = content
+	}
+
+	var progressMessageID int
+	onProgress := func(done, total int) {
+		progressText := a.textf(locale, uploadProgressText, done, total)
+		if progressMessageID == 0 {
+			sent, err := a.bot.Send(tgbotapi.NewMessage(chatID, progressText))
+			if err == nil {
+				progressMessageID = sent.MessageID
+			}
+			return
+		}
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, progressMessageID, progressText))
+	}
+
+	sessions, err := a.trainingService.ParseTrainings(a.ctx, entity.Event{UserID: userID, Text: text}, onProgress)
 	if err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errUploadTraining))
+		a.sendUploadError(chatID, locale, err)
 		return
 	}
 
-	text := fmt.Sprintf(finishText, session.ExerciseCount(), session.SetCount(), session.TotalVolume())
+	var sb strings.Builder
+	for _, session := range sessions {
+		sb.WriteString(a.textf(locale, uploadSessionSummaryText, session.Date().Format(time.DateOnly), session.ExerciseCount(), session.SetCount(), session.TotalVolume()))
+	}
+
+	summary := a.textf(locale, uploadFinishText, len(sessions), sb.String())
+	for _, chunk := range splitMessage(summary, maxTgMessageLength) {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, chunk))
+	}
+}
+
+// sendUploadError показывает ошибки во введенном тексте, остальные ошибки заменяет общим сообщением.
+func (a *API) sendUploadError(chatID int64, locale i18n.Locale, err error) {
+	var lineErr *parser.LineError
+
+	text := a.text(locale, errUploadTraining)
+	switch {
+	case errors.As(err, &lineErr) && errors.Is(lineErr, errs.ErrExerciseNotFound):
+		text = a.textf(locale, errUploadExercise, lineErr.Line, lineErr.Text)
+	case errors.As(err, &lineErr):
+		text = a.textf(locale, errUploadLine, lineErr.Line, lineErr.Text)
+	case errors.Is(err, errs.ErrNoTrainingData), errors.Is(err, errs.ErrInvalidEventData):
+		text = a.text(locale, errUploadEmpty)
+	default:
+		log.Printf("Error uploading trainings: %v\n", err)
+	}
+
 	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
 }
 
 func (a *API) ClearTrainingHandler(message *tgbotapi.Message) {
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	defer a.clearUserState(userID)
 
-	if err := a.trainingService.ClearSession(a.ctx, userID); err != nil {
-		text := errClearTraining
+	session, err := a.trainingService.ClearSession(a.ctx, userID)
+	if err != nil {
+		text := a.text(locale, errClearTraining)
 		if errors.Is(err, errs.ErrSessionNotFound) {
-			text = errNoTraining
+			text = a.text(locale, errNoTraining)
 		}
-		msg := tgbotapi.NewMessage(message.From.ID, text)
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	_, _ = a.bot.Send(tgbotapi.NewMessage(message.From.ID, clearTrainingDoneText))
+	a.closeDashboard(message.Chat.ID, session)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(message.Chat.ID, a.text(locale, clearTrainingDoneText)))
 }
 
 func (a *API) StartTrainingHandler(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
 
 	_, err := a.trainingService.StartTraining(a.ctx, userID)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(errStartTraining, err))
+		msg := tgbotapi.NewMessage(chatID, a.textf(locale, errStartTraining, err))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, group := range muscleGroupsWithSmiles {
-		plainGroup := strings.TrimLeft(group, muscleGroupSmilePrefix)
-		button := tgbotapi.NewInlineKeyboardButtonData(group, musclePrefix+plainGroup)
-		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
+	a.refreshDashboard(chatID, locale, userID)
+
+	buttons := a.muscleGroupButtons(locale)
+
+	text := a.text(locale, startTrainingText)
+	if gym, err := a.trainingService.GetCurrentGym(a.ctx, userID); err == nil && gym != nil {
+		text += a.textf(locale, trainingGymText, tgbotapi.EscapeText(parseMode, gym.Name()))
 	}
 
-	msg := tgbotapi.NewMessage(chatID, startTrainingText)
+	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = parseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
@@ -426,27 +525,40 @@ func (a *API) MuscleGroupHandler(callback *tgbotapi.CallbackQuery) {
 	messageID := callback.Message.MessageID
 	userID := strconv.FormatInt(callback.From.ID, 10)
 	state := a.getUserState(userID)
+	locale := a.userLocale(callback.From)
 
 	muscleGroup, page, _, cancelExerciseID, err := parseMuscleGroupCallbackData(callback.Data)
 	if err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, err)))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, err)))
 		return
 	}
 
 	if cancelExerciseID != uuid.Nil {
 		if err := a.trainingService.DeleteExercise(a.ctx, userID, cancelExerciseID); err != nil {
-			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, err)))
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, err)))
 			return
 		}
 	}
 
-	exercises, err := a.trainingService.GetExercisesByMuscleGroup(a.ctx, muscleGroup)
+	var exercises []entity.Exercise
+	if muscleGroup == favoritesMuscleGroup {
+		exercises, err = a.trainingService.GetFavoriteExercises(a.ctx, userID)
+	} else {
+		exercises, err = a.trainingService.GetExercisesByMuscleGroup(a.ctx, userID, muscleGroup)
+	}
+	if err == nil && state != entity.StateAwaitingExerciseProgression && state != entity.StateAwaitingExerciseHistory {
+		exercises, err = a.trainingService.FilterExercisesByGym(a.ctx, userID, exercises)
+	}
 	if err != nil {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errExerciseLoad))
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
 		return
 	}
 	if len(exercises) == 0 {
-		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, errNoExercises))
+		text := a.text(locale, errNoExercises)
+		if muscleGroup == favoritesMuscleGroup {
+			text = a.text(locale, errNoFavoriteExercises)
+		}
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
 		return
 	}
 
@@ -466,18 +578,22 @@ func (a *API) MuscleGroupHandler(callback *tgbotapi.CallbackQuery) {
 
 	var buttons [][]tgbotapi.InlineKeyboardButton
 	for _, exercise := range pagedExercises {
-		button := tgbotapi.NewInlineKeyboardButtonData(exercise.Name(), callbackDataPrefix+exercise.ID().String())
+		label := exercise.Name()
+		if !exercise.IsPublic() {
+			label = a.textf(locale, privateExerciseLabelText, label)
+		}
+		button := tgbotapi.NewInlineKeyboardButtonData(label, callbackDataPrefix+exercise.ID().String())
 		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
 	}
 
 	var paginationButtons []tgbotapi.InlineKeyboardButton
 	if page > 0 {
-		prevButton := tgbotapi.NewInlineKeyboardButtonData(paginationPrevText, fmt.Sprintf("%s%s:%s:%d", musclePrefix, muscleGroup, prevDirection, page-1))
+		prevButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, paginationPrevText), fmt.Sprintf("%s%s:%s:%d", musclePrefix, muscleGroup, prevDirection, page-1))
 		paginationButtons = append(paginationButtons, prevButton)
 	}
 
 	if page < totalPages-1 {
-		nextButton := tgbotapi.NewInlineKeyboardButtonData(paginationNextText, fmt.Sprintf("%s%s:%s:%d", musclePrefix, muscleGroup, nextDirection, page+1))
+		nextButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, paginationNextText), fmt.Sprintf("%s%s:%s:%d", musclePrefix, muscleGroup, nextDirection, page+1))
 		paginationButtons = append(paginationButtons, nextButton)
 	}
 
@@ -486,11 +602,11 @@ func (a *API) MuscleGroupHandler(callback *tgbotapi.CallbackQuery) {
 	}
 
 	if page < totalPages-1 && len(paginationButtons) == 1 {
-		backButton := tgbotapi.NewInlineKeyboardButtonData(backToMuscleGroupsText, backToMuscleGroups)
+		backButton := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, backToMuscleGroupsText), backToMuscleGroups)
 		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(backButton))
 	}
 
-	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, fmt.Sprintf(muscleGroupDoneText, muscleGroup))
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, muscleGroupDoneText, a.muscleGroupTitle(locale, muscleGroup)))
 	editMsg.ParseMode = parseMode
 
 	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.NewInlineKeyboardMarkup(buttons...))
@@ -502,15 +618,11 @@ func (a *API) MuscleGroupHandler(callback *tgbotapi.CallbackQuery) {
 func (a *API) BackToMuscleGroupsHandler(callback *tgbotapi.CallbackQuery) {
 	chatID := callback.Message.Chat.ID
 	messageID := callback.Message.MessageID
+	locale := a.userLocale(callback.From)
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, group := range muscleGroupsWithSmiles {
-		plainGroup := strings.TrimLeft(group, muscleGroupSmilePrefix)
-		button := tgbotapi.NewInlineKeyboardButtonData(group, musclePrefix+plainGroup)
-		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
-	}
+	buttons := a.muscleGroupButtons(locale)
 
-	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, muscleGroupSelectText)
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, muscleGroupSelectText))
 	editMsg.ParseMode = parseMode
 	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.NewInlineKeyboardMarkup(buttons...))
 
@@ -524,9 +636,10 @@ func (a *API) ExerciseHandler(callback *tgbotapi.CallbackQuery) {
 	userID := strconv.FormatInt(callback.From.ID, 10)
 	text := strings.TrimPrefix(callback.Data, exercisePrefix)
 	args := strings.SplitN(text, ":", 2)
+	locale := a.userLocale(callback.From)
 
 	if len(args) != 2 {
-		msg := tgbotapi.NewMessage(chatID, errInternal)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInternal))
 		_, _ = a.bot.Send(msg)
 		return
 	}
@@ -535,106 +648,71 @@ func (a *API) ExerciseHandler(callback *tgbotapi.CallbackQuery) {
 
 	exerciseID, err := uuid.Parse(exerciseIDStr)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, errInvalidExerciseID)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
 	err = a.trainingService.AddTrainingExercise(a.ctx, userID, exerciseID)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(errAddExercise, err))
+		msg := tgbotapi.NewMessage(chatID, a.textf(locale, errAddExercise, err))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	sets, err := a.trainingService.GetLastSetsForExercise(a.ctx, userID, exerciseID, daysForSetStatistics)
+	msgText, err := a.exerciseScreenText(locale, userID, exerciseID)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, errInternal)
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInternal))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	lastSets := a.formatter.FormatLastSets(sets)
-
-	backButton := tgbotapi.NewInlineKeyboardButtonData(backToExercisesText, fmt.Sprintf("%s%s:%s:0:%s", musclePrefix, muscleGroup, nextDirection, exerciseIDStr))
-	buttons := [][]tgbotapi.InlineKeyboardButton{
-		{backButton},
+	pinned := false
+	if settings, err := a.trainingService.GetUserSettings(a.ctx, userID); err == nil {
+		pinned = settings.IsFavorite(exerciseID)
 	}
 
-	msgText := exerciseText
-	if lastSets != "" {
-		msgText = fmt.Sprintf("%s\n\n%s", exerciseText, fmt.Sprintf(lastSetsText, lastSets))
-	}
 	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, msgText)
 	editMsg.ParseMode = parseMode
 
-	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.NewInlineKeyboardMarkup(buttons...))
+	editMarkup := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, a.exerciseScreenMarkup(locale, muscleGroup, exerciseID, pinned))
 
 	a.setUserState(userID, entity.StateAwaitingSetInput)
 	_, _ = a.bot.Send(editMsg)
 	_, _ = a.bot.Send(editMarkup)
+
+	a.refreshDashboard(chatID, locale, userID)
+	a.syncPartners(callback.From)
 }
 
 func (a *API) SetHandler(message *tgbotapi.Message) {
 	userID := strconv.FormatInt(message.From.ID, 10)
-	input := message.Text
-
-	parts := strings.SplitN(input, "\n", 2)
-	setData := strings.Split(parts[0], ",")
-	if len(setData) != 2 {
-		msg := tgbotapi.NewMessage(message.Chat.ID, errInvalidFormat)
-		_, _ = a.bot.Send(msg)
-		return
-	}
-
-	weight, errWeight := strconv.ParseFloat(setData[0], 64)
-	reps, errReps := strconv.Atoi(setData[1])
-	if errWeight != nil || errReps != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID, errParseData)
-		_, _ = a.bot.Send(msg)
-		return
-	}
-	var notes string
-	if len(parts) > 1 {
-		notes = strings.TrimSpace(parts[1])
-	}
+	locale := a.userLocale(message.From)
 
-	err := a.trainingService.AddOrUpdateSet(a.ctx, userID, message.MessageID, float32(weight), uint8(reps), notes)
+	_, err := a.trainingService.AddOrUpdateSet(a.ctx, userID, message.MessageID, message.Text)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID, fmt.Sprintf(errGeneral, err))
+		text := a.textf(locale, errGeneral, err)
+		if errors.Is(err, errs.ErrInvalidSetFormat) {
+			text = a.text(locale, errInvalidSetFormat)
+		}
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(startNewExerciseText, startNewExercisePrefix),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(finishTrainingText, confirmationFinishTrainingPrefix),
-		),
-	)
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, setText)
-	msg.ReplyMarkup = keyboard
-
-	_, _ = a.bot.Send(msg)
+	a.refreshDashboard(message.Chat.ID, locale, userID)
 }
 
 func (a *API) StartNewExerciseHandler(callback *tgbotapi.CallbackQuery) {
 	chatID := callback.Message.Chat.ID
 	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
 
 	defer a.clearUserState(userID)
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	for _, group := range muscleGroupsWithSmiles {
-		plainGroup := strings.TrimLeft(group, muscleGroupSmilePrefix)
-		button := tgbotapi.NewInlineKeyboardButtonData(group, musclePrefix+plainGroup)
-		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
-	}
+	buttons := a.muscleGroupButtons(locale)
 
-	msg := tgbotapi.NewMessage(chatID, muscleGroupSelectText)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, muscleGroupSelectText))
 	msg.ParseMode = parseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
@@ -643,17 +721,18 @@ func (a *API) StartNewExerciseHandler(callback *tgbotapi.CallbackQuery) {
 
 func (a *API) ConfirmationFinishTrainingHandler(callback *tgbotapi.CallbackQuery) {
 	chatID := callback.Message.Chat.ID
+	locale := a.userLocale(callback.From)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(answerYes, acceptFinishTrainingPrefix),
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerYes), acceptFinishTrainingPrefix),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(answerNo, rejectFinishTrainingPrefix),
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerNo), rejectFinishTrainingPrefix),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, finishTrainingConfirmationText)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, finishTrainingConfirmationText))
 	msg.ReplyMarkup = keyboard
 
 	_, _ = a.bot.Send(msg)
@@ -663,29 +742,55 @@ func (a *API) AcceptFinishTrainingHandler(callback *tgbotapi.CallbackQuery) {
 	chatID := callback.Message.Chat.ID
 	messageID := callback.Message.MessageID
 	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
 
 	defer a.clearUserState(userID)
 
 	session, err := a.trainingService.EndSession(a.ctx, userID)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, trainingAlreadyFinishedText)))
+		return
+	}
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(errGeneral, err))
+		msg := tgbotapi.NewMessage(chatID, a.textf(locale, errGeneral, err))
 		_, _ = a.bot.Send(msg)
 		return
 	}
 
-	text := fmt.Sprintf(finishText, session.ExerciseCount(), session.SetCount(), session.TotalVolume())
+	a.closeDashboard(chatID, session)
+
+	if session.IsEmpty() {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, emptyTrainingFinishedText)))
+		return
+	}
+
+	text := a.textf(locale, finishText, session.ExerciseCount(), session.SetCount(), session.TotalVolume())
+	text += a.formatMuscleVolume(locale, session.MuscleVolume())
+
+	pace := session.Pace()
+	text += a.formatPace(locale, pace)
+
 	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
 	editMsg.ParseMode = parseMode
+	if pace.TimeUnderSession > 0 {
+		markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, restReportButtonText), restReportPrefix+session.ID().String()),
+		))
+		editMsg.ReplyMarkup = &markup
+	}
 
 	_, _ = a.bot.Send(editMsg)
 
-	details := a.formatter.FormatTrainingLogs([]entity.TrainingSession{*session})
+	details := a.formatTrainingLogs([]entity.TrainingSession{*session})
 	if details != "" {
 		chunks := splitMessage(details, maxTgMessageLength)
 		for _, chunk := range chunks {
 			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, chunk))
 		}
 	}
+
+	a.sendTrainingDetailsPrompt(chatID, locale, session.ID())
+	a.onSessionFinished(session)
 }
 
 func (a *API) RejectFinishTrainingHandler(callback *tgbotapi.CallbackQuery) {
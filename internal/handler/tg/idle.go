@@ -0,0 +1,169 @@
+package tg
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/config"
+	"gymnote/internal/entity"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// RunSessionSweeper периодически ищет брошенные тренировки, пока не отменен контекст API.
+func (a *API) RunSessionSweeper(cfg *config.SessionConfig) {
+	ticker := time.NewTicker(cfg.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			sweeps, err := a.trainingService.SweepIdleSessions(a.ctx, cfg.IdleReminder, cfg.IdleClose)
+			if err != nil {
+				log.Printf("Idle sessions sweep error: %v", err)
+				continue
+			}
+
+			for _, sweep := range sweeps {
+				a.notifyIdleSweep(sweep, cfg.IdleClose)
+			}
+		}
+	}
+}
+
+func (a *API) notifyIdleSweep(sweep entity.IdleSweep, closeAfter time.Duration) {
+	session := sweep.Session
+	userID := session.UserID()
+
+	chatID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		log.Printf("Invalid user id '%s' in idle session: %v", userID, err)
+		return
+	}
+
+	locale := a.userLocale(&tgbotapi.User{ID: chatID})
+
+	switch sweep.Result {
+	case entity.IdleSweepReminded:
+		a.sendIdleReminder(chatID, locale, userID, time.Since(session.LastActivityAt()), closeAfter)
+	case entity.IdleSweepFinished:
+		a.clearUserState(userID)
+		a.closeDashboard(chatID, &session)
+
+		text := a.textf(locale, idleFinishedText, session.ExerciseCount(), session.SetCount(), session.TotalVolume())
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+
+		details := a.formatTrainingLogs([]entity.TrainingSession{session})
+		if details != "" {
+			for _, chunk := range splitMessage(details, maxTgMessageLength) {
+				_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, chunk))
+			}
+		}
+
+		a.onSessionFinished(&session)
+	case entity.IdleSweepDiscarded:
+		a.clearUserState(userID)
+		a.closeDashboard(chatID, &session)
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, idleDiscardedText)))
+	}
+}
+
+func (a *API) sendIdleReminder(chatID int64, locale i18n.Locale, userID string, idle, closeAfter time.Duration) {
+	key := idleReminderFinishText
+	if settings, err := a.trainingService.GetUserSettings(a.ctx, userID); err == nil && settings.IdleSessionAction() == entity.IdleSessionDiscard {
+		key = idleReminderDiscardText
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.textf(locale, key, formatter.FormatDuration(idle), formatter.FormatDuration(closeAfter)))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, idleKeepButtonText), idleKeepPrefix),
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, finishTrainingText), confirmationFinishTrainingPrefix),
+	))
+
+	_, _ = a.bot.Send(msg)
+}
+
+// IdleKeepHandler продлевает тренировку после напоминания.
+func (a *API) IdleKeepHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	if err := a.trainingService.KeepSession(a.ctx, userID); err != nil {
+		a.sendCurrentError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, idleKeptText)))
+}
+
+// AutoCloseHandler показывает, что делать с брошенной тренировкой, и позволяет это изменить.
+func (a *API) AutoCloseHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	settings, err := a.trainingService.GetUserSettings(a.ctx, userID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errAutoClose)))
+		return
+	}
+
+	text, markup := a.autoCloseView(locale, settings.IdleSessionAction())
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) IdleActionHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	action := entity.IdleSessionAction(strings.TrimPrefix(callback.Data, idleActionPrefix))
+
+	if err := a.trainingService.SetIdleSessionAction(a.ctx, userID, action); err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errAutoClose)))
+		return
+	}
+
+	text, markup := a.autoCloseView(locale, action)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+}
+
+func (a *API) autoCloseView(locale i18n.Locale, current entity.IdleSessionAction) (string, tgbotapi.InlineKeyboardMarkup) {
+	options := []struct {
+		action entity.IdleSessionAction
+		key    string
+	}{
+		{action: entity.IdleSessionFinish, key: autoCloseFinishText},
+		{action: entity.IdleSessionDiscard, key: autoCloseDiscardText},
+	}
+
+	var (
+		currentText string
+		rows        [][]tgbotapi.InlineKeyboardButton
+	)
+
+	for _, option := range options {
+		label := a.text(locale, option.key)
+		if option.action == current {
+			currentText = label
+			label = "✅ " + label
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, idleActionPrefix+string(option.action)),
+		))
+	}
+
+	return a.textf(locale, autoCloseText, currentText), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
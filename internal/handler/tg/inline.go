@@ -0,0 +1,121 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+// InlineQueryHandler отвечает на запрос вида "@bot жим" списком подходящих упражнений.
+func (a *API) InlineQueryHandler(query *tgbotapi.InlineQuery) {
+	userID := strconv.FormatInt(query.From.ID, 10)
+	locale := a.userLocale(query.From)
+
+	results := make([]interface{}, 0)
+
+	if strings.TrimSpace(query.Query) != "" {
+		exercises, err := a.trainingService.SearchExercises(a.ctx, userID, query.Query)
+		if err != nil && !errors.Is(err, errs.ErrSearchQueryTooShort) {
+			log.Printf("inline search err: %v", err)
+		}
+
+		for _, exercise := range exercises {
+			results = append(results, a.exerciseInlineResult(locale, userID, exercise))
+		}
+	}
+
+	inlineConfig := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     0,
+		IsPersonal:    true,
+	}
+
+	if _, err := a.bot.Request(inlineConfig); err != nil {
+		log.Printf("answer inline query err: %v", err)
+	}
+}
+
+// ChosenInlineResultHandler добавляет выбранное в inline-режиме упражнение в текущую тренировку.
+func (a *API) ChosenInlineResultHandler(result *tgbotapi.ChosenInlineResult) {
+	chatID := result.From.ID
+	userID := strconv.FormatInt(result.From.ID, 10)
+	locale := a.userLocale(result.From)
+
+	exerciseID, err := uuid.Parse(result.ResultID)
+	if err != nil {
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID))
+		_, _ = a.bot.Send(msg)
+		return
+	}
+
+	err = a.trainingService.AddTrainingExercise(a.ctx, userID, exerciseID)
+	if err != nil {
+		text := a.textf(locale, errAddExercise, err)
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			text = a.text(locale, errNoTraining)
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		_, _ = a.bot.Send(msg)
+		return
+	}
+
+	msgText, err := a.exerciseScreenText(locale, userID, exerciseID)
+	if err != nil {
+		msg := tgbotapi.NewMessage(chatID, a.text(locale, errInternal))
+		_, _ = a.bot.Send(msg)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, msgText)
+	msg.ParseMode = parseMode
+
+	a.setUserState(userID, entity.StateAwaitingSetInput)
+	_, _ = a.bot.Send(msg)
+
+	a.refreshDashboard(chatID, locale, userID)
+	a.syncPartners(result.From)
+}
+
+func (a *API) exerciseInlineResult(locale i18n.Locale, userID string, exercise entity.Exercise) tgbotapi.InlineQueryResultArticle {
+	title := exercise.Name()
+	if !exercise.IsPublic() && exercise.OwnerID() == userID {
+		title = a.textf(locale, privateExerciseLabelText, title)
+	}
+
+	article := tgbotapi.NewInlineQueryResultArticle(
+		exercise.ID().String(),
+		title,
+		a.textf(locale, inlineExerciseChosenText, exercise.Name()),
+	)
+	article.Description = a.muscleGroupName(locale, exercise.MuscleGroup())
+	if exercise.Equipment() != "" {
+		article.Description = fmt.Sprintf("%s · %s", article.Description, exercise.Equipment())
+	}
+
+	return article
+}
+
+// exerciseScreenText собирает текст экрана упражнения с подходами за последние дни.
+func (a *API) exerciseScreenText(locale i18n.Locale, userID string, exerciseID uuid.UUID) (string, error) {
+	sets, err := a.trainingService.GetLastSetsForExercise(a.ctx, userID, exerciseID, daysForSetStatistics)
+	if err != nil {
+		return "", err
+	}
+
+	lastSets := a.formatter.FormatLastSets(sets)
+	if lastSets == "" {
+		return a.text(locale, exerciseText), nil
+	}
+
+	return fmt.Sprintf("%s\n\n%s", a.text(locale, exerciseText), a.textf(locale, lastSetsText, lastSets)), nil
+}
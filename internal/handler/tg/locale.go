@@ -0,0 +1,91 @@
+package tg
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/i18n"
+)
+
+func (a *API) userLocale(user *tgbotapi.User) i18n.Locale {
+	if user == nil {
+		return i18n.DefaultLocale
+	}
+
+	userID := strconv.FormatInt(user.ID, 10)
+
+	override, ok := a.getLocaleOverride(userID)
+	if !ok {
+		settings, err := a.trainingService.GetUserSettings(a.ctx, userID)
+		if err != nil {
+			return i18n.ResolveLocale("", user.LanguageCode)
+		}
+
+		override = settings.Locale()
+		a.setLocaleOverride(userID, override)
+	}
+
+	return i18n.ResolveLocale(override, user.LanguageCode)
+}
+
+func (a *API) getLocaleOverride(userID string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	locale, ok := a.userLocales[userID]
+	return locale, ok
+}
+
+func (a *API) setLocaleOverride(userID string, locale string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.userLocales[userID] = locale
+}
+
+func (a *API) text(locale i18n.Locale, key string) string {
+	return messages.Text(locale, key)
+}
+
+func (a *API) textf(locale i18n.Locale, key string, args ...any) string {
+	return messages.Textf(locale, key, args...)
+}
+
+func (a *API) LanguageHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	locale := a.userLocale(message.From)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, languageRUText), languagePrefix+string(i18n.LocaleRU)),
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, languageENText), languagePrefix+string(i18n.LocaleEN)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, languageAutoText), languagePrefix),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, selectLanguageText))
+	msg.ReplyMarkup = keyboard
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) SelectLanguageHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	selected := strings.TrimPrefix(callback.Data, languagePrefix)
+
+	if err := a.trainingService.SetUserLocale(a.ctx, userID, selected); err != nil {
+		log.Printf("Set locale error for user '%s': %v\n", userID, err)
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(a.userLocale(callback.From), errChangeLanguage)))
+		return
+	}
+
+	a.setLocaleOverride(userID, selected)
+	locale := a.userLocale(callback.From)
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, languageChangedText)))
+}
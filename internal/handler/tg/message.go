@@ -1,5 +1,7 @@
 package tg
 
+import "gymnote/internal/i18n"
+
 const (
 	// commands
 	startCommand                  = "start"
@@ -12,6 +14,18 @@ const (
 	getExerciseProgressionCommand = "get_exercise_progression"
 	getExerciseHistoryCommand     = "get_exercise_history"
 	oneRMCommand                  = "one_rm"
+	languageCommand               = "language"
+	promoteExerciseCommand        = "promote_exercise"
+	manageExerciseCommand         = "manage_exercise"
+	gymCommand                    = "gym"
+	currentCommand                = "current"
+	autoCloseCommand              = "auto_close"
+	coachCommand                  = "coach"
+	joinGroupCommand              = "join"
+	leaveGroupCommand             = "leave"
+	leaderboardCommand            = "leaderboard"
+	groupLiftsCommand             = "group_lifts"
+	partnerCommand                = "partner"
 	// callbacks
 	musclePrefix                      = "muscle:"
 	exercisePrefix                    = "exercise:"
@@ -21,70 +35,441 @@ const (
 	startNewExercisePrefix            = "start_new_exercise:"
 	startGetExerciseProgressionPrefix = "start_progression:"
 	startGetExerciseHistoryPrefix     = "start_exercise_history:"
+	languagePrefix                    = "language:"
+	promoteExercisePrefix             = "promote_exercise:"
+	manageExercisePrefix              = "manage_exercise:"
+	exerciseEditPrefix                = "exercise_edit:"
+	exerciseEditMusclePrefix          = "exercise_edit_muscle:"
+	exerciseEditMergePrefix           = "exercise_edit_merge:"
+	exerciseEditConfirmPrefix         = "exercise_edit_confirm"
+	exerciseEditCancelPrefix          = "exercise_edit_cancel"
+
+	favoritePrefix = "favorite:"
+	gymPrefix      = "gym:"
+
+	techniquePrefix      = "technique:"
+	techniqueClosePrefix = "technique_close:"
+
+	trainingPrefix               = "training:"
+	trainingExercisePrefix       = "training_ex:"
+	trainingExerciseDeletePrefix = "training_ex_del:"
+	trainingSetPrefix            = "training_set:"
+	trainingSetDeletePrefix      = "training_set_del:"
+	trainingDatePrefix           = "training_date:"
+	trainingDeletePrefix         = "training_delete:"
+	trainingDeleteConfirmPrefix  = "training_delete_ok:"
+	trainingRatePrefix           = "training_rate:"
+	trainingDetailsPrefix        = "training_details:"
+	restReportPrefix             = "rest_report:"
+
+	idleKeepPrefix   = "idle_keep:"
+	idleActionPrefix = "idle_action:"
+
+	undoSetPrefix         = "undo_set:"
+	activeSetsPrefix      = "active_sets:"
+	activeSetDeletePrefix = "active_set_del:"
+	currentPrefix         = "current:"
+
+	coachInvitePrefix    = "coach_invite:"
+	coachAcceptPrefix    = "coach_accept:"
+	coachDeclinePrefix   = "coach_decline:"
+	coachRevokePrefix    = "coach_revoke:"
+	coachAthletePrefix   = "coach_athlete:"
+	coachTrainingsPrefix = "coach_trainings:"
+	coachSessionPrefix   = "coach_session:"
+	coachCommentPrefix   = "coach_comment:"
+	coachProgressPrefix  = "coach_progress:"
+	coachChartPrefix     = "coach_ex:"
+
+	groupPRPrefix = "group_pr:"
+
+	partnerJoinPrefix = "partner_join:"
+
+	workoutPlanPrefix    = "workout_plan:"
+	workoutAthletePrefix = "workout_to:"
+	workoutAssignPrefix  = "workout_assign:"
+	workoutStartPrefix   = "workout_start:"
 
 	backToMuscleGroups = "back_to_muscle_groups"
 
+	// favoritesMuscleGroup подборка избранного в callback данных вместо мышечной группы
+	favoritesMuscleGroup = "favorites"
+
 	nextDirection = "next"
 	prevDirection = "prev"
 )
 
+// message catalog keys, texts live in message_ru.go and message_en.go
 const (
-	startText                                 = "Я бот для ведения дневника тренировок. Используй команду /help, чтобы узнать доступные команды."
-	helpText                                  = "📋 Список команд:\n/start - Запустить бота\n/help - Показать справку\n/start_training - Начать новую тренировку\n/upload_training - Загрузить новую тренировку\n/get_trainings - Посмотреть историю тренировок\n/get_exercise_progression - Посмотреть прогрессию весов по упражнению\n/get_exercise_history - Посмотреть историю конкретного упражнения\n/create_exercise - Создать новое упражнение\n/clear_training - Сбросить текущую тренировку\n/one_rm - Рассчитать одноповторный максимум и процентовки\n\nНажимай команды и следуй подсказкам, чтобы вести тренировочный дневник!"
-	clearTrainingDoneText                     = "✅ Текущая тренировка успешно удалена!"
-	donateAuthorText                          = "\nPS: не забудь подкинуть деньжат @%s"
-	startTrainingText                         = "🏋️ *Новая тренировка началась!* Выбери мышечную группу:"
-	muscleGroupDoneText                       = "✅ Выбрано: *%s*\nТеперь выбери упражнение:"
-	muscleGroupSelectText                     = "🏋️ Выбери мышечную группу для нового упражнения:"
-	startProgressionMuscleGroupSelectText     = "В статистике учитываются тренировки за последний год.\n🏋️ Выбери мышечную группу:"
-	startExerciseHistoryMuscleGroupSelectText = "В истории учитываются последние 20 тренировок, когда выполнялось упражнение.\n🏋️ Выбери мышечную группу:"
-	exerciseText                              = "✅ Отлично! Вы выбрали упражнение.\nВведите вес и количество повторений через запятую (например: 50.5,12)\nЕсли ошиблись в введенных данных - отредактируйте сообщение"
-	lastSetsText                              = "📊 Последние подходы:\n%s"
-	setText                                   = "✅ Подход сохранён! Введите данные нового подхода, либо выберите действие:"
-	exerciseCreatedText                       = "Упражнение \"%s\" добавлено в группу \"%s\""
-	startNewExerciseText                      = "➕ Начать новое упражнение"
-	finishTrainingConfirmationText            = "Вы уверены, что хотите завершить тренировку?"
-	finishTrainingText                        = "🏁 Завершить тренировку"
-	finishText                                = "🏁 Тренировка завершена!\n• Упражнений: %d\n• Подходов: %d\n• Общий вес (кг): %.2f"
-	startOneRMText                            = "Введите вес и количество повторений через запятую (например: 152.5,5).\n\nЯ посчитаю одноповторный максимум по формулам Эпли, Бжицки, Лэндера, Ломбарди, Мэйхью, О'Коннора, Ватана, покажу среднее значение и популярные процентовки от 1ПМ."
-	notFoundTrainingsText                     = "🏋️‍♂️ Тренировок пока нет... Но каждый путь начинается с первого шага! Давай, жги, и пусть следующий запрос покажет твои крутые результаты! 🔥"
-	startCreateExerciseText                   = "Введите название упражнения, группу мышц и оборудование:\n\nФормат:\n<название>\n<группа мышц>\n<оборудование>"
-	startGetTrainingsText                     = "📅 Введите период поиска тренировок в формате: ГГГГ-ММ-ДД ГГГГ-ММ-ДД (например, 2024-12-31 2025-01-22).\nЕсли не укажете даты — покажем тренировки за последние 14 дней. 🔍"
-	startUploadTrainingText                   = "Введите всю тренировку в формате:\n<год-месяц-число> (опционально)\n<номер упражнения>. <название упражнения> - <вес>,<кол-во повторений> (заметка по подходу); <вес>,<кол-во повторений> (заметка по подходу)\n\nПример:\n2025-01-31\n1. Бабочка - 82,7 (тяжело); 72,8 (тяжело); 54.5,12 (тяжело)\n2. Жим гантелей лежа - 25,10 (нормально); 25,10 (нормально)"
-	paginationNextText                        = "Вперед ➡️"
-	paginationPrevText                        = "⬅️ Назад"
-	loadingProgressionText                    = "⏳ График уже строится, ожидайте"
-	backToMuscleGroupsText                    = "⬅️ Выбрать другую"
-	backToExercisesText                       = "⬅️ Выбрать другое"
-
-	adminOnlyText                     = "Функция доступна только избранным :)"
-	answerYes                         = "✅ Да"
-	answerNo                          = "❌ Нет"
-	emptyExerciseNameText             = "Пустое имя упражнения"
-	unknownMuscleGroupText            = "Неизвестная мышечная группа!\nДоступные: %v"
-	exerciseWithNameAlreadyExistsText = "Упражнение \"%s\" уже существует!"
-	unknownCommandText                = "Неизвестная команда. Используй /help для справки"
+	startText                                 = "start"
+	helpText                                  = "help"
+	clearTrainingDoneText                     = "clear_training_done"
+	donateAuthorText                          = "donate_author"
+	startTrainingText                         = "start_training"
+	muscleGroupDoneText                       = "muscle_group_done"
+	muscleGroupSelectText                     = "muscle_group_select"
+	startProgressionMuscleGroupSelectText     = "start_progression_muscle_group_select"
+	startExerciseHistoryMuscleGroupSelectText = "start_exercise_history_muscle_group_select"
+	exerciseText                              = "exercise"
+	lastSetsText                              = "last_sets"
+	exerciseCreatedText                       = "exercise_created"
+	startNewExerciseText                      = "start_new_exercise"
+	finishTrainingConfirmationText            = "finish_training_confirmation"
+	finishTrainingText                        = "finish_training"
+	finishText                                = "finish"
+	startOneRMText                            = "start_one_rm"
+	notFoundTrainingsText                     = "not_found_trainings"
+	startCreateExerciseText                   = "start_create_exercise"
+	startGetTrainingsText                     = "start_get_trainings"
+	startUploadTrainingText                   = "start_upload_training"
+	uploadProgressText                        = "upload_progress"
+	uploadFinishText                          = "upload_finish"
+	uploadSessionSummaryText                  = "upload_session_summary"
+	paginationNextText                        = "pagination_next"
+	paginationPrevText                        = "pagination_prev"
+	loadingProgressionText                    = "loading_progression"
+	backToMuscleGroupsText                    = "back_to_muscle_groups"
+	backToExercisesText                       = "back_to_exercises"
+
+	adminOnlyText                     = "admin_only"
+	answerYes                         = "answer_yes"
+	answerNo                          = "answer_no"
+	emptyExerciseNameText             = "empty_exercise_name"
+	unknownMuscleGroupText            = "unknown_muscle_group"
+	exerciseWithNameAlreadyExistsText = "exercise_with_name_already_exists"
+	unknownCommandText                = "unknown_command"
 
 	// error messages
-	errStartTraining     = "❌ Ошибка при запуске тренировки: %v"
-	errNoTraining        = "❌ Нет активной тренировки"
-	errExerciseLoad      = "❌ Ошибка загрузки упражнений"
-	errClearTraining     = "❌ Ошибка сброса тренировки"
-	errUploadTraining    = "❌ Ошибка загрузки тренировки"
-	errGetTrainings      = "❌ Ошибка поиска тренировок"
-	errNoExercises       = "❌ Упражнения не найдены"
-	errAddExercise       = "❌ Ошибка при добавлении упражнения: %v"
-	errProgression       = "❌ Ошибка построения графика. Попробуйте позже"
-	errInvalidFormat     = "❌ Неверный формат. Введите вес и повторения через запятую (например: 50.5,12)"
-	errParseData         = "❌ Ошибка при разборе данных. Проверьте формат и попробуйте снова."
-	errGeneral           = "❌ Ошибка: %v"
-	errInvalidExerciseID = "❌ Ошибка: неверный формат ID упражнения."
-	errCreateExercise    = "❌ Ошибка при добавлении упражнения"
-	errInternal          = "❌ Непредвиденная ошибка"
-)
+	errStartTraining     = "err_start_training"
+	errNoTraining        = "err_no_training"
+	errExerciseLoad      = "err_exercise_load"
+	errClearTraining     = "err_clear_training"
+	errUploadTraining    = "err_upload_training"
+	errUploadLine        = "err_upload_line"
+	errUploadExercise    = "err_upload_exercise"
+	errUploadEmpty       = "err_upload_empty"
+	errUploadFile        = "err_upload_file"
+	errGetTrainings      = "err_get_trainings"
+	errNoExercises       = "err_no_exercises"
+	errSearchTooShort    = "err_search_too_short"
+	errAddExercise       = "err_add_exercise"
+	errProgression       = "err_progression"
+	errInvalidFormat     = "err_invalid_format"
+	errParseData         = "err_parse_data"
+	errInvalidSetFormat  = "err_invalid_set_format"
+	errGeneral           = "err_general"
+	errInvalidExerciseID = "err_invalid_exercise_id"
+	errCreateExercise    = "err_create_exercise"
+	errInternal          = "err_internal"
+	errChangeLanguage    = "err_change_language"
+
+	// one rm
+	oneRMTitleText       = "one_rm_title"
+	oneRMInputText       = "one_rm_input"
+	oneRMFormulasText    = "one_rm_formulas"
+	oneRMFormulaText     = "one_rm_formula"
+	oneRMAverageText     = "one_rm_average"
+	oneRMPercentagesText = "one_rm_percentages"
+	oneRMPercentageText  = "one_rm_percentage"
+	formulaEpleyText     = "formula_epley"
+	formulaBrzyckiText   = "formula_brzycki"
+	formulaLanderText    = "formula_lander"
+	formulaLombardiText  = "formula_lombardi"
+	formulaMayhewText    = "formula_mayhew"
+	formulaOConnerText   = "formula_oconner"
+	formulaWathanText    = "formula_wathan"
+
+	// chart
+	chartDateText   = "chart_date"
+	chartWeightText = "chart_weight"
+
+	// language
+	selectLanguageText  = "select_language"
+	languageAutoText    = "language_auto"
+	languageRUText      = "language_ru"
+	languageENText      = "language_en"
+	languageChangedText = "language_changed"
+
+	// command descriptions
+	startCommandDescription                  = "command_start"
+	startTrainingCommandDescription          = "command_start_training"
+	uploadTrainingCommandDescription         = "command_upload_training"
+	getTrainingsCommandDescription           = "command_get_trainings"
+	getExerciseProgressionCommandDescription = "command_get_exercise_progression"
+	getExerciseHistoryCommandDescription     = "command_get_exercise_history"
+	createExerciseCommandDescription         = "command_create_exercise"
+	clearTrainingCommandDescription          = "command_clear_training"
+	oneRMCommandDescription                  = "command_one_rm"
+	languageCommandDescription               = "command_language"
+	gymCommandDescription                    = "command_gym"
+	currentCommandDescription                = "command_current"
+	autoCloseCommandDescription              = "command_auto_close"
+	coachCommandDescription                  = "command_coach"
+	joinGroupCommandDescription              = "command_join"
+	leaveGroupCommandDescription             = "command_leave"
+	leaderboardCommandDescription            = "command_leaderboard"
+	groupLiftsCommandDescription             = "command_group_lifts"
+	partnerCommandDescription                = "command_partner"
+	helpCommandDescription                   = "command_help"
+
+	// exercises
+	privateExerciseCreatedText = "private_exercise_created"
+	privateExerciseLabelText   = "private_exercise_label"
+	startPromoteExerciseText   = "start_promote_exercise"
+	promoteExerciseSelectText  = "promote_exercise_select"
+	promoteExerciseButtonText  = "promote_exercise_button"
+	exercisePromotedText       = "exercise_promoted"
+	errNoPrivateExercises      = "err_no_private_exercises"
+	errExerciseAlreadyPublic   = "err_exercise_already_public"
+	errPromoteExercise         = "err_promote_exercise"
 
-var (
-	muscleGroupSmilePrefix = "💪🏋️🦵🤷 "
-	muscleGroupsWithSmiles = []string{"💪 Спина", "🏋️ Грудь", "🦵 Ноги", "💪 Руки", "🤷 Плечи"}
-	availableMuscleGroups  = []string{"Спина", "Грудь", "Ноги", "Руки", "Плечи"}
+	// inline search
+	inlineExerciseChosenText = "inline_exercise_chosen"
+
+	// catalog administration
+	startManageExerciseText           = "start_manage_exercise"
+	manageExerciseSelectText          = "manage_exercise_select"
+	manageExerciseText                = "manage_exercise"
+	exerciseEditRenameButtonText      = "exercise_edit_rename_button"
+	exerciseEditMuscleGroupButtonText = "exercise_edit_muscle_group_button"
+	exerciseEditEquipmentButtonText   = "exercise_edit_equipment_button"
+	exerciseEditMergeButtonText       = "exercise_edit_merge_button"
+	exerciseEditArchiveButtonText     = "exercise_edit_archive_button"
+	exerciseEditRenameText            = "exercise_edit_rename"
+	exerciseEditEquipmentText         = "exercise_edit_equipment"
+	exerciseEditMergeText             = "exercise_edit_merge"
+	exerciseEditMergeSelectText       = "exercise_edit_merge_select"
+	exerciseEditEmptyValueText        = "exercise_edit_empty_value"
+	confirmRenameExerciseText         = "confirm_rename_exercise"
+	confirmMuscleGroupExerciseText    = "confirm_muscle_group_exercise"
+	confirmEquipmentExerciseText      = "confirm_equipment_exercise"
+	confirmArchiveExerciseText        = "confirm_archive_exercise"
+	confirmMergeExerciseText          = "confirm_merge_exercise"
+	exerciseEditDoneText              = "exercise_edit_done"
+	exerciseEditCanceledText          = "exercise_edit_canceled"
+	exerciseEditExpiredText           = "exercise_edit_expired"
+	errEditExercise                   = "err_edit_exercise"
+	errExerciseArchived               = "err_exercise_archived"
+	errInvalidExerciseMerge           = "err_invalid_exercise_merge"
+
+	// muscle groups
+	muscleVolumeTitleText = "muscle_volume_title"
+	muscleVolumeLineText  = "muscle_volume_line"
+	errSecondaryMuscles   = "err_secondary_muscles"
+
+	// favorites
+	favoritesText          = "favorites"
+	pinExerciseText        = "pin_exercise"
+	unpinExerciseText      = "unpin_exercise"
+	errNoFavoriteExercises = "err_no_favorite_exercises"
+	errFavoriteExercise    = "err_favorite_exercise"
+
+	// gyms
+	trainingGymText            = "training_gym"
+	noGymText                  = "no_gym"
+	currentGymText             = "current_gym"
+	analyticsByGymText         = "analytics_by_gym"
+	analyticsAllGymsText       = "analytics_all_gyms"
+	newGymButtonText           = "new_gym_button"
+	noGymButtonText            = "no_gym_button"
+	analyticsByGymButtonText   = "analytics_by_gym_button"
+	analyticsAllGymsButtonText = "analytics_all_gyms_button"
+	startCreateGymText         = "start_create_gym"
+	gymCreatedText             = "gym_created"
+	errGym                     = "err_gym"
+	errGymNotFound             = "err_gym_not_found"
+	errUnknownEquipment        = "err_unknown_equipment"
+
+	// technique
+	techniqueButtonText    = "technique_button"
+	closeTechniqueText     = "close_technique"
+	techniqueTitleText     = "technique_title"
+	techniqueCuesText      = "technique_cues"
+	techniqueMistakesText  = "technique_mistakes"
+	noTechniqueText        = "no_technique"
+	techniqueAdminHintText = "technique_admin_hint"
+	techniqueUpdatedText   = "technique_updated"
+	errTechnique           = "err_technique"
+	errTechniqueMedia      = "err_technique_media"
+	errTechniqueMediaLimit = "err_technique_media_limit"
+
+	// training editing
+	selectTrainingText               = "select_training"
+	trainingButtonText               = "training_button"
+	trainingExerciseText             = "training_exercise"
+	trainingSetButtonText            = "training_set_button"
+	changeTrainingDateButtonText     = "change_training_date_button"
+	deleteTrainingButtonText         = "delete_training_button"
+	deleteTrainingExerciseButtonText = "delete_training_exercise_button"
+	backToTrainingText               = "back_to_training"
+	editTrainingSetText              = "edit_training_set"
+	editTrainingDateText             = "edit_training_date"
+	confirmDeleteTrainingText        = "confirm_delete_training"
+	trainingDeletedText              = "training_deleted"
+	trainingUpdatedText              = "training_updated"
+	errEditTraining                  = "err_edit_training"
+	errTrainingNotFound              = "err_training_not_found"
+	trainingEditExpiredText          = "training_edit_expired"
+
+	// active session sets
+	undoSetButtonText    = "undo_set_button"
+	activeSetsButtonText = "active_sets_button"
+	setUndoneText        = "set_undone"
+	setAlreadyUndoneText = "set_already_undone"
+	activeSetsText       = "active_sets"
+	noActiveSetsText     = "no_active_sets"
+	activeSetButtonText  = "active_set_button"
+	errSessionSet        = "err_session_set"
+
+	// current training
+	currentTrainingText         = "current_training"
+	currentExerciseLineText     = "current_exercise_line"
+	noCurrentExercisesText      = "no_current_exercises"
+	currentExerciseSelectedText = "current_exercise_selected"
+	errCurrentTraining          = "err_current_training"
+
+	// training details
+	trainingDetailsPromptText = "training_details_prompt"
+	trainingDetailsButtonText = "training_details_button"
+	editTrainingDetailsText   = "edit_training_details"
+	trainingsSummaryText      = "trainings_summary"
+	trainingsAvgDurationText  = "trainings_avg_duration"
+	trainingsAvgRatingText    = "trainings_avg_rating"
+	trainingsBodyweightText   = "trainings_bodyweight"
+
+	// training pace
+	paceTimeText             = "pace_time"
+	paceRestText             = "pace_rest"
+	paceDensityText          = "pace_density"
+	restReportButtonText     = "rest_report_button"
+	restReportTitleText      = "rest_report_title"
+	restReportLineText       = "rest_report_line"
+	restReportNoDataLineText = "rest_report_no_data_line"
+	noRestDataText           = "no_rest_data"
+
+	// idle sessions
+	idleReminderFinishText  = "idle_reminder_finish"
+	idleReminderDiscardText = "idle_reminder_discard"
+	idleKeepButtonText      = "idle_keep_button"
+	idleKeptText            = "idle_kept"
+	idleFinishedText        = "idle_finished"
+	idleDiscardedText       = "idle_discarded"
+	autoCloseText           = "auto_close"
+	autoCloseFinishText     = "auto_close_finish"
+	autoCloseDiscardText    = "auto_close_discard"
+	errAutoClose            = "err_auto_close"
+
+	// finish training
+	trainingAlreadyFinishedText = "training_already_finished"
+	emptyTrainingFinishedText   = "empty_training_finished"
+
+	// session dashboard
+	dashboardTitleText    = "dashboard_title"
+	dashboardEmptyText    = "dashboard_empty"
+	dashboardExerciseText = "dashboard_exercise"
+	dashboardNoSetsText   = "dashboard_no_sets"
+
+	// coach
+	coachTitleText               = "coach_title"
+	coachNoAthletesText          = "coach_no_athletes"
+	coachYourCoachesText         = "coach_your_coaches"
+	coachRevokeButtonText        = "coach_revoke_button"
+	coachInviteButtonText        = "coach_invite_button"
+	coachInviteText              = "coach_invite"
+	coachInviteInvalidText       = "coach_invite_invalid"
+	coachConsentText             = "coach_consent"
+	coachAcceptButtonText        = "coach_accept_button"
+	coachDeclineButtonText       = "coach_decline_button"
+	coachAcceptedText            = "coach_accepted"
+	coachDeclinedText            = "coach_declined"
+	coachAthleteJoinedText       = "coach_athlete_joined"
+	coachRevokedText             = "coach_revoked"
+	coachRevokedByOtherText      = "coach_revoked_by_other"
+	coachAthleteText             = "coach_athlete"
+	coachTrainingsButtonText     = "coach_trainings_button"
+	coachProgressButtonText      = "coach_progress_button"
+	coachSelectTrainingText      = "coach_select_training"
+	coachSelectExerciseText      = "coach_select_exercise"
+	coachCommentButtonText       = "coach_comment_button"
+	coachCommentPromptText       = "coach_comment_prompt"
+	coachCommentSentText         = "coach_comment_sent"
+	coachCommentNotificationText = "coach_comment_notification"
+	sessionCommentsTitleText     = "session_comments_title"
+	sessionCommentText           = "session_comment"
+	errCoach                     = "err_coach"
+	errCoachLinkExists           = "err_coach_link_exists"
+	errCoachSelfLink             = "err_coach_self_link"
+	errInvalidComment            = "err_invalid_comment"
+
+	// assigned workouts
+	workoutPlanButtonText      = "workout_plan_button"
+	workoutPlanPromptText      = "workout_plan_prompt"
+	workoutPlanDateText        = "workout_plan_date"
+	workoutSelectAthletesText  = "workout_select_athletes"
+	workoutAssignButtonText    = "workout_assign_button"
+	workoutAssignedText        = "workout_assigned"
+	workoutDraftExpiredText    = "workout_draft_expired"
+	workoutDeliveredText       = "workout_delivered"
+	workoutStartButtonText     = "workout_start_button"
+	workoutStartedText         = "workout_started"
+	workoutComparisonText      = "workout_comparison"
+	workoutComparisonSetText   = "workout_comparison_set"
+	dashboardPlannedSetText    = "dashboard_planned_set"
+	errWorkoutPlanFormat       = "err_workout_plan_format"
+	errWorkoutDateInPast       = "err_workout_date_in_past"
+	errNoAthletesSelected      = "err_no_athletes_selected"
+	errWorkoutAthleteNotLinked = "err_workout_athlete_not_linked"
+	errAssignedWorkoutNotFound = "err_assigned_workout_not_found"
+	errWorkoutCompleted        = "err_workout_completed"
+	errWorkoutTrainingStarted  = "err_workout_training_started"
+
+	// group
+	groupHelpText                   = "group_help"
+	groupPrivateOnlyText            = "group_private_only"
+	groupOnlyText                   = "group_only"
+	groupJoinedText                 = "group_joined"
+	groupAlreadyJoinedText          = "group_already_joined"
+	groupLeftText                   = "group_left"
+	groupPRButtonText               = "group_pr_button"
+	groupPREnabledText              = "group_pr_enabled"
+	groupPRDisabledText             = "group_pr_disabled"
+	groupNoMembersText              = "group_no_members"
+	groupAdminOnlyText              = "group_admin_only"
+	groupLiftsUsageText             = "group_lifts_usage"
+	groupLiftsCurrentText           = "group_lifts_current"
+	groupLiftsSavedText             = "group_lifts_saved"
+	groupLeaderboardTitleText       = "group_leaderboard_title"
+	groupLeaderboardEmptyText       = "group_leaderboard_empty"
+	groupLeaderboardVolumeText      = "group_leaderboard_volume"
+	groupLeaderboardVolumeEntryText = "group_leaderboard_volume_entry"
+	groupLeaderboardSessionsText    = "group_leaderboard_sessions"
+	groupLeaderboardEntryText       = "group_leaderboard_entry"
+	groupLeaderboardLiftText        = "group_leaderboard_lift"
+	groupLeaderboardNoLiftText      = "group_leaderboard_no_lift"
+	groupPRTitleText                = "group_pr_title"
+	groupPRText                     = "group_pr"
+	errGroup                        = "err_group"
+	errNotGroupMember               = "err_not_group_member"
+	errTooManyGroupLifts            = "err_too_many_group_lifts"
+	errGroupLiftNotFound            = "err_group_lift_not_found"
+
+	// partner
+	partnerInviteText             = "partner_invite"
+	partnerListText               = "partner_list"
+	partnerInviteInvalidText      = "partner_invite_invalid"
+	partnerAlreadyJoinedText      = "partner_already_joined"
+	partnerNoExercisesText        = "partner_no_exercises"
+	partnerConsentText            = "partner_consent"
+	partnerJoinButtonText         = "partner_join_button"
+	partnerJoinedText             = "partner_joined"
+	partnerJoinedNotificationText = "partner_joined_notification"
+	partnerExerciseText           = "partner_exercise"
+	errPartner                    = "err_partner"
+	errSharedSessionFull          = "err_shared_session_full"
+	errPartnerTrainingStarted     = "err_partner_training_started"
 )
+
+var messages = i18n.Catalog{
+	i18n.LocaleRU: messagesRU,
+	i18n.LocaleEN: messagesEN,
+}
@@ -0,0 +1,321 @@
+package tg
+
+var messagesEN = map[string]string{
+	startText:                             "I'm a bot for keeping a training diary. Use /help to see the available commands.",
+	helpText:                              "📋 Commands:\n/start - Start the bot\n/help - Show help\n/start_training - Start a new training\n/upload_training - Upload trainings\n/get_trainings - View training history\n/get_exercise_progression - View weight progression for an exercise\n/get_exercise_history - View the history of an exercise\n/create_exercise - Create a new exercise\n/current - Exercises of the current training: go back to one, reorder or remove\n/partner - Invite a partner: a shared training with common exercises and your own sets\n/clear_training - Reset the current training\n/auto_close - Finish or delete a training left unfinished\n/one_rm - Calculate one-rep max and percentages\n/gym - Choose a gym: exercises without its equipment are hidden\n/coach - Coach mode: invite a coach or view your athletes' trainings\n/language - Change the interface language\n\n👥 In a group chat: /join - take part in weekly leaderboards, /leaderboard - the leaderboard, /group_lifts - lifts for the e1RM leaderboard\n\nTap the commands and follow the hints to keep your training diary!",
+	clearTrainingDoneText:                 "✅ Current training has been deleted!",
+	donateAuthorText:                      "\nPS: don't forget to tip @%s",
+	startTrainingText:                     "🏋️ *A new training has started!* Choose a muscle group:",
+	muscleGroupDoneText:                   "✅ Selected: *%s*\nNow choose an exercise:",
+	muscleGroupSelectText:                 "🏋️ Choose a muscle group for the next exercise:",
+	startProgressionMuscleGroupSelectText: "Statistics include trainings from the last year.\n🏋️ Choose a muscle group:",
+	startExerciseHistoryMuscleGroupSelectText: "History includes the last 20 trainings with this exercise.\n🏋️ Choose a muscle group:",
+	exerciseText:                             "✅ Great! Exercise selected.\nEnter weight and reps separated by a comma (e.g. 50.5,12)\nSeveral sets at once work too: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4\nIf you made a mistake, edit your message",
+	lastSetsText:                             "📊 Last sets:\n%s",
+	exerciseCreatedText:                      "Exercise \"%s\" added to group \"%s\"",
+	startNewExerciseText:                     "➕ Start a new exercise",
+	finishTrainingConfirmationText:           "Are you sure you want to finish the training?",
+	finishTrainingText:                       "🏁 Finish training",
+	finishText:                               "🏁 Training finished!\n• Exercises: %d\n• Sets: %d\n• Total volume (kg): %.2f",
+	startOneRMText:                           "Enter weight and reps separated by a comma (e.g. 152.5,5).\n\nI'll calculate your one-rep max using the Epley, Brzycki, Lander, Lombardi, Mayhew, O'Conner and Wathan formulas, show the average and common percentages of 1RM.",
+	notFoundTrainingsText:                    "🏋️‍♂️ No trainings yet... But every journey starts with a first step! Go crush it, and let the next request show your results! 🔥",
+	startCreateExerciseText:                  "Enter the exercise name, muscle group and equipment:\n\nFormat:\n<name>\n<muscle group>\n<equipment>\n<secondary muscles with load share> (optional, e.g. Arms 0.5, Shoulders 0.3)",
+	startGetTrainingsText:                    "📅 Enter the search period as YYYY-MM-DD YYYY-MM-DD (e.g. 2024-12-31 2025-01-22).\nWithout dates you'll get trainings for the last 14 days. 🔍",
+	startUploadTrainingText:                  "Send trainings as text or as a .txt file in the format:\n<year-month-day> (optional)\n<exercise number>. <exercise name> - <weight>,<reps> (set note); <weight>,<reps> (set note)\n\nEvery line with a date starts a new training, so you can upload several at once.\nSets can be written in short form: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4\n\nExample:\n2025-01-31\n1. Бабочка - 82,7 (hard); 72,8 (hard); 54.5,12 (hard)\n2. Жим гантелей лежа - 25,10; 25,10\n\n2025-02-02\n1. Присед - 100,5; 100,5",
+	uploadProgressText:                       "⏳ Trainings uploaded: %d of %d",
+	uploadFinishText:                         "🏁 Trainings uploaded: %d\n\n%s",
+	uploadSessionSummaryText:                 "• %s — exercises: %d, sets: %d, total volume (kg): %.2f\n",
+	paginationNextText:                       "Next ➡️",
+	paginationPrevText:                       "⬅️ Back",
+	loadingProgressionText:                   "⏳ Building the chart, please wait",
+	backToMuscleGroupsText:                   "⬅️ Choose another group",
+	backToExercisesText:                      "⬅️ Choose another exercise",
+	adminOnlyText:                            "This feature is only available to the chosen ones :)",
+	answerYes:                                "✅ Yes",
+	answerNo:                                 "❌ No",
+	emptyExerciseNameText:                    "Empty exercise name",
+	unknownMuscleGroupText:                   "Unknown muscle group!\nAvailable: %v",
+	exerciseWithNameAlreadyExistsText:        "Exercise \"%s\" already exists!",
+	unknownCommandText:                       "Unknown command. Use /help for help",
+	errStartTraining:                         "❌ Failed to start the training: %v",
+	errNoTraining:                            "❌ No active training",
+	errExerciseLoad:                          "❌ Failed to load exercises",
+	errClearTraining:                         "❌ Failed to reset the training",
+	errUploadTraining:                        "❌ Failed to upload the training, please try again later",
+	errUploadLine:                            "❌ Couldn't parse line %d: %s\nExpected format: \"1. Exercise - 50,10; 50,10\"",
+	errUploadExercise:                        "❌ Line %d: exercise \"%s\" is not in the catalog",
+	errUploadEmpty:                           "❌ The text contains no trainings",
+	errUploadFile:                            "❌ Couldn't read the file. Send a plain .txt file up to 1 MB",
+	errGetTrainings:                          "❌ Failed to find trainings",
+	errNoExercises:                           "❌ No exercises found",
+	errSearchTooShort:                        "🔎 Type at least two letters of the name",
+	errAddExercise:                           "❌ Failed to add the exercise: %v",
+	errProgression:                           "❌ Failed to build the chart. Try again later",
+	errInvalidFormat:                         "❌ Invalid format. Enter weight and reps separated by a comma (e.g. 50.5,12)",
+	errParseData:                             "❌ Failed to parse the data. Check the format and try again.",
+	errInvalidSetFormat:                      "❌ Invalid format. Enter weight and reps separated by a comma (e.g. 50.5,12) or in short form: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4",
+	errGeneral:                               "❌ Error: %v",
+	errInvalidExerciseID:                     "❌ Error: invalid exercise ID format.",
+	errCreateExercise:                        "❌ Failed to add the exercise",
+	errInternal:                              "❌ Unexpected error",
+	oneRMTitleText:                           "📈 One-rep max calculation\n\n",
+	oneRMInputText:                           "Input: %s kg x %d\n\n",
+	oneRMFormulasText:                        "Formulas:\n\n",
+	oneRMFormulaText:                         "• *%s*: %s kg\n",
+	oneRMAverageText:                         "\nAverage 1RM: %s kg\n\n",
+	oneRMPercentagesText:                     "Percentages of 1RM:\n",
+	oneRMPercentageText:                      "• %d%%: %s kg\n",
+	formulaEpleyText:                         "Epley",
+	formulaBrzyckiText:                       "Brzycki",
+	formulaLanderText:                        "Lander",
+	formulaLombardiText:                      "Lombardi",
+	formulaMayhewText:                        "Mayhew",
+	formulaOConnerText:                       "O'Conner",
+	formulaWathanText:                        "Wathan",
+	chartDateText:                            "Date",
+	chartWeightText:                          "Weight (kg)",
+	selectLanguageText:                       "🌐 Choose the interface language:",
+	languageAutoText:                         "🔄 Same as Telegram",
+	languageRUText:                           "🇷🇺 Русский",
+	languageENText:                           "🇬🇧 English",
+	languageChangedText:                      "✅ Interface language changed",
+	errChangeLanguage:                        "❌ Failed to change the language",
+	startCommandDescription:                  "Start the bot",
+	startTrainingCommandDescription:          "Start a training",
+	uploadTrainingCommandDescription:         "Upload trainings",
+	getTrainingsCommandDescription:           "View training history",
+	getExerciseProgressionCommandDescription: "View weight progression for an exercise",
+	getExerciseHistoryCommandDescription:     "View the history of an exercise",
+	createExerciseCommandDescription:         "Create a new exercise",
+	clearTrainingCommandDescription:          "Reset the current training",
+	oneRMCommandDescription:                  "Calculate one-rep max",
+	autoCloseCommandDescription:              "What to do with an abandoned training",
+	currentCommandDescription:                "Exercises of the current training",
+	gymCommandDescription:                    "Choose a gym and its equipment",
+	coachCommandDescription:                  "Coach and athletes",
+	joinGroupCommandDescription:              "👋 Join the group leaderboards",
+	leaveGroupCommandDescription:             "🚪 Leave the group leaderboards",
+	leaderboardCommandDescription:            "🏆 Weekly leaderboard",
+	groupLiftsCommandDescription:             "🏋️ Lifts for the e1RM leaderboard",
+	partnerCommandDescription:                "Invite a partner to the current training",
+	languageCommandDescription:               "Change the language",
+	helpCommandDescription:                   "Help and commands",
+	privateExerciseCreatedText:               "🔒 Private exercise \"%s\" added to group \"%s\". Only you can see it",
+	privateExerciseLabelText:                 "🔒 %s",
+	startPromoteExerciseText:                 "Enter the name of the private exercise to make public:",
+	promoteExerciseSelectText:                "Private exercises found: %d. Choose which one to make public:",
+	promoteExerciseButtonText:                "%s · %s · owner %s",
+	exercisePromotedText:                     "✅ Exercise \"%s\" is now available to everyone",
+	errNoPrivateExercises:                    "❌ No private exercises with this name found",
+	errExerciseAlreadyPublic:                 "❌ The exercise is already public",
+	errPromoteExercise:                       "❌ Failed to make the exercise public",
+	inlineExerciseChosenText:                 "🏋️ %s",
+	startManageExerciseText:                  "Enter the name of the exercise to change:",
+	manageExerciseSelectText:                 "Choose an exercise:",
+	manageExerciseText:                       "Exercise \"%s\"\nMuscle group: %s\nEquipment: %s\n\nWhat do you want to change?",
+	exerciseEditRenameButtonText:             "✏️ Rename",
+	exerciseEditMuscleGroupButtonText:        "💪 Muscle group",
+	exerciseEditEquipmentButtonText:          "🛠 Equipment",
+	exerciseEditMergeButtonText:              "🔀 Merge into another",
+	exerciseEditArchiveButtonText:            "🗄 Archive",
+	exerciseEditRenameText:                   "Enter the new exercise name:",
+	exerciseEditEquipmentText:                "Enter the equipment:",
+	exerciseEditMergeText:                    "Enter the name of the exercise that should receive the history:",
+	exerciseEditMergeSelectText:              "Choose the exercise that should receive the history:",
+	exerciseEditEmptyValueText:               "Empty value",
+	confirmRenameExerciseText:                "Rename \"%s\" to \"%s\"? The name will also change in all logged sets.",
+	confirmMuscleGroupExerciseText:           "Move \"%s\" from %s to %s? The muscle group will also change in all logged sets.",
+	confirmEquipmentExerciseText:             "Change the equipment of \"%s\": \"%s\" → \"%s\"?",
+	confirmArchiveExerciseText:               "Archive \"%s\"? The exercise will disappear from lists and search, its history is kept.",
+	confirmMergeExerciseText:                 "Merge \"%s\" into \"%s\"? All history of the first one moves to the second, and the first one is archived.",
+	exerciseEditDoneText:                     "✅ Changes saved: \"%s\"",
+	exerciseEditCanceledText:                 "Change canceled",
+	exerciseEditExpiredText:                  "The change was already applied or canceled, start over: /manage_exercise",
+	errEditExercise:                          "❌ Failed to change the exercise",
+	errExerciseArchived:                      "❌ The exercise is already archived",
+	errInvalidExerciseMerge:                  "❌ These exercises cannot be merged",
+	muscleVolumeTitleText:                    "\n\n💪 Volume by muscle group (kg):\n",
+	muscleVolumeLineText:                     "• %s: %.2f\n",
+	errSecondaryMuscles:                      "❌ Could not parse secondary muscles. Format: Arms 0.5, Shoulders 30%%\nAvailable: %v",
+	favoritesText:                            "⭐ Favourites",
+	pinExerciseText:                          "⭐ Pin to favourites",
+	unpinExerciseText:                        "✖️ Unpin from favourites",
+	errNoFavoriteExercises:                   "⭐ No favourites yet. Pin an exercise with the \"⭐ Pin to favourites\" button after choosing it",
+	errFavoriteExercise:                      "❌ Failed to update favourites",
+	trainingGymText:                          "\n🏟 Gym: *%s*",
+	noGymText:                                "🏟 No gym selected: all exercises are shown during a training.",
+	currentGymText:                           "🏟 Current gym: %s\nEquipment: %s\n\nExercises that need other equipment are hidden during a training.",
+	analyticsByGymText:                       "\n📊 Statistics: current gym only.",
+	analyticsAllGymsText:                     "\n📊 Statistics: all gyms.",
+	newGymButtonText:                         "➕ New gym",
+	noGymButtonText:                          "🚫 No gym",
+	analyticsByGymButtonText:                 "📊 Statistics for the current gym only",
+	analyticsAllGymsButtonText:               "📊 Statistics for all gyms",
+	startCreateGymText:                       "Enter the gym name and its equipment:\n\nFormat:\n<name>\n<equipment, comma separated>\n\nCatalog equipment: %s. Bodyweight exercises are available in any gym.",
+	gymCreatedText:                           "✅ Gym \"%s\" has been created and selected.",
+	errGym:                                   "❌ Failed to update gyms",
+	errGymNotFound:                           "❌ Gym not found",
+	errUnknownEquipment:                      "❌ Unknown equipment. Available: %s",
+	techniqueButtonText:                      "ℹ️ Technique",
+	closeTechniqueText:                       "✖️ Hide",
+	techniqueTitleText:                       "ℹ️ Technique: %s",
+	techniqueCuesText:                        "\n\n✅ Cues:",
+	techniqueMistakesText:                    "\n\n⚠️ Common mistakes:",
+	noTechniqueText:                          "ℹ️ There is no technique description for \"%s\" yet.",
+	techniqueAdminHintText:                   "\n\n🛠 Reply to this message with text to replace the card: description, a blank line, cues one per line, a blank line, common mistakes. A photo, GIF or video sent as a reply to this message or the exercise screen is attached to the card.",
+	techniqueUpdatedText:                     "✅ The technique card has been updated",
+	errTechnique:                             "❌ Failed to update the technique card",
+	errTechniqueMedia:                        "❌ Only a photo, GIF or video can be attached",
+	errTechniqueMediaLimit:                   "❌ A card can hold at most %d attachments",
+	selectTrainingText:                       "✏️ Choose a training to view or edit it:",
+	trainingButtonText:                       "📅 %s · exercises: %d",
+	trainingExerciseText:                     "📅 %s\n%d. %s\n\nChoose a set to edit it or 🗑 to delete it:",
+	trainingSetButtonText:                    "✏️ Set %d: %s×%d",
+	changeTrainingDateButtonText:             "📅 Change the date",
+	deleteTrainingButtonText:                 "🗑 Delete the training",
+	deleteTrainingExerciseButtonText:         "🗑 Delete the exercise",
+	backToTrainingText:                       "⬅️ Back to the training",
+	editTrainingSetText:                      "Send new data for set %d as weight,reps (note), e.g. 80,10 (hard)",
+	editTrainingDateText:                     "Send the new training date as YYYY-MM-DD",
+	confirmDeleteTrainingText:                "Delete the training with all its sets?",
+	trainingDeletedText:                      "🗑 The training has been deleted",
+	trainingUpdatedText:                      "✅ The training has been updated",
+	errEditTraining:                          "❌ Failed to edit the training",
+	errTrainingNotFound:                      "❌ Training not found",
+	trainingEditExpiredText:                  "The change has already been applied or canceled, open the training again: /get_trainings",
+	undoSetButtonText:                        "↩️ Undo set",
+	activeSetsButtonText:                     "📋 Exercise sets",
+	setUndoneText:                            "↩️ Sets undone: %d. Enter the set again or choose an action:",
+	setAlreadyUndoneText:                     "These sets have already been removed.",
+	activeSetsText:                           "📋 %s\nTap a set to delete it:",
+	noActiveSetsText:                         "The current exercise has no sets yet.",
+	activeSetButtonText:                      "🗑 Set %d: %s×%d",
+	errSessionSet:                            "❌ Failed to delete the set.",
+	currentTrainingText:                      "🏋️ Current training. ▶️ marks the exercise new sets go to. Tap an exercise to continue it:\n",
+	currentExerciseLineText:                  "\n%s%d. %s - sets: %d",
+	noCurrentExercisesText:                   "The current training has no exercises yet.",
+	currentExerciseSelectedText:              "▶️ Continuing: %s. Enter the set or choose an action:",
+	errCurrentTraining:                       "❌ Failed to update the current training.",
+	trainingDetailsPromptText:                "📝 Optionally rate the training from 1 to 5 and add your bodyweight and a note.",
+	trainingDetailsButtonText:                "📝 Bodyweight and note",
+	editTrainingDetailsText:                  "Send your bodyweight and a note: the first line is bodyweight in kg (optional), the rest is the note. Anything you leave out stays unchanged. For example:\n81.5\nBack felt strong",
+	trainingsSummaryText:                     "📊 Trainings: %d",
+	trainingsAvgDurationText:                 "\n🕒 Average duration: %s",
+	trainingsAvgRatingText:                   "\n⭐ Average rating: %.1f",
+	trainingsBodyweightText:                  "\n⚖️ Bodyweight: %s → %s kg",
+	paceTimeText:                             "\n\n⏱ Training time: %s",
+	paceRestText:                             "\n💤 Average rest: %s",
+	paceDensityText:                          "\n📈 Density: %s kg/min",
+	restReportButtonText:                     "💤 Rest by exercise",
+	restReportTitleText:                      "💤 Rest between sets:",
+	restReportLineText:                       "\n%d. %s - average %s (%s)",
+	restReportNoDataLineText:                 "\n%d. %s - no data",
+	noRestDataText:                           "This training has no set times: they are recorded when sets are logged during the training.",
+	idleReminderFinishText:                   "⏰ Your training has been idle for %s. If you don't continue, it will be finished automatically with the logged sets in %s.",
+	idleReminderDiscardText:                  "⏰ Your training has been idle for %s. If you don't continue, it will be deleted in %s.",
+	idleKeepButtonText:                       "▶️ Continue",
+	idleKeptText:                             "▶️ Training continues! Enter a set or choose an exercise.",
+	idleFinishedText:                         "🏁 Training finished automatically: %d exercises, %d sets, %.0f kg volume.",
+	idleDiscardedText:                        "🗑 The abandoned training has been deleted.",
+	autoCloseText:                            "If a training is left unfinished, the bot reminds you first and then:\n\n%s",
+	autoCloseFinishText:                      "🏁 Finish it with the logged sets",
+	autoCloseDiscardText:                     "🗑 Delete it",
+	errAutoClose:                             "❌ Failed to save the setting.",
+	trainingAlreadyFinishedText:              "The workout has already been finished.",
+	emptyTrainingFinishedText:                "The workout had no completed sets, so it was not saved.",
+	dashboardTitleText:                       "🏋️ Current workout\n⏱ %s · 📦 %s kg\n",
+	dashboardEmptyText:                       "\nAdd an exercise to start",
+	dashboardExerciseText:                    "\n%s%d. %s: %s",
+	dashboardNoSetsText:                      "—",
+	coachTitleText:                           "🧑‍🏫 Coach mode\n",
+	coachNoAthletesText:                      "\nNo athletes yet. Send an athlete an invite link — once they agree, you will see their trainings.\n",
+	coachYourCoachesText:                     "\nYour coaches with access to your trainings:",
+	coachRevokeButtonText:                    "🚫 Revoke access: %s",
+	coachInviteButtonText:                    "🔗 Invite an athlete",
+	coachInviteText:                          "Send this link to your athlete. It works once: access opens only after they agree.\n%s",
+	coachInviteInvalidText:                   "⚠️ The invite is invalid or has already been used",
+	coachConsentText:                         "%s invites you to be their athlete. The coach will see your trainings and exercise progress and can leave comments. You can revoke access at any time via /coach.",
+	coachAcceptButtonText:                    "✅ Agree",
+	coachDeclineButtonText:                   "❌ Decline",
+	coachAcceptedText:                        "✅ %s can now see your trainings",
+	coachDeclinedText:                        "Invite declined",
+	coachAthleteJoinedText:                   "🎉 %s accepted your invite. Their trainings are available in /coach",
+	coachRevokedText:                         "🚫 Access with %s revoked",
+	coachRevokedByOtherText:                  "🚫 %s revoked access to trainings",
+	coachAthleteText:                         "🏃 %s",
+	coachTrainingsButtonText:                 "📅 Trainings for 30 days",
+	coachProgressButtonText:                  "📈 Exercise progress",
+	coachSelectTrainingText:                  "Choose a training to comment on:",
+	coachSelectExerciseText:                  "Choose an exercise:",
+	coachCommentButtonText:                   "💬 Comment",
+	coachCommentPromptText:                   "Write a comment for the training on %s:",
+	coachCommentSentText:                     "✅ Comment sent to the athlete",
+	coachCommentNotificationText:             "💬 %s commented on your training on %s:\n%s",
+	sessionCommentsTitleText:                 "\n\n💬 Coach comments:",
+	sessionCommentText:                       "\n%s: %s",
+	errCoach:                                 "❌ Failed to perform the coach action",
+	errCoachLinkExists:                       "⚠️ This coach already sees your trainings",
+	errCoachSelfLink:                         "⚠️ You cannot coach yourself",
+	errInvalidComment:                        "⚠️ A comment must be non-empty and at most 1000 characters",
+	workoutPlanButtonText:                    "📝 Assign a workout",
+	workoutPlanPromptText:                    "Send the plan in the /upload_training format. The date above the exercises is the day the athlete gets the workout, without a date — today:\n2024-02-15\n1. Bench press - 60,10; 60,10; 60,8\n2. Squat - 80,8; 80,8",
+	workoutPlanDateText:                      "📅 %s\n",
+	workoutSelectAthletesText:                "Choose the athletes and tap \"Assign\":",
+	workoutAssignButtonText:                  "📨 Assign",
+	workoutAssignedText:                      "✅ Workouts assigned: %d. Athletes will get them on the planned day",
+	workoutDraftExpiredText:                  "⚠️ The plan was not found, send it again",
+	workoutDeliveredText:                     "🗓 %s assigned you a workout for %s:\n\n%s",
+	workoutStartButtonText:                   "▶️ Start the assigned workout",
+	workoutStartedText:                       "🏋️ The assigned workout has started. Log your sets — they fill the plan of the active exercise in order, switch exercises in /current",
+	workoutComparisonText:                    "📊 %s finished the workout for %s\nset: plan → actual\n",
+	workoutComparisonSetText:                 "  %d: %s → %s %s\n",
+	dashboardPlannedSetText:                  "plan %s",
+	errWorkoutPlanFormat:                     "⚠️ Could not parse the plan, check the /upload_training format",
+	errWorkoutDateInPast:                     "⚠️ A workout cannot be assigned to a past date",
+	errNoAthletesSelected:                    "⚠️ Choose at least one athlete",
+	errWorkoutAthleteNotLinked:               "⚠️ One of the athletes has revoked your access",
+	errAssignedWorkoutNotFound:               "⚠️ The assigned workout was not found",
+	errWorkoutCompleted:                      "✅ This assigned workout is already done",
+	errWorkoutTrainingStarted:                "⚠️ Finish the current training first",
+	groupHelpText:                            "👋 I keep the training diaries of this group's members.\n\n/join - join the group leaderboards\n/leave - leave the leaderboards\n/leaderboard - weekly leaderboard: volume, sessions and e1RM relative to bodyweight\n/group_lifts - lifts for the e1RM leaderboard (admins can change them)\n\nTrainings are logged in a private chat with the bot.",
+	groupPrivateOnlyText:                     "This command only works in a private chat with the bot: %s",
+	groupOnlyText:                            "This command only works in a group chat. Add the bot to a group.",
+	groupJoinedText:                          "✅ %s joined the group leaderboards. The button below turns announcements of your PRs on or off.",
+	groupAlreadyJoinedText:                   "%s is already on the group leaderboards.",
+	groupLeftText:                            "🚪 %s left the group leaderboards.",
+	groupPRButtonText:                        "🏆 Announce my PRs",
+	groupPREnabledText:                       "Your PRs will be announced in the group",
+	groupPRDisabledText:                      "Your PRs will no longer be announced in the group",
+	groupNoMembersText:                       "Nobody is on the leaderboards yet. Send /join to take part.",
+	groupAdminOnlyText:                       "Only group admins can change the leaderboard lifts.",
+	groupLiftsUsageText:                      "To set the lifts (up to %d), send them separated by semicolons:\n/group_lifts Bench press; Barbell squat",
+	groupLiftsCurrentText:                    "🏋️ Leaderboard lifts: %s\n\n",
+	groupLiftsSavedText:                      "✅ Leaderboard lifts: %s",
+	groupLeaderboardTitleText:                "🏆 Leaderboard for the week since %s\n",
+	groupLeaderboardEmptyText:                "\nNobody has trained this week yet.",
+	groupLeaderboardVolumeText:               "\n📦 Volume:\n",
+	groupLeaderboardVolumeEntryText:          "%d. %s — %s kg\n",
+	groupLeaderboardSessionsText:             "\n🗓 Sessions:\n",
+	groupLeaderboardEntryText:                "%d. %s — %s\n",
+	groupLeaderboardLiftText:                 "\n🏋️ %s (e1RM / bodyweight):\n",
+	groupLeaderboardNoLiftText:               "no results with a recorded bodyweight\n",
+	groupPRTitleText:                         "🎉 New PRs for %s!\n",
+	groupPRText:                              "• %s: %s, e1RM %s kg (was %s kg)\n",
+	errGroup:                                 "❌ Failed to run the group command, try again later.",
+	errNotGroupMember:                        "You are not on the group leaderboards. Send /join.",
+	errTooManyGroupLifts:                     "You can choose up to %d lifts.",
+	errGroupLiftNotFound:                     "Exercise not found in the shared catalog, check the name.",
+	partnerInviteText:                        "👥 Send this link to your partner to train together: exercises are picked once for everyone, and each of you logs your own sets in your own chat.\n\n%s",
+	partnerListText:                          "\n\nAlready with you: %s",
+	partnerInviteInvalidText:                 "The shared training has already ended or the link is invalid.",
+	partnerAlreadyJoinedText:                 "You are already in this training.",
+	partnerNoExercisesText:                   "none picked yet",
+	partnerConsentText:                       "👥 %s invites you to a shared training.\nExercises: %s\n\nYour sets are logged separately and saved to your own diary.",
+	partnerJoinButtonText:                    "✅ Join",
+	partnerJoinedText:                        "✅ You are training with %s. Send your sets; new exercises appear for everyone.",
+	partnerJoinedNotificationText:            "👥 %s joined your training.",
+	partnerExerciseText:                      "👥 %s picked %s, send your sets.",
+	errPartner:                               "❌ Failed to update the shared training, try again later.",
+	errSharedSessionFull:                     "The shared training already has %d participants.",
+	errPartnerTrainingStarted:                "Finish or reset your current training first.",
+}
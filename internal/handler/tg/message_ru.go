@@ -0,0 +1,321 @@
+package tg
+
+var messagesRU = map[string]string{
+	startText:                             "Я бот для ведения дневника тренировок. Используй команду /help, чтобы узнать доступные команды.",
+	helpText:                              "📋 Список команд:\n/start - Запустить бота\n/help - Показать справку\n/start_training - Начать новую тренировку\n/upload_training - Загрузить новую тренировку\n/get_trainings - Посмотреть историю тренировок\n/get_exercise_progression - Посмотреть прогрессию весов по упражнению\n/get_exercise_history - Посмотреть историю конкретного упражнения\n/create_exercise - Создать новое упражнение\n/current - Упражнения текущей тренировки: вернуться к упражнению, изменить порядок или удалить\n/partner - Позвать партнера: совместная тренировка с общими упражнениями и своими подходами\n/clear_training - Сбросить текущую тренировку\n/auto_close - Завершать или удалять тренировку, забытую без завершения\n/one_rm - Рассчитать одноповторный максимум и процентовки\n/gym - Выбрать зал: упражнения без нужного оборудования скрываются\n/coach - Режим тренера: пригласить тренера или посмотреть тренировки своих спортсменов\n/language - Сменить язык интерфейса\n\n👥 В групповом чате: /join - участвовать в недельных рейтингах, /leaderboard - рейтинг, /group_lifts - упражнения для рейтинга e1RM\n\nНажимай команды и следуй подсказкам, чтобы вести тренировочный дневник!",
+	clearTrainingDoneText:                 "✅ Текущая тренировка успешно удалена!",
+	donateAuthorText:                      "\nPS: не забудь подкинуть деньжат @%s",
+	startTrainingText:                     "🏋️ *Новая тренировка началась!* Выбери мышечную группу:",
+	muscleGroupDoneText:                   "✅ Выбрано: *%s*\nТеперь выбери упражнение:",
+	muscleGroupSelectText:                 "🏋️ Выбери мышечную группу для нового упражнения:",
+	startProgressionMuscleGroupSelectText: "В статистике учитываются тренировки за последний год.\n🏋️ Выбери мышечную группу:",
+	startExerciseHistoryMuscleGroupSelectText: "В истории учитываются последние 20 тренировок, когда выполнялось упражнение.\n🏋️ Выбери мышечную группу:",
+	exerciseText:                             "✅ Отлично! Вы выбрали упражнение.\nВведите вес и количество повторений через запятую (например: 50.5,12)\nМожно сразу несколько подходов: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4\nЕсли ошиблись в введенных данных - отредактируйте сообщение",
+	lastSetsText:                             "📊 Последние подходы:\n%s",
+	exerciseCreatedText:                      "Упражнение \"%s\" добавлено в группу \"%s\"",
+	startNewExerciseText:                     "➕ Начать новое упражнение",
+	finishTrainingConfirmationText:           "Вы уверены, что хотите завершить тренировку?",
+	finishTrainingText:                       "🏁 Завершить тренировку",
+	finishText:                               "🏁 Тренировка завершена!\n• Упражнений: %d\n• Подходов: %d\n• Общий вес (кг): %.2f",
+	startOneRMText:                           "Введите вес и количество повторений через запятую (например: 152.5,5).\n\nЯ посчитаю одноповторный максимум по формулам Эпли, Бжицки, Лэндера, Ломбарди, Мэйхью, О'Коннора, Ватана, покажу среднее значение и популярные процентовки от 1ПМ.",
+	notFoundTrainingsText:                    "🏋️‍♂️ Тренировок пока нет... Но каждый путь начинается с первого шага! Давай, жги, и пусть следующий запрос покажет твои крутые результаты! 🔥",
+	startCreateExerciseText:                  "Введите название упражнения, группу мышц и оборудование:\n\nФормат:\n<название>\n<группа мышц>\n<оборудование>\n<вспомогательные мышцы с долей нагрузки> (необязательно, например: Руки 0.5, Плечи 0.3)",
+	startGetTrainingsText:                    "📅 Введите период поиска тренировок в формате: ГГГГ-ММ-ДД ГГГГ-ММ-ДД (например, 2024-12-31 2025-01-22).\nЕсли не укажете даты — покажем тренировки за последние 14 дней. 🔍",
+	startUploadTrainingText:                  "Введите тренировки текстом или пришлите .txt файл в формате:\n<год-месяц-число> (опционально)\n<номер упражнения>. <название упражнения> - <вес>,<кол-во повторений> (заметка по подходу); <вес>,<кол-во повторений> (заметка по подходу)\n\nКаждая строка с датой начинает новую тренировку, так что можно загрузить сразу несколько.\nПодходы можно записывать сокращённо: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4\n\nПример:\n2025-01-31\n1. Бабочка - 82,7 (тяжело); 72,8 (тяжело); 54.5,12 (тяжело)\n2. Жим гантелей лежа - 25,10 (нормально); 25,10 (нормально)\n\n2025-02-02\n1. Присед - 100,5; 100,5",
+	uploadProgressText:                       "⏳ Загружено тренировок: %d из %d",
+	uploadFinishText:                         "🏁 Загружено тренировок: %d\n\n%s",
+	uploadSessionSummaryText:                 "• %s — упражнений: %d, подходов: %d, общий вес (кг): %.2f\n",
+	paginationNextText:                       "Вперед ➡️",
+	paginationPrevText:                       "⬅️ Назад",
+	loadingProgressionText:                   "⏳ График уже строится, ожидайте",
+	backToMuscleGroupsText:                   "⬅️ Выбрать другую",
+	backToExercisesText:                      "⬅️ Выбрать другое",
+	adminOnlyText:                            "Функция доступна только избранным :)",
+	answerYes:                                "✅ Да",
+	answerNo:                                 "❌ Нет",
+	emptyExerciseNameText:                    "Пустое имя упражнения",
+	unknownMuscleGroupText:                   "Неизвестная мышечная группа!\nДоступные: %v",
+	exerciseWithNameAlreadyExistsText:        "Упражнение \"%s\" уже существует!",
+	unknownCommandText:                       "Неизвестная команда. Используй /help для справки",
+	errStartTraining:                         "❌ Ошибка при запуске тренировки: %v",
+	errNoTraining:                            "❌ Нет активной тренировки",
+	errExerciseLoad:                          "❌ Ошибка загрузки упражнений",
+	errClearTraining:                         "❌ Ошибка сброса тренировки",
+	errUploadTraining:                        "❌ Не удалось загрузить тренировку, попробуйте позже",
+	errUploadLine:                            "❌ Строка %d не разобрана: %s\nОжидается формат «1. Упражнение - 50,10; 50,10»",
+	errUploadExercise:                        "❌ Строка %d: упражнение «%s» не найдено в каталоге",
+	errUploadEmpty:                           "❌ В тексте нет ни одной тренировки",
+	errUploadFile:                            "❌ Не удалось прочитать файл. Пришлите текстовый .txt файл размером до 1 МБ",
+	errGetTrainings:                          "❌ Ошибка поиска тренировок",
+	errNoExercises:                           "❌ Упражнения не найдены",
+	errSearchTooShort:                        "🔎 Введите хотя бы две буквы названия",
+	errAddExercise:                           "❌ Ошибка при добавлении упражнения: %v",
+	errProgression:                           "❌ Ошибка построения графика. Попробуйте позже",
+	errInvalidFormat:                         "❌ Неверный формат. Введите вес и повторения через запятую (например: 50.5,12)",
+	errParseData:                             "❌ Ошибка при разборе данных. Проверьте формат и попробуйте снова.",
+	errInvalidSetFormat:                      "❌ Неверный формат. Введите вес и повторения через запятую (например: 50.5,12) или сокращённо: 50x10x3, 3×8 @ 60, 60/70/80 x 8/6/4",
+	errGeneral:                               "❌ Ошибка: %v",
+	errInvalidExerciseID:                     "❌ Ошибка: неверный формат ID упражнения.",
+	errCreateExercise:                        "❌ Ошибка при добавлении упражнения",
+	errInternal:                              "❌ Непредвиденная ошибка",
+	oneRMTitleText:                           "📈 Расчёт одноповторного максимума\n\n",
+	oneRMInputText:                           "Исходные данные: %s кг x %d\n\n",
+	oneRMFormulasText:                        "Формулы:\n\n",
+	oneRMFormulaText:                         "• *%s*: %s кг\n",
+	oneRMAverageText:                         "\nСредний 1ПМ: %s кг\n\n",
+	oneRMPercentagesText:                     "Проценты от 1ПМ:\n",
+	oneRMPercentageText:                      "• %d%%: %s кг\n",
+	formulaEpleyText:                         "Эпли",
+	formulaBrzyckiText:                       "Бжицки",
+	formulaLanderText:                        "Лэндер",
+	formulaLombardiText:                      "Ломбарди",
+	formulaMayhewText:                        "Мэйхью",
+	formulaOConnerText:                       "О'Коннор",
+	formulaWathanText:                        "Ватан",
+	chartDateText:                            "Дата",
+	chartWeightText:                          "Вес (кг)",
+	selectLanguageText:                       "🌐 Выберите язык интерфейса:",
+	languageAutoText:                         "🔄 Как в Telegram",
+	languageRUText:                           "🇷🇺 Русский",
+	languageENText:                           "🇬🇧 English",
+	languageChangedText:                      "✅ Язык интерфейса изменён",
+	errChangeLanguage:                        "❌ Не удалось изменить язык",
+	startCommandDescription:                  "Запустить бота",
+	startTrainingCommandDescription:          "Начать тренировку",
+	uploadTrainingCommandDescription:         "Загрузить тренировку",
+	getTrainingsCommandDescription:           "Посмотреть историю тренировок",
+	getExerciseProgressionCommandDescription: "Посмотреть прогрессию весов по упражнению",
+	getExerciseHistoryCommandDescription:     "Посмотреть историю конкретного упражнения",
+	createExerciseCommandDescription:         "Создать новое упражнение",
+	clearTrainingCommandDescription:          "Сбросить текущую тренировку",
+	oneRMCommandDescription:                  "Рассчитать одноповторный максимум",
+	autoCloseCommandDescription:              "Что делать с брошенной тренировкой",
+	currentCommandDescription:                "Упражнения текущей тренировки",
+	gymCommandDescription:                    "Выбрать зал и оборудование",
+	coachCommandDescription:                  "Тренер и спортсмены",
+	joinGroupCommandDescription:              "👋 Участвовать в рейтингах группы",
+	leaveGroupCommandDescription:             "🚪 Выйти из рейтингов группы",
+	leaderboardCommandDescription:            "🏆 Рейтинг недели",
+	groupLiftsCommandDescription:             "🏋️ Упражнения для рейтинга e1RM",
+	partnerCommandDescription:                "Позвать партнера на текущую тренировку",
+	languageCommandDescription:               "Сменить язык",
+	helpCommandDescription:                   "Помощь и команды",
+	privateExerciseCreatedText:               "🔒 Личное упражнение \"%s\" добавлено в группу \"%s\". Оно видно только вам",
+	privateExerciseLabelText:                 "🔒 %s",
+	startPromoteExerciseText:                 "Введите название личного упражнения, которое нужно сделать общим:",
+	promoteExerciseSelectText:                "Найдено личных упражнений: %d. Выберите, какое сделать общим:",
+	promoteExerciseButtonText:                "%s · %s · автор %s",
+	exercisePromotedText:                     "✅ Упражнение \"%s\" теперь доступно всем",
+	errNoPrivateExercises:                    "❌ Личные упражнения с таким названием не найдены",
+	errExerciseAlreadyPublic:                 "❌ Упражнение уже общее",
+	errPromoteExercise:                       "❌ Ошибка при публикации упражнения",
+	inlineExerciseChosenText:                 "🏋️ %s",
+	startManageExerciseText:                  "Введите название упражнения, которое нужно изменить:",
+	manageExerciseSelectText:                 "Выберите упражнение:",
+	manageExerciseText:                       "Упражнение «%s»\nГруппа: %s\nОборудование: %s\n\nЧто изменить?",
+	exerciseEditRenameButtonText:             "✏️ Переименовать",
+	exerciseEditMuscleGroupButtonText:        "💪 Мышечная группа",
+	exerciseEditEquipmentButtonText:          "🛠 Оборудование",
+	exerciseEditMergeButtonText:              "🔀 Объединить с другим",
+	exerciseEditArchiveButtonText:            "🗄 Архивировать",
+	exerciseEditRenameText:                   "Введите новое название упражнения:",
+	exerciseEditEquipmentText:                "Введите оборудование:",
+	exerciseEditMergeText:                    "Введите название упражнения, в которое нужно перенести историю:",
+	exerciseEditMergeSelectText:              "Выберите упражнение, в которое перенести историю:",
+	exerciseEditEmptyValueText:               "Пустое значение",
+	confirmRenameExerciseText:                "Переименовать «%s» в «%s»? Название изменится и во всех записанных подходах.",
+	confirmMuscleGroupExerciseText:           "Перенести «%s» из группы %s в группу %s? Группа изменится и во всех записанных подходах.",
+	confirmEquipmentExerciseText:             "Изменить оборудование «%s»: «%s» → «%s»?",
+	confirmArchiveExerciseText:               "Архивировать «%s»? Упражнение пропадёт из списков и поиска, история подходов сохранится.",
+	confirmMergeExerciseText:                 "Объединить «%s» с «%s»? Вся история первого перейдёт ко второму, а первое будет архивировано.",
+	exerciseEditDoneText:                     "✅ Изменения сохранены: «%s»",
+	exerciseEditCanceledText:                 "Изменение отменено",
+	exerciseEditExpiredText:                  "Изменение уже применено или отменено, начните заново: /manage_exercise",
+	errEditExercise:                          "❌ Ошибка при изменении упражнения",
+	errExerciseArchived:                      "❌ Упражнение уже в архиве",
+	errInvalidExerciseMerge:                  "❌ Эти упражнения нельзя объединить",
+	muscleVolumeTitleText:                    "\n\n💪 Объём по мышцам (кг):\n",
+	muscleVolumeLineText:                     "• %s: %.2f\n",
+	errSecondaryMuscles:                      "❌ Не удалось разобрать вспомогательные мышцы. Формат: Руки 0.5, Плечи 30%%\nДоступные: %v",
+	favoritesText:                            "⭐ Избранное",
+	pinExerciseText:                          "⭐ В избранное",
+	unpinExerciseText:                        "✖️ Убрать из избранного",
+	errNoFavoriteExercises:                   "⭐ В избранном пока пусто. Закрепить упражнение можно кнопкой «⭐ В избранное» после его выбора",
+	errFavoriteExercise:                      "❌ Не удалось обновить избранное",
+	trainingGymText:                          "\n🏟 Зал: *%s*",
+	noGymText:                                "🏟 Зал не выбран: в тренировке показываются все упражнения.",
+	currentGymText:                           "🏟 Текущий зал: %s\nОборудование: %s\n\nУпражнения без этого оборудования скрыты в тренировке.",
+	analyticsByGymText:                       "\n📊 Статистика: только по текущему залу.",
+	analyticsAllGymsText:                     "\n📊 Статистика: по всем залам.",
+	newGymButtonText:                         "➕ Новый зал",
+	noGymButtonText:                          "🚫 Без зала",
+	analyticsByGymButtonText:                 "📊 Статистика только по текущему залу",
+	analyticsAllGymsButtonText:               "📊 Статистика по всем залам",
+	startCreateGymText:                       "Введите название зала и доступное оборудование:\n\nФормат:\n<название>\n<оборудование через запятую>\n\nОборудование из каталога: %s. Упражнения с собственным весом доступны в любом зале.",
+	gymCreatedText:                           "✅ Зал «%s» создан и выбран текущим.",
+	errGym:                                   "❌ Ошибка при работе с залами",
+	errGymNotFound:                           "❌ Зал не найден",
+	errUnknownEquipment:                      "❌ Неизвестное оборудование. Доступно: %s",
+	techniqueButtonText:                      "ℹ️ Техника",
+	closeTechniqueText:                       "✖️ Скрыть",
+	techniqueTitleText:                       "ℹ️ Техника: %s",
+	techniqueCuesText:                        "\n\n✅ Подсказки:",
+	techniqueMistakesText:                    "\n\n⚠️ Частые ошибки:",
+	noTechniqueText:                          "ℹ️ Для упражнения «%s» пока нет описания техники.",
+	techniqueAdminHintText:                   "\n\n🛠 Ответьте на это сообщение текстом, чтобы заменить описание: описание, пустая строка, подсказки по одной в строке, пустая строка, частые ошибки. Фото, GIF или видео в ответ на это сообщение или экран упражнения добавятся к карточке.",
+	techniqueUpdatedText:                     "✅ Карточка техники обновлена",
+	errTechnique:                             "❌ Ошибка при работе с карточкой техники",
+	errTechniqueMedia:                        "❌ Прикрепить можно только фото, GIF или видео",
+	errTechniqueMediaLimit:                   "❌ К карточке можно прикрепить не больше %d вложений",
+	selectTrainingText:                       "✏️ Выберите тренировку, чтобы посмотреть или изменить её:",
+	trainingButtonText:                       "📅 %s · упражнений: %d",
+	trainingExerciseText:                     "📅 %s\n%d. %s\n\nВыберите подход, чтобы изменить его, или 🗑, чтобы удалить:",
+	trainingSetButtonText:                    "✏️ Подход %d: %s×%d",
+	changeTrainingDateButtonText:             "📅 Изменить дату",
+	deleteTrainingButtonText:                 "🗑 Удалить тренировку",
+	deleteTrainingExerciseButtonText:         "🗑 Удалить упражнение",
+	backToTrainingText:                       "⬅️ К тренировке",
+	editTrainingSetText:                      "Отправьте новые данные подхода %d в формате вес,повторы (заметка), например: 80,10 (тяжело)",
+	editTrainingDateText:                     "Отправьте новую дату тренировки в формате ГГГГ-ММ-ДД",
+	confirmDeleteTrainingText:                "Удалить тренировку вместе со всеми подходами?",
+	trainingDeletedText:                      "🗑 Тренировка удалена",
+	trainingUpdatedText:                      "✅ Тренировка обновлена",
+	errEditTraining:                          "❌ Ошибка при изменении тренировки",
+	errTrainingNotFound:                      "❌ Тренировка не найдена",
+	trainingEditExpiredText:                  "Изменение уже применено или отменено, откройте тренировку заново: /get_trainings",
+	undoSetButtonText:                        "↩️ Отменить подход",
+	activeSetsButtonText:                     "📋 Подходы упражнения",
+	setUndoneText:                            "↩️ Отменено подходов: %d. Введите данные подхода заново, либо выберите действие:",
+	setAlreadyUndoneText:                     "Эти подходы уже удалены.",
+	activeSetsText:                           "📋 %s\nНажмите на подход, чтобы удалить его:",
+	noActiveSetsText:                         "В текущем упражнении пока нет подходов.",
+	activeSetButtonText:                      "🗑 Подход %d: %s×%d",
+	errSessionSet:                            "❌ Не удалось удалить подход.",
+	currentTrainingText:                      "🏋️ Текущая тренировка. ▶️ - упражнение, в которое записываются подходы. Нажмите на упражнение, чтобы продолжить его:\n",
+	currentExerciseLineText:                  "\n%s%d. %s - подходов: %d",
+	noCurrentExercisesText:                   "В текущей тренировке пока нет упражнений.",
+	currentExerciseSelectedText:              "▶️ Продолжаем: %s. Введите данные подхода, либо выберите действие:",
+	errCurrentTraining:                       "❌ Не удалось изменить текущую тренировку.",
+	trainingDetailsPromptText:                "📝 По желанию оцените тренировку от 1 до 5 и добавьте вес тела и заметку.",
+	trainingDetailsButtonText:                "📝 Вес тела и заметка",
+	editTrainingDetailsText:                  "Отправьте вес тела и заметку: первая строка - вес тела в кг (ее можно пропустить), остальное - заметка. Не отправленное останется прежним. Например:\n81.5\nХорошо шла спина",
+	trainingsSummaryText:                     "📊 Тренировок: %d",
+	trainingsAvgDurationText:                 "\n🕒 Средняя длительность: %s",
+	trainingsAvgRatingText:                   "\n⭐ Средняя оценка: %.1f",
+	trainingsBodyweightText:                  "\n⚖️ Вес тела: %s → %s кг",
+	paceTimeText:                             "\n\n⏱ Время тренировки: %s",
+	paceRestText:                             "\n💤 Средний отдых: %s",
+	paceDensityText:                          "\n📈 Плотность: %s кг/мин",
+	restReportButtonText:                     "💤 Отдых по упражнениям",
+	restReportTitleText:                      "💤 Отдых между подходами:",
+	restReportLineText:                       "\n%d. %s - в среднем %s (%s)",
+	restReportNoDataLineText:                 "\n%d. %s - нет данных",
+	noRestDataText:                           "Для этой тренировки нет времени подходов: оно записывается, когда подходы вводятся во время тренировки.",
+	idleReminderFinishText:                   "⏰ В тренировке нет действий уже %s. Если не продолжить, через %s она завершится автоматически с записанными подходами.",
+	idleReminderDiscardText:                  "⏰ В тренировке нет действий уже %s. Если не продолжить, через %s она будет удалена.",
+	idleKeepButtonText:                       "▶️ Продолжить",
+	idleKeptText:                             "▶️ Продолжаем тренировку! Введите данные подхода или выберите упражнение.",
+	idleFinishedText:                         "🏁 Тренировка завершена автоматически: упражнений %d, подходов %d, объем %.0f кг.",
+	idleDiscardedText:                        "🗑 Брошенная тренировка удалена.",
+	autoCloseText:                            "Если тренировка брошена без завершения, бот сначала напомнит о ней, а затем:\n\n%s",
+	autoCloseFinishText:                      "🏁 Завершать с записанными подходами",
+	autoCloseDiscardText:                     "🗑 Удалять",
+	errAutoClose:                             "❌ Не удалось сохранить настройку.",
+	trainingAlreadyFinishedText:              "Тренировка уже завершена.",
+	emptyTrainingFinishedText:                "В тренировке не было заполненных подходов, она не сохранена.",
+	dashboardTitleText:                       "🏋️ Текущая тренировка\n⏱ %s · 📦 %s кг\n",
+	dashboardEmptyText:                       "\nДобавь упражнение, чтобы начать",
+	dashboardExerciseText:                    "\n%s%d. %s: %s",
+	dashboardNoSetsText:                      "—",
+	coachTitleText:                           "🧑‍🏫 Режим тренера\n",
+	coachNoAthletesText:                      "\nСпортсменов пока нет. Отправь спортсмену ссылку-приглашение — после его согласия ты увидишь его тренировки.\n",
+	coachYourCoachesText:                     "\nТвои тренеры, у которых есть доступ к тренировкам:",
+	coachRevokeButtonText:                    "🚫 Закрыть доступ: %s",
+	coachInviteButtonText:                    "🔗 Пригласить спортсмена",
+	coachInviteText:                          "Отправь эту ссылку спортсмену. Она одноразовая: доступ откроется только после его согласия.\n%s",
+	coachInviteInvalidText:                   "⚠️ Приглашение недействительно или уже использовано",
+	coachConsentText:                         "%s приглашает тебя стать его спортсменом. Тренер будет видеть твои тренировки, прогресс по упражнениям и сможет оставлять комментарии. Доступ можно закрыть в любой момент через /coach.",
+	coachAcceptButtonText:                    "✅ Согласен",
+	coachDeclineButtonText:                   "❌ Отказаться",
+	coachAcceptedText:                        "✅ Теперь %s видит твои тренировки",
+	coachDeclinedText:                        "Приглашение отклонено",
+	coachAthleteJoinedText:                   "🎉 %s принял приглашение. Его тренировки доступны в /coach",
+	coachRevokedText:                         "🚫 Доступ с %s закрыт",
+	coachRevokedByOtherText:                  "🚫 %s закрыл доступ к тренировкам",
+	coachAthleteText:                         "🏃 %s",
+	coachTrainingsButtonText:                 "📅 Тренировки за 30 дней",
+	coachProgressButtonText:                  "📈 Прогресс по упражнениям",
+	coachSelectTrainingText:                  "Выбери тренировку, чтобы оставить комментарий:",
+	coachSelectExerciseText:                  "Выбери упражнение:",
+	coachCommentButtonText:                   "💬 Комментировать",
+	coachCommentPromptText:                   "Напиши комментарий к тренировке %s:",
+	coachCommentSentText:                     "✅ Комментарий отправлен спортсмену",
+	coachCommentNotificationText:             "💬 %s прокомментировал тренировку %s:\n%s",
+	sessionCommentsTitleText:                 "\n\n💬 Комментарии тренера:",
+	sessionCommentText:                       "\n%s: %s",
+	errCoach:                                 "❌ Не удалось выполнить действие тренера",
+	errCoachLinkExists:                       "⚠️ Этот тренер уже видит твои тренировки",
+	errCoachSelfLink:                         "⚠️ Нельзя стать тренером самому себе",
+	errInvalidComment:                        "⚠️ Комментарий должен быть непустым и не длиннее 1000 символов",
+	workoutPlanButtonText:                    "📝 Назначить тренировку",
+	workoutPlanPromptText:                    "Пришли план в формате /upload_training. Дата над упражнениями — день, в который спортсмен получит тренировку, без даты — сегодня:\n2024-02-15\n1. Жим лежа - 60,10; 60,10; 60,8\n2. Присед - 80,8; 80,8",
+	workoutPlanDateText:                      "📅 %s\n",
+	workoutSelectAthletesText:                "Выбери спортсменов и нажми «Назначить»:",
+	workoutAssignButtonText:                  "📨 Назначить",
+	workoutAssignedText:                      "✅ Назначено тренировок: %d. Спортсмены получат их в назначенный день",
+	workoutDraftExpiredText:                  "⚠️ План не найден, пришли его заново",
+	workoutDeliveredText:                     "🗓 %s назначил тренировку на %s:\n\n%s",
+	workoutStartButtonText:                   "▶️ Начать тренировку по плану",
+	workoutStartedText:                       "🏋️ Тренировка по плану началась. Записывай подходы — они заполняют план активного упражнения по порядку, переключиться на другое упражнение можно в /current",
+	workoutComparisonText:                    "📊 %s выполнил тренировку на %s\nподход: план → факт\n",
+	workoutComparisonSetText:                 "  %d: %s → %s %s\n",
+	dashboardPlannedSetText:                  "план %s",
+	errWorkoutPlanFormat:                     "⚠️ Не удалось разобрать план, проверь формат как в /upload_training",
+	errWorkoutDateInPast:                     "⚠️ Нельзя назначить тренировку на прошедшую дату",
+	errNoAthletesSelected:                    "⚠️ Выбери хотя бы одного спортсмена",
+	errWorkoutAthleteNotLinked:               "⚠️ Один из спортсменов закрыл тебе доступ",
+	errAssignedWorkoutNotFound:               "⚠️ Тренировка по плану не найдена",
+	errWorkoutCompleted:                      "✅ Эта тренировка по плану уже выполнена",
+	errWorkoutTrainingStarted:                "⚠️ Сначала заверши текущую тренировку",
+	groupHelpText:                            "👋 Я веду дневник тренировок участников этой группы.\n\n/join - участвовать в рейтингах группы\n/leave - выйти из рейтингов\n/leaderboard - рейтинг недели: объем, тренировки и e1RM к весу тела\n/group_lifts - упражнения для рейтинга e1RM (менять могут администраторы)\n\nТренировки записываются в личном чате с ботом.",
+	groupPrivateOnlyText:                     "Эта команда работает только в личном чате с ботом: %s",
+	groupOnlyText:                            "Эта команда работает только в групповом чате. Добавьте бота в группу.",
+	groupJoinedText:                          "✅ %s участвует в рейтингах группы. Кнопка ниже включает объявления о ваших рекордах.",
+	groupAlreadyJoinedText:                   "%s уже участвует в рейтингах группы.",
+	groupLeftText:                            "🚪 %s больше не участвует в рейтингах группы.",
+	groupPRButtonText:                        "🏆 Объявлять мои рекорды",
+	groupPREnabledText:                       "Ваши рекорды будут объявляться в группе",
+	groupPRDisabledText:                      "Ваши рекорды больше не объявляются в группе",
+	groupNoMembersText:                       "В рейтингах пока никого нет. Отправьте /join, чтобы участвовать.",
+	groupAdminOnlyText:                       "Менять упражнения для рейтинга могут только администраторы группы.",
+	groupLiftsUsageText:                      "Чтобы задать упражнения (не больше %d), отправьте их через точку с запятой:\n/group_lifts Жим лежа; Приседания со штангой",
+	groupLiftsCurrentText:                    "🏋️ Упражнения рейтинга: %s\n\n",
+	groupLiftsSavedText:                      "✅ Упражнения рейтинга: %s",
+	groupLeaderboardTitleText:                "🏆 Рейтинг недели с %s\n",
+	groupLeaderboardEmptyText:                "\nНа этой неделе еще никто не тренировался.",
+	groupLeaderboardVolumeText:               "\n📦 Объем:\n",
+	groupLeaderboardVolumeEntryText:          "%d. %s — %s кг\n",
+	groupLeaderboardSessionsText:             "\n🗓 Тренировки:\n",
+	groupLeaderboardEntryText:                "%d. %s — %s\n",
+	groupLeaderboardLiftText:                 "\n🏋️ %s (e1RM / вес тела):\n",
+	groupLeaderboardNoLiftText:               "нет результатов с указанным весом тела\n",
+	groupPRTitleText:                         "🎉 Новые рекорды у %s!\n",
+	groupPRText:                              "• %s: %s, e1RM %s кг (было %s кг)\n",
+	errGroup:                                 "❌ Не удалось выполнить команду группы, попробуйте позже.",
+	errNotGroupMember:                        "Вы не участвуете в рейтингах группы. Отправьте /join.",
+	errTooManyGroupLifts:                     "Можно выбрать не больше %d упражнений.",
+	errGroupLiftNotFound:                     "Упражнение не найдено в общем каталоге, проверьте название.",
+	partnerInviteText:                        "👥 Отправьте партнеру ссылку, чтобы тренироваться вместе: упражнения выбираются один раз на всех, а подходы каждый записывает в своем чате.\n\n%s",
+	partnerListText:                          "\n\nУже с вами: %s",
+	partnerInviteInvalidText:                 "Совместная тренировка уже закончилась или ссылка неверна.",
+	partnerAlreadyJoinedText:                 "Вы уже участвуете в этой тренировке.",
+	partnerNoExercisesText:                   "пока не выбраны",
+	partnerConsentText:                       "👥 %s приглашает вас на совместную тренировку.\nУпражнения: %s\n\nВаши подходы будут записываться отдельно и сохранятся в вашем дневнике.",
+	partnerJoinButtonText:                    "✅ Присоединиться",
+	partnerJoinedText:                        "✅ Вы тренируетесь вместе с %s. Отправляйте свои подходы, новые упражнения появятся у всех.",
+	partnerJoinedNotificationText:            "👥 %s присоединился к вашей тренировке.",
+	partnerExerciseText:                      "👥 %s выбрал упражнение %s, отправляйте свои подходы.",
+	errPartner:                               "❌ Не удалось выполнить действие с совместной тренировкой, попробуйте позже.",
+	errSharedSessionFull:                     "В совместной тренировке уже %d участника.",
+	errPartnerTrainingStarted:                "Сначала завершите или сбросьте свою текущую тренировку.",
+}
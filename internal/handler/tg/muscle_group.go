@@ -0,0 +1,155 @@
+package tg
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/i18n"
+)
+
+const defaultSecondaryMuscleWeight = float32(0.5)
+
+// muscleGroups возвращает справочник мышечных групп в порядке отображения.
+func (a *API) muscleGroups() []entity.MuscleGroup {
+	groups, err := a.trainingService.GetMuscleGroups(a.ctx)
+	if err != nil {
+		log.Printf("get muscle groups err: %v", err)
+		return nil
+	}
+
+	return groups
+}
+
+func (a *API) muscleGroupName(locale i18n.Locale, name string) string {
+	for _, group := range a.muscleGroups() {
+		if group.Name() == name {
+			return group.Title(string(locale))
+		}
+	}
+
+	return name
+}
+
+// muscleGroupTitle как muscleGroupName, но понимает и подборку избранного.
+func (a *API) muscleGroupTitle(locale i18n.Locale, name string) string {
+	if name == favoritesMuscleGroup {
+		return a.text(locale, favoritesText)
+	}
+
+	return a.muscleGroupName(locale, name)
+}
+
+// muscleGroupButtons клавиатура выбора упражнения: избранное и все мышечные группы.
+func (a *API) muscleGroupButtons(locale i18n.Locale) [][]tgbotapi.InlineKeyboardButton {
+	favorites := tgbotapi.NewInlineKeyboardButtonData(a.text(locale, favoritesText), musclePrefix+favoritesMuscleGroup)
+
+	return append(
+		[][]tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardRow(favorites)},
+		a.muscleGroupButtonsWithPrefix(locale, musclePrefix)...,
+	)
+}
+
+func (a *API) muscleGroupButtonsWithPrefix(locale i18n.Locale, prefix string) [][]tgbotapi.InlineKeyboardButton {
+	var buttons [][]tgbotapi.InlineKeyboardButton
+	for _, group := range a.muscleGroups() {
+		label := group.Emoji() + " " + group.Title(string(locale))
+		button := tgbotapi.NewInlineKeyboardButtonData(label, prefix+group.Name())
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(button))
+	}
+
+	return buttons
+}
+
+// findMuscleGroup ищет мышечную группу по названию на любом поддерживаемом языке
+// и возвращает название, под которым группа хранится в базе.
+func (a *API) findMuscleGroup(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+
+	for _, group := range a.muscleGroups() {
+		if strings.EqualFold(input, group.Name()) {
+			return group.Name(), true
+		}
+		for _, title := range group.Titles() {
+			if strings.EqualFold(input, title) {
+				return group.Name(), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (a *API) availableMuscleGroups(locale i18n.Locale) []string {
+	groups := a.muscleGroups()
+
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Title(string(locale)))
+	}
+
+	return names
+}
+
+// parseSecondaryMuscles разбирает строку вида "Руки 0.5, Плечи 30%". Без веса
+// вспомогательной группе достается defaultSecondaryMuscleWeight.
+func (a *API) parseSecondaryMuscles(input string) ([]entity.SecondaryMuscle, bool) {
+	var muscles []entity.SecondaryMuscle
+
+	for _, item := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ';' }) {
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+
+		weight := defaultSecondaryMuscleWeight
+		if parsed, ok := parseMuscleWeight(fields[len(fields)-1]); ok {
+			weight = parsed
+			fields = fields[:len(fields)-1]
+		}
+
+		name, ok := a.findMuscleGroup(strings.Join(fields, " "))
+		if !ok || weight <= 0 || weight > 1 {
+			return nil, false
+		}
+
+		muscles = append(muscles, entity.SecondaryMuscle{MuscleGroup: name, Weight: weight})
+	}
+
+	return muscles, true
+}
+
+func parseMuscleWeight(s string) (float32, bool) {
+	percent := strings.HasSuffix(s, "%")
+
+	weight, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 32)
+	if err != nil {
+		return 0, false
+	}
+
+	if percent {
+		weight /= 100
+	}
+
+	return float32(weight), true
+}
+
+// formatMuscleVolume выводит объем тренировки по мышечным группам в порядке справочника.
+func (a *API) formatMuscleVolume(locale i18n.Locale, volume map[string]float32) string {
+	var sb strings.Builder
+
+	for _, group := range a.muscleGroups() {
+		if v, ok := volume[group.Name()]; ok && v > 0 {
+			sb.WriteString(a.textf(locale, muscleVolumeLineText, group.Title(string(locale)), v))
+		}
+	}
+
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return a.text(locale, muscleVolumeTitleText) + sb.String()
+}
@@ -0,0 +1,161 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+// partnerInvitePayload параметр /start из ссылки-приглашения в совместную тренировку
+const partnerInvitePayload = "partner_"
+
+// PartnerHandler открывает текущую тренировку для партнера и присылает ссылку-приглашение.
+func (a *API) PartnerHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	shared, err := a.trainingService.ShareSession(a.ctx, userID, message.From.String())
+	if err != nil {
+		a.sendPartnerError(chatID, locale, err)
+		return
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%s", a.bot.Self.UserName, partnerInvitePayload, shared.ID())
+	text := a.textf(locale, partnerInviteText, link)
+	if partners := shared.Partners(userID); len(partners) > 0 {
+		text += a.textf(locale, partnerListText, partnerNames(partners))
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+// partnerInviteConsent показывает приглашение в совместную тренировку из ссылки.
+func (a *API) partnerInviteConsent(message *tgbotapi.Message, payload string) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	sharedID, err := uuid.Parse(strings.TrimPrefix(payload, partnerInvitePayload))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, partnerInviteInvalidText)))
+		return
+	}
+
+	shared, err := a.trainingService.GetSharedSession(a.ctx, sharedID)
+	if err != nil {
+		a.sendPartnerError(chatID, locale, err)
+		return
+	}
+
+	if _, ok := shared.Participant(userID); ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, partnerAlreadyJoinedText)))
+		return
+	}
+
+	names := make([]string, 0, len(shared.Exercises()))
+	for _, exercise := range shared.Exercises() {
+		names = append(names, exercise.Name())
+	}
+	exercises := a.text(locale, partnerNoExercisesText)
+	if len(names) > 0 {
+		exercises = strings.Join(names, ", ")
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.textf(locale, partnerConsentText, partnerNames(shared.Participants()), exercises))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, partnerJoinButtonText), partnerJoinPrefix+sharedID.String()),
+	))
+
+	_, _ = a.bot.Send(msg)
+}
+
+// PartnerJoinHandler добавляет пользователя в совместную тренировку и сообщает об этом партнерам.
+func (a *API) PartnerJoinHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sharedID, err := uuid.Parse(strings.TrimPrefix(callback.Data, partnerJoinPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	shared, err := a.trainingService.JoinSharedSession(a.ctx, sharedID, userID, callback.From.String())
+	if err != nil {
+		a.sendPartnerError(chatID, locale, err)
+		return
+	}
+
+	a.setUserState(userID, entity.StateAwaitingSetInput)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, partnerJoinedText, partnerNames(shared.Partners(userID)))))
+	a.refreshDashboard(chatID, locale, userID)
+
+	for _, partner := range shared.Partners(userID) {
+		a.notifyUser(partner.UserID, func(locale i18n.Locale) string {
+			return a.textf(locale, partnerJoinedNotificationText, callback.From.String())
+		})
+	}
+}
+
+// syncPartners сообщает партнерам по совместной тренировке о выбранном упражнении и обновляет
+// их закрепленные сообщения: подходы они записывают в него без повторного выбора.
+func (a *API) syncPartners(user *tgbotapi.User) {
+	userID := strconv.FormatInt(user.ID, 10)
+
+	partners, err := a.trainingService.GetSessionPartners(a.ctx, userID)
+	if err != nil {
+		return
+	}
+
+	for _, partner := range partners {
+		chatID, err := strconv.ParseInt(partner.UserID, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		session, err := a.trainingService.GetCurrentSession(a.ctx, partner.UserID)
+		if err != nil || session == nil || session.ActiveExercise() == nil {
+			continue
+		}
+
+		locale := a.userLocale(&tgbotapi.User{ID: chatID})
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, partnerExerciseText, user.String(), session.ActiveExercise().Name())))
+		a.refreshDashboard(chatID, locale, partner.UserID)
+	}
+}
+
+func partnerNames(participants []entity.SessionParticipant) string {
+	names := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		names = append(names, participant.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (a *API) sendPartnerError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errPartner)
+	switch {
+	case errors.Is(err, errs.ErrSessionNotFound):
+		text = a.text(locale, errNoTraining)
+	case errors.Is(err, errs.ErrSharedSessionNotFound):
+		text = a.text(locale, partnerInviteInvalidText)
+	case errors.Is(err, errs.ErrSharedSessionFull):
+		text = a.textf(locale, errSharedSessionFull, entity.MaxSessionParticipants)
+	case errors.Is(err, errs.ErrTrainingStarted):
+		text = a.text(locale, errPartnerTrainingStarted)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
@@ -0,0 +1,77 @@
+package tg
+
+import (
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// RestReportHandler показывает отдых между подходами по каждому упражнению тренировки.
+func (a *API) RestReportHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, restReportPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.GetTrainingSession(a.ctx, userID, sessionID)
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.formatRestReport(locale, session.Pace())))
+}
+
+// formatPace добавляет к итогам тренировки время, средний отдых и плотность, если они известны.
+func (a *API) formatPace(locale i18n.Locale, pace entity.SessionPace) string {
+	if pace.TimeUnderSession <= 0 {
+		return ""
+	}
+
+	text := a.textf(locale, paceTimeText, formatter.FormatDuration(pace.TimeUnderSession))
+	if pace.AvgRest > 0 {
+		text += a.textf(locale, paceRestText, formatter.FormatRest(pace.AvgRest))
+	}
+	if pace.Density > 0 {
+		text += a.textf(locale, paceDensityText, formatter.FormatWeightFloat(float64(pace.Density)))
+	}
+
+	return text
+}
+
+func (a *API) formatRestReport(locale i18n.Locale, pace entity.SessionPace) string {
+	if pace.TimeUnderSession <= 0 {
+		return a.text(locale, noRestDataText)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(a.text(locale, restReportTitleText))
+
+	for _, exercise := range pace.Exercises {
+		if len(exercise.Intervals) == 0 {
+			sb.WriteString(a.textf(locale, restReportNoDataLineText, exercise.Number, exercise.Name))
+			continue
+		}
+
+		intervals := make([]string, 0, len(exercise.Intervals))
+		for _, interval := range exercise.Intervals {
+			intervals = append(intervals, formatter.FormatRest(interval))
+		}
+
+		sb.WriteString(a.textf(locale, restReportLineText, exercise.Number, exercise.Name,
+			formatter.FormatRest(exercise.Avg()), strings.Join(intervals, ", ")))
+	}
+
+	return sb.String()
+}
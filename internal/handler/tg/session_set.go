@@ -0,0 +1,140 @@
+package tg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// UndoSetHandler отменяет подходы, записанные сообщением, и обновляет закрепленное сообщение тренировки.
+func (a *API) UndoSetHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	setMessageID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, undoSetPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	undone, err := a.trainingService.UndoSets(a.ctx, userID, setMessageID)
+	if err != nil {
+		a.sendSessionSetError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Request(tgbotapi.NewCallback(callback.ID, a.textf(locale, setUndoneText, len(undone))))
+	a.refreshDashboard(chatID, locale, userID)
+}
+
+// ActiveSetsHandler показывает подходы активного упражнения с кнопками удаления.
+func (a *API) ActiveSetsHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	session, err := a.trainingService.GetCurrentSession(a.ctx, userID)
+	if err != nil {
+		a.sendSessionSetError(chatID, locale, err)
+		return
+	}
+
+	text, markup := a.activeSetsView(locale, session)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) ActiveSetDeleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	setID, err := uuid.Parse(strings.TrimPrefix(callback.Data, activeSetDeletePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.DeleteSessionSet(a.ctx, userID, setID)
+	if err != nil {
+		a.sendSessionSetError(chatID, locale, err)
+		return
+	}
+
+	text, markup := a.activeSetsView(locale, session)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+
+	a.refreshDashboard(chatID, locale, userID)
+}
+
+// activeSetsView перечисляет заполненные подходы активного упражнения, заготовка пропускается.
+func (a *API) activeSetsView(locale i18n.Locale, session *entity.TrainingSession) (string, tgbotapi.InlineKeyboardMarkup) {
+	exercise := session.ActiveExercise()
+	if exercise == nil {
+		return a.text(locale, noActiveSetsText), tgbotapi.NewInlineKeyboardMarkup()
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, set := range exercise.Sets() {
+		if !set.IsFilled() {
+			continue
+		}
+
+		label := a.textf(locale, activeSetButtonText, set.Number(), formatter.FormatWeightFloat(float64(set.Weight())), set.Reps())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, activeSetDeletePrefix+set.ID().String()),
+		))
+	}
+
+	if len(rows) == 0 {
+		return a.text(locale, noActiveSetsText), tgbotapi.NewInlineKeyboardMarkup()
+	}
+
+	return a.textf(locale, activeSetsText, exercise.Name()), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// setActionsMarkup - кнопки под сообщением тренировки, при setMessageID = 0 без отмены.
+func (a *API) setActionsMarkup(locale i18n.Locale, setMessageID int) tgbotapi.InlineKeyboardMarkup {
+	setsRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, activeSetsButtonText), activeSetsPrefix),
+	)
+	if setMessageID != 0 {
+		setsRow = append([]tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, undoSetButtonText), undoSetPrefix+strconv.Itoa(setMessageID)),
+		}, setsRow...)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		setsRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, startNewExerciseText), startNewExercisePrefix),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, finishTrainingText), confirmationFinishTrainingPrefix),
+		),
+	)
+}
+
+func (a *API) sendSessionSetError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errSessionSet)
+	switch {
+	case errors.Is(err, errs.ErrSessionNotFound):
+		text = a.text(locale, errNoTraining)
+	case errors.Is(err, errs.ErrSetNotFound):
+		text = a.text(locale, setAlreadyUndoneText)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
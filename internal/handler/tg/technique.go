@@ -0,0 +1,284 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+// TechniqueHandler отправляет вложения и карточку техники упражнения.
+func (a *API) TechniqueHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	locale := a.userLocale(callback.From)
+
+	exerciseID, err := uuid.Parse(strings.TrimPrefix(callback.Data, techniquePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidExerciseID)))
+		return
+	}
+
+	exercise, err := a.trainingService.GetExercise(a.ctx, exerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errExerciseLoad)))
+		return
+	}
+
+	technique, err := a.trainingService.GetTechnique(a.ctx, exerciseID)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errTechnique)))
+		return
+	}
+
+	for _, media := range technique.Media() {
+		_, _ = a.bot.Send(techniqueMediaMessage(chatID, media))
+	}
+
+	text := a.formatTechnique(locale, exercise.Name(), technique)
+	if a.isAdmin(callback.From) {
+		text += a.text(locale, techniqueAdminHintText)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, closeTechniqueText), techniqueClosePrefix+exerciseID.String()),
+	))
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) CloseTechniqueHandler(callback *tgbotapi.CallbackQuery) {
+	_, _ = a.bot.Request(tgbotapi.NewDeleteMessage(callback.Message.Chat.ID, callback.Message.MessageID))
+}
+
+// isTechniqueReply сообщает, что администратор ответил на сообщение упражнения вложением
+// или на карточку техники текстом.
+func (a *API) isTechniqueReply(message *tgbotapi.Message) bool {
+	reply := message.ReplyToMessage
+	if reply == nil || reply.From == nil || reply.From.ID != a.bot.Self.ID || !a.isAdmin(message.From) {
+		return false
+	}
+
+	exerciseID, card := techniqueExerciseID(reply)
+	if exerciseID == uuid.Nil {
+		return false
+	}
+
+	if message.Photo != nil || message.Animation != nil || message.Video != nil {
+		return true
+	}
+
+	if doc := message.Document; doc != nil {
+		return strings.HasPrefix(doc.MimeType, "image/") || strings.HasPrefix(doc.MimeType, "video/")
+	}
+
+	return card && message.Text != "" && !message.IsCommand()
+}
+
+// TechniqueReplyHandler прикрепляет к карточке техники фото, GIF или видео из ответа
+// администратора, а текстовый ответ на карточку заменяет ее описание.
+func (a *API) TechniqueReplyHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+	exerciseID, _ := techniqueExerciseID(message.ReplyToMessage)
+
+	var err error
+	if message.Text != "" {
+		description, cues, mistakes, ok := parseTechniqueText(message.Text)
+		if !ok {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidFormat)))
+			return
+		}
+
+		_, err = a.trainingService.UpdateTechniqueText(a.ctx, userID, exerciseID, description, cues, mistakes)
+	} else {
+		var media entity.TechniqueMedia
+		media, err = a.techniqueMediaFromMessage(message, exerciseID)
+		if err == nil {
+			_, err = a.trainingService.AddTechniqueMedia(a.ctx, userID, exerciseID, media)
+		}
+	}
+
+	if err != nil {
+		text := a.text(locale, errTechnique)
+		switch {
+		case errors.Is(err, errs.ErrTechniqueMediaLimit):
+			text = a.textf(locale, errTechniqueMediaLimit, entity.MaxTechniqueMedia)
+		case errors.Is(err, errs.ErrUnsupportedFile):
+			text = a.text(locale, errTechniqueMedia)
+		case errors.Is(err, errs.ErrFileTooLarge):
+			text = a.text(locale, errUploadFile)
+		case errors.Is(err, errs.ErrExerciseArchived):
+			text = a.text(locale, errExerciseArchived)
+		}
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, techniqueUpdatedText)))
+}
+
+// techniqueMediaFromMessage берет file_id фото, GIF или видео. Изображение или видео,
+// отправленное файлом, сохраняется в MediaPath и не зависит от file_id бота.
+func (a *API) techniqueMediaFromMessage(message *tgbotapi.Message, exerciseID uuid.UUID) (entity.TechniqueMedia, error) {
+	switch {
+	case message.Animation != nil:
+		return entity.TechniqueMedia{Type: entity.MediaAnimation, FileID: message.Animation.FileID}, nil
+	case message.Video != nil:
+		return entity.TechniqueMedia{Type: entity.MediaVideo, FileID: message.Video.FileID}, nil
+	case len(message.Photo) > 0:
+		largest := message.Photo[len(message.Photo)-1]
+		return entity.TechniqueMedia{Type: entity.MediaPhoto, FileID: largest.FileID}, nil
+	case message.Document != nil:
+		return a.saveTechniqueDocument(message.Document, exerciseID)
+	}
+
+	return entity.TechniqueMedia{}, errs.ErrUnsupportedFile
+}
+
+func (a *API) saveTechniqueDocument(doc *tgbotapi.Document, exerciseID uuid.UUID) (entity.TechniqueMedia, error) {
+	var mediaType entity.MediaType
+	switch {
+	case doc.MimeType == "image/gif":
+		mediaType = entity.MediaAnimation
+	case strings.HasPrefix(doc.MimeType, "image/"):
+		mediaType = entity.MediaPhoto
+	case strings.HasPrefix(doc.MimeType, "video/"):
+		mediaType = entity.MediaVideo
+	default:
+		return entity.TechniqueMedia{}, errs.ErrUnsupportedFile
+	}
+
+	data, err := a.downloadFile(doc.FileID, doc.FileSize, maxMediaFileSize)
+	if err != nil {
+		return entity.TechniqueMedia{}, err
+	}
+
+	if err := os.MkdirAll(a.cfg.MediaPath, 0o755); err != nil {
+		return entity.TechniqueMedia{}, fmt.Errorf("create media dir error: %w", err)
+	}
+
+	path := filepath.Join(a.cfg.MediaPath, fmt.Sprintf("%s-%s%s", exerciseID, doc.FileUniqueID, filepath.Ext(doc.FileName)))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return entity.TechniqueMedia{}, fmt.Errorf("write media file error: %w", err)
+	}
+
+	return entity.TechniqueMedia{Type: mediaType, Path: path}, nil
+}
+
+func techniqueMediaMessage(chatID int64, media entity.TechniqueMedia) tgbotapi.Chattable {
+	var file tgbotapi.RequestFileData = tgbotapi.FileID(media.FileID)
+	if media.FileID == "" {
+		file = tgbotapi.FilePath(media.Path)
+	}
+
+	switch media.Type {
+	case entity.MediaAnimation:
+		return tgbotapi.NewAnimation(chatID, file)
+	case entity.MediaVideo:
+		return tgbotapi.NewVideo(chatID, file)
+	default:
+		return tgbotapi.NewPhoto(chatID, file)
+	}
+}
+
+// techniqueExerciseID находит упражнение по кнопкам сообщения бота, card - это карточка техники.
+func techniqueExerciseID(message *tgbotapi.Message) (uuid.UUID, bool) {
+	if message == nil || message.ReplyMarkup == nil {
+		return uuid.Nil, false
+	}
+
+	for _, row := range message.ReplyMarkup.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData == nil {
+				continue
+			}
+
+			data := *button.CallbackData
+			switch {
+			case strings.HasPrefix(data, techniqueClosePrefix):
+				if id, err := uuid.Parse(strings.TrimPrefix(data, techniqueClosePrefix)); err == nil {
+					return id, true
+				}
+			case strings.HasPrefix(data, techniquePrefix):
+				if id, err := uuid.Parse(strings.TrimPrefix(data, techniquePrefix)); err == nil {
+					return id, false
+				}
+			}
+		}
+	}
+
+	return uuid.Nil, false
+}
+
+// parseTechniqueText разбирает текст карточки: абзацы через пустую строку - описание,
+// подсказки и частые ошибки, по одной в строке.
+func parseTechniqueText(input string) (string, []string, []string, bool) {
+	paragraphs := strings.Split(strings.ReplaceAll(strings.TrimSpace(input), "\r\n", "\n"), "\n\n")
+	if len(paragraphs) == 0 || len(paragraphs) > 3 {
+		return "", nil, nil, false
+	}
+
+	lines := func(paragraph string) []string {
+		var result []string
+		for _, line := range strings.Split(paragraph, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-•*"))
+			if line != "" {
+				result = append(result, line)
+			}
+		}
+		return result
+	}
+
+	description := strings.TrimSpace(paragraphs[0])
+
+	var cues, mistakes []string
+	if len(paragraphs) > 1 {
+		cues = lines(paragraphs[1])
+	}
+	if len(paragraphs) > 2 {
+		mistakes = lines(paragraphs[2])
+	}
+
+	return description, cues, mistakes, description != ""
+}
+
+func (a *API) formatTechnique(locale i18n.Locale, name string, technique *entity.Technique) string {
+	if technique.IsEmpty() {
+		return a.textf(locale, noTechniqueText, name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(a.textf(locale, techniqueTitleText, name))
+
+	if technique.Description() != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(technique.Description())
+	}
+
+	if len(technique.Cues()) > 0 {
+		sb.WriteString(a.text(locale, techniqueCuesText))
+		for _, cue := range technique.Cues() {
+			sb.WriteString("\n• " + cue)
+		}
+	}
+
+	if len(technique.Mistakes()) > 0 {
+		sb.WriteString(a.text(locale, techniqueMistakesText))
+		for _, mistake := range technique.Mistakes() {
+			sb.WriteString("\n• " + mistake)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,510 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// maxEditableTrainings сколько последних тренировок из выборки /get_trainings можно открыть кнопками.
+const maxEditableTrainings = 20
+
+type trainingEditAction string
+
+const (
+	trainingEditSet     trainingEditAction = "set"
+	trainingEditDate    trainingEditAction = "date"
+	trainingEditDetails trainingEditAction = "details"
+)
+
+// trainingEdit правка завершенной тренировки, ожидающая ввода пользователя.
+type trainingEdit struct {
+	action         trainingEditAction
+	sessionID      uuid.UUID
+	exerciseNumber uint8
+	setNumber      uint8
+}
+
+func (a *API) setTrainingEdit(userID string, edit trainingEdit) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.trainingEdits[userID] = edit
+}
+
+func (a *API) popTrainingEdit(userID string) (trainingEdit, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	edit, ok := a.trainingEdits[userID]
+	delete(a.trainingEdits, userID)
+	return edit, ok
+}
+
+// sendTrainingPicker предлагает открыть одну из найденных тренировок для просмотра и правки.
+func (a *API) sendTrainingPicker(chatID int64, locale i18n.Locale, trainings []entity.TrainingSession) {
+	recent := slices.Clone(trainings)
+	slices.Reverse(recent)
+	if len(recent) > maxEditableTrainings {
+		recent = recent[:maxEditableTrainings]
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, training := range recent {
+		label := a.textf(locale, trainingButtonText, training.Date().Format(time.DateOnly), training.ExerciseCount())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, trainingPrefix+training.ID().String()),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, selectTrainingText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) TrainingHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, trainingPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.GetTrainingSession(a.ctx, userID, sessionID)
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	a.showTraining(callback.Message, locale, session)
+}
+
+func (a *API) TrainingExerciseHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, numbers, err := parseTrainingCallbackData(strings.TrimPrefix(callback.Data, trainingExercisePrefix), 1)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.GetTrainingSession(a.ctx, userID, sessionID)
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	a.showTrainingExercise(callback.Message, locale, session, numbers[0])
+}
+
+func (a *API) TrainingSetHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, numbers, err := parseTrainingCallbackData(strings.TrimPrefix(callback.Data, trainingSetPrefix), 2)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	a.setTrainingEdit(userID, trainingEdit{
+		action:         trainingEditSet,
+		sessionID:      sessionID,
+		exerciseNumber: numbers[0],
+		setNumber:      numbers[1],
+	})
+	a.setUserState(userID, entity.StateAwaitingTrainingEditInput)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.textf(locale, editTrainingSetText, numbers[1])))
+}
+
+func (a *API) TrainingSetDeleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, numbers, err := parseTrainingCallbackData(strings.TrimPrefix(callback.Data, trainingSetDeletePrefix), 2)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.DeleteTrainingSet(a.ctx, userID, sessionID, numbers[0], numbers[1])
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	if _, ok := session.FindExercise(numbers[0]); ok && !session.IsEmpty() {
+		a.showTrainingExercise(callback.Message, locale, session, numbers[0])
+		return
+	}
+
+	a.showTraining(callback.Message, locale, session)
+}
+
+func (a *API) TrainingExerciseDeleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, numbers, err := parseTrainingCallbackData(strings.TrimPrefix(callback.Data, trainingExerciseDeletePrefix), 1)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.DeleteTrainingExercise(a.ctx, userID, sessionID, numbers[0])
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	a.showTraining(callback.Message, locale, session)
+}
+
+func (a *API) TrainingDateHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, trainingDatePrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	a.setTrainingEdit(userID, trainingEdit{action: trainingEditDate, sessionID: sessionID})
+	a.setUserState(userID, entity.StateAwaitingTrainingEditInput)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, editTrainingDateText)))
+}
+
+func (a *API) TrainingDeleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	locale := a.userLocale(callback.From)
+	sessionID := strings.TrimPrefix(callback.Data, trainingDeletePrefix)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerYes), trainingDeleteConfirmPrefix+sessionID),
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, answerNo), trainingPrefix+sessionID),
+	))
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, a.text(locale, confirmDeleteTrainingText), keyboard))
+}
+
+func (a *API) TrainingDeleteConfirmHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, trainingDeleteConfirmPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	if err := a.trainingService.DeleteTrainingSession(a.ctx, userID, sessionID); err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, trainingDeletedText)))
+}
+
+func (a *API) TrainingRateHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, numbers, err := parseTrainingCallbackData(strings.TrimPrefix(callback.Data, trainingRatePrefix), 1)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	session, err := a.trainingService.RateTrainingSession(a.ctx, userID, sessionID, numbers[0])
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	a.showTraining(callback.Message, locale, session)
+}
+
+func (a *API) TrainingDetailsHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	sessionID, err := uuid.Parse(strings.TrimPrefix(callback.Data, trainingDetailsPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	a.setTrainingEdit(userID, trainingEdit{action: trainingEditDetails, sessionID: sessionID})
+	a.setUserState(userID, entity.StateAwaitingTrainingEditInput)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, editTrainingDetailsText)))
+}
+
+// sendTrainingDetailsPrompt после завершения предлагает необязательно оценить тренировку
+// и указать вес тела с заметкой.
+func (a *API) sendTrainingDetailsPrompt(chatID int64, locale i18n.Locale, sessionID uuid.UUID) {
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, trainingDetailsPromptText))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(a.trainingDetailsRows(locale, sessionID.String())...)
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) trainingDetailsRows(locale i18n.Locale, sessionID string) [][]tgbotapi.InlineKeyboardButton {
+	var ratings []tgbotapi.InlineKeyboardButton
+	for rating := entity.MinSessionRating; rating <= entity.MaxSessionRating; rating++ {
+		ratings = append(ratings, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%d⭐", rating), fmt.Sprintf("%s%s:%d", trainingRatePrefix, sessionID, rating)))
+	}
+
+	return [][]tgbotapi.InlineKeyboardButton{
+		ratings,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, trainingDetailsButtonText), trainingDetailsPrefix+sessionID),
+		),
+	}
+}
+
+// TrainingEditInputHandler применяет введенный подход, дату или вес тела с заметкой к завершенной тренировке.
+func (a *API) TrainingEditInputHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	edit, ok := a.popTrainingEdit(userID)
+	a.clearUserState(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, trainingEditExpiredText)))
+		return
+	}
+
+	var (
+		session *entity.TrainingSession
+		err     error
+	)
+
+	switch edit.action {
+	case trainingEditSet:
+		session, err = a.trainingService.UpdateTrainingSet(a.ctx, userID, edit.sessionID, edit.exerciseNumber, edit.setNumber, message.Text)
+	case trainingEditDate:
+		date, parseErr := time.Parse(time.DateOnly, strings.TrimSpace(message.Text))
+		if parseErr != nil {
+			_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInvalidFormat)))
+			return
+		}
+		session, err = a.trainingService.ChangeTrainingDate(a.ctx, userID, edit.sessionID, date)
+	case trainingEditDetails:
+		bodyweight, notes := parseTrainingDetails(message.Text)
+		session, err = a.trainingService.UpdateTrainingDetails(a.ctx, userID, edit.sessionID, bodyweight, notes)
+	}
+
+	if err != nil {
+		a.sendTrainingEditError(chatID, locale, err)
+		return
+	}
+
+	text, markup := a.trainingView(locale, session)
+	msg := tgbotapi.NewMessage(chatID, a.text(locale, trainingUpdatedText)+"\n\n"+text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+func (a *API) showTraining(message *tgbotapi.Message, locale i18n.Locale, session *entity.TrainingSession) {
+	if session.IsEmpty() {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(message.Chat.ID, message.MessageID, a.text(locale, trainingDeletedText)))
+		return
+	}
+
+	text, markup := a.trainingView(locale, session)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(message.Chat.ID, message.MessageID, text, markup))
+}
+
+func (a *API) trainingView(locale i18n.Locale, session *entity.TrainingSession) (string, tgbotapi.InlineKeyboardMarkup) {
+	sessionID := session.ID().String()
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, exercise := range session.Exercises() {
+		label := fmt.Sprintf("✏️ %d. %s", exercise.Number(), exercise.Name())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%s:%d", trainingExercisePrefix, sessionID, exercise.Number())),
+		))
+	}
+
+	rows = append(rows, a.trainingDetailsRows(locale, sessionID)...)
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, changeTrainingDateButtonText), trainingDatePrefix+sessionID),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, deleteTrainingButtonText), trainingDeletePrefix+sessionID),
+		),
+	)
+
+	text := strings.TrimSpace(a.formatTrainingLogs([]entity.TrainingSession{*session}))
+	text += a.sessionCommentsText(locale, session.UserID(), session.ID())
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func (a *API) showTrainingExercise(message *tgbotapi.Message, locale i18n.Locale, session *entity.TrainingSession, exerciseNumber uint8) {
+	exercise, ok := session.FindExercise(exerciseNumber)
+	if !ok {
+		a.showTraining(message, locale, session)
+		return
+	}
+
+	sessionID := session.ID().String()
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, set := range exercise.Sets() {
+		label := a.textf(locale, trainingSetButtonText, set.Number(), formatter.FormatWeightFloat(float64(set.Weight())), set.Reps())
+		if set.Notes() != "" {
+			label += " (" + set.Notes() + ")"
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%s:%d:%d", trainingSetPrefix, sessionID, exerciseNumber, set.Number())),
+			tgbotapi.NewInlineKeyboardButtonData("🗑", fmt.Sprintf("%s%s:%d:%d", trainingSetDeletePrefix, sessionID, exerciseNumber, set.Number())),
+		))
+	}
+
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, deleteTrainingExerciseButtonText), fmt.Sprintf("%s%s:%d", trainingExerciseDeletePrefix, sessionID, exerciseNumber)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, backToTrainingText), trainingPrefix+sessionID),
+		),
+	)
+
+	text := a.textf(locale, trainingExerciseText, session.Date().Format(time.DateOnly), exercise.Number(), exercise.Name())
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(message.Chat.ID, message.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(rows...)))
+}
+
+func (a *API) sendTrainingEditError(chatID int64, locale i18n.Locale, err error) {
+	text := a.text(locale, errEditTraining)
+	switch {
+	case errors.Is(err, errs.ErrSessionNotFound):
+		text = a.text(locale, errTrainingNotFound)
+	case errors.Is(err, errs.ErrInvalidSetFormat):
+		text = a.text(locale, errInvalidSetFormat)
+	case errors.Is(err, errs.ErrInvalidBodyweight), errors.Is(err, errs.ErrInvalidRating):
+		text = a.text(locale, errInvalidFormat)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+func (a *API) formatTrainingsSummary(locale i18n.Locale, summary entity.SessionsSummary) string {
+	text := a.textf(locale, trainingsSummaryText, summary.Sessions)
+	if summary.AvgDuration > 0 {
+		text += a.textf(locale, trainingsAvgDurationText, formatter.FormatDuration(summary.AvgDuration))
+	}
+	if summary.AvgRating > 0 {
+		text += a.textf(locale, trainingsAvgRatingText, summary.AvgRating)
+	}
+	if summary.LastBodyweight > 0 {
+		text += a.textf(locale, trainingsBodyweightText,
+			formatter.FormatWeightFloat(float64(summary.FirstBodyweight)), formatter.FormatWeightFloat(float64(summary.LastBodyweight)))
+	}
+
+	return text
+}
+
+// formatTrainingLogs выводит тренировки с названиями залов их владельцев.
+func (a *API) formatTrainingLogs(sessions []entity.TrainingSession) string {
+	gymNames := make(map[uuid.UUID]string)
+	loaded := make(map[string]bool)
+
+	for _, session := range sessions {
+		if session.GymID() == uuid.Nil || loaded[session.UserID()] {
+			continue
+		}
+		loaded[session.UserID()] = true
+
+		gyms, err := a.trainingService.GetGyms(a.ctx, session.UserID())
+		if err != nil {
+			continue
+		}
+		for _, gym := range gyms {
+			gymNames[gym.ID()] = gym.Name()
+		}
+	}
+
+	return a.formatter.FormatTrainingLogs(sessions, gymNames)
+}
+
+// parseTrainingDetails берет вес тела из первой строки, если она число, остальной текст - заметка.
+// Не указанное в ответе возвращается как nil, чтобы не затереть сохраненное значение.
+func parseTrainingDetails(input string) (*float32, *string) {
+	input = strings.TrimSpace(input)
+	first, rest, _ := strings.Cut(input, "\n")
+
+	weight := strings.TrimSpace(first)
+	for _, unit := range []string{"кг", "kg"} {
+		weight = strings.TrimSpace(strings.TrimSuffix(weight, unit))
+	}
+
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(weight, ",", "."), 32)
+	if err != nil {
+		return nil, &input
+	}
+
+	bodyweight := float32(parsed)
+	if rest = strings.TrimSpace(rest); rest == "" {
+		return &bodyweight, nil
+	}
+
+	return &bodyweight, &rest
+}
+
+// parseTrainingCallbackData разбирает "<session_id>:<n1>[:<n2>]" с count порядковыми номерами.
+func parseTrainingCallbackData(data string, count int) (uuid.UUID, []uint8, error) {
+	parts := strings.Split(data, ":")
+	if len(parts) != count+1 {
+		return uuid.Nil, nil, fmt.Errorf("invalid training callback data: %s", data)
+	}
+
+	sessionID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	numbers := make([]uint8, 0, count)
+	for _, part := range parts[1:] {
+		number, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		numbers = append(numbers, uint8(number))
+	}
+
+	return sessionID, numbers, nil
+}
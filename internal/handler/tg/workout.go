@@ -0,0 +1,330 @@
+package tg
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/formatter"
+	"gymnote/internal/i18n"
+)
+
+// workoutDraft планы тренера, ожидающие выбора спортсменов.
+type workoutDraft struct {
+	plans    []entity.WorkoutPlan
+	selected []string
+}
+
+func (a *API) setWorkoutDraft(userID string, draft workoutDraft) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.workoutDrafts[userID] = draft
+}
+
+func (a *API) getWorkoutDraft(userID string) (workoutDraft, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	draft, ok := a.workoutDrafts[userID]
+	return draft, ok
+}
+
+func (a *API) popWorkoutDraft(userID string) (workoutDraft, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	draft, ok := a.workoutDrafts[userID]
+	delete(a.workoutDrafts, userID)
+	return draft, ok
+}
+
+// RunWorkoutDelivery периодически присылает спортсменам тренировки, назначенные на сегодня.
+func (a *API) RunWorkoutDelivery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.deliverDueWorkouts()
+		}
+	}
+}
+
+func (a *API) deliverDueWorkouts() {
+	workouts, err := a.trainingService.DeliverDueWorkouts(a.ctx)
+	if err != nil {
+		log.Printf("Assigned workouts delivery error: %v", err)
+		return
+	}
+
+	for _, workout := range workouts {
+		chatID, err := strconv.ParseInt(workout.AthleteID(), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		locale := a.userLocale(&tgbotapi.User{ID: chatID})
+		text := a.textf(locale, workoutDeliveredText, workout.CoachName(), workout.Date().Format(time.DateOnly), formatWorkoutPlan(workout.Exercises()))
+
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(a.text(locale, workoutStartButtonText), workoutStartPrefix+workout.ID().String()),
+		))
+
+		_, _ = a.bot.Send(msg)
+	}
+}
+
+// WorkoutPlanHandler просит тренера прислать план тренировки.
+func (a *API) WorkoutPlanHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	a.setUserState(userID, entity.StateAwaitingWorkoutPlan)
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, workoutPlanPromptText)))
+}
+
+func (a *API) WorkoutPlanInputHandler(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := strconv.FormatInt(message.From.ID, 10)
+	locale := a.userLocale(message.From)
+
+	defer a.clearUserState(userID)
+
+	plans, err := a.trainingService.ParseWorkoutPlans(a.ctx, userID, message.Text)
+	if err != nil {
+		a.sendWorkoutError(chatID, locale, err)
+		return
+	}
+
+	draft := workoutDraft{plans: plans}
+	a.setWorkoutDraft(userID, draft)
+
+	text, markup, err := a.workoutAthletesView(locale, userID, draft)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCoach)))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+
+	_, _ = a.bot.Send(msg)
+}
+
+// WorkoutAthleteHandler отмечает или снимает спортсмена, которому назначается план.
+func (a *API) WorkoutAthleteHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+	athleteID := strings.TrimPrefix(callback.Data, workoutAthletePrefix)
+
+	draft, ok := a.getWorkoutDraft(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, workoutDraftExpiredText)))
+		return
+	}
+
+	if idx := slices.Index(draft.selected, athleteID); idx >= 0 {
+		draft.selected = slices.Delete(draft.selected, idx, idx+1)
+	} else {
+		draft.selected = append(draft.selected, athleteID)
+	}
+	a.setWorkoutDraft(userID, draft)
+
+	text, markup, err := a.workoutAthletesView(locale, userID, draft)
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errCoach)))
+		return
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, markup))
+}
+
+func (a *API) WorkoutAssignHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	draft, ok := a.getWorkoutDraft(userID)
+	if !ok {
+		_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.text(locale, workoutDraftExpiredText)))
+		return
+	}
+
+	workouts, err := a.trainingService.AssignWorkouts(a.ctx, userID, draft.plans, draft.selected)
+	if err != nil {
+		a.sendWorkoutError(chatID, locale, err)
+		return
+	}
+
+	a.popWorkoutDraft(userID)
+	_, _ = a.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, a.textf(locale, workoutAssignedText, len(workouts))))
+
+	a.deliverDueWorkouts()
+}
+
+func (a *API) workoutAthletesView(locale i18n.Locale, userID string, draft workoutDraft) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	links, err := a.trainingService.GetCoachLinks(a.ctx, userID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	var sb strings.Builder
+	for _, plan := range draft.plans {
+		sb.WriteString(a.textf(locale, workoutPlanDateText, plan.Date.Format(time.DateOnly)))
+		sb.WriteString(formatWorkoutPlan(plan.Exercises))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(a.text(locale, workoutSelectAthletesText))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, link := range links {
+		if link.CoachID() != userID {
+			continue
+		}
+
+		label := link.AthleteName()
+		if slices.Contains(draft.selected, link.AthleteID()) {
+			label = "✅ " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, workoutAthletePrefix+link.AthleteID()),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(a.text(locale, workoutAssignButtonText), workoutAssignPrefix),
+	))
+
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// WorkoutStartHandler начинает тренировку по плану тренера.
+func (a *API) WorkoutStartHandler(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	userID := strconv.FormatInt(callback.From.ID, 10)
+	locale := a.userLocale(callback.From)
+
+	workoutID, err := uuid.Parse(strings.TrimPrefix(callback.Data, workoutStartPrefix))
+	if err != nil {
+		_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, errInternal)))
+		return
+	}
+
+	if _, err := a.trainingService.StartAssignedWorkout(a.ctx, userID, workoutID); err != nil {
+		a.sendWorkoutError(chatID, locale, err)
+		return
+	}
+
+	a.setUserState(userID, entity.StateAwaitingSetInput)
+	a.refreshDashboard(chatID, locale, userID)
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, a.text(locale, workoutStartedText)))
+}
+
+// notifyCoachAboutWorkout присылает тренеру сравнение плана с выполненной тренировкой.
+func (a *API) notifyCoachAboutWorkout(session *entity.TrainingSession) {
+	if session.AssignmentID() == uuid.Nil || session.IsEmpty() {
+		return
+	}
+
+	workout, err := a.trainingService.GetAssignedWorkout(a.ctx, session.UserID(), session.AssignmentID())
+	if err != nil {
+		log.Printf("Error getting assigned workout '%s': %v", session.AssignmentID(), err)
+		return
+	}
+
+	athleteName := session.UserID()
+	if links, err := a.trainingService.GetCoachLinks(a.ctx, session.UserID()); err == nil {
+		for _, link := range links {
+			if link.CoachID() == workout.CoachID() {
+				athleteName = link.AthleteName()
+			}
+		}
+	}
+
+	a.notifyUser(workout.CoachID(), func(locale i18n.Locale) string {
+		return a.textf(locale, workoutComparisonText, athleteName, workout.Date().Format(time.DateOnly)) +
+			a.formatWorkoutComparison(locale, workout.Compare(*session))
+	})
+}
+
+func (a *API) formatWorkoutComparison(locale i18n.Locale, comparisons []entity.ExerciseComparison) string {
+	var sb strings.Builder
+	for i, exercise := range comparisons {
+		sb.WriteString(fmt.Sprintf("\n%d. %s\n", i+1, exercise.Name))
+		for j, set := range exercise.Sets {
+			planned, actual := "—", "—"
+			mark := "❌"
+			if set.Planned != nil {
+				planned = formatSetValue(set.Planned.Weight, set.Planned.Reps)
+			}
+			if set.Actual != nil {
+				actual = formatSetValue(set.Actual.Weight(), set.Actual.Reps())
+				mark = "✅"
+				if set.Planned == nil {
+					mark = "➕"
+				} else if set.Actual.Weight() < set.Planned.Weight || set.Actual.Reps() < set.Planned.Reps {
+					mark = "⚠️"
+				}
+			}
+			sb.WriteString(a.textf(locale, workoutComparisonSetText, j+1, planned, actual, mark))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatWorkoutPlan записывает план в формате /upload_training.
+func formatWorkoutPlan(exercises []entity.PlannedExercise) string {
+	var sb strings.Builder
+	for i, exercise := range exercises {
+		sets := make([]string, 0, len(exercise.Sets))
+		for _, set := range exercise.Sets {
+			sets = append(sets, formatSetValue(set.Weight, set.Reps))
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s - %s\n", i+1, exercise.Name, strings.Join(sets, "; ")))
+	}
+
+	return sb.String()
+}
+
+func formatSetValue(weight float32, reps uint8) string {
+	return formatter.FormatWeightFloat(float64(weight)) + "×" + strconv.Itoa(int(reps))
+}
+
+func (a *API) sendWorkoutError(chatID int64, locale i18n.Locale, err error) {
+	text := a.textf(locale, errGeneral, err)
+	switch {
+	case errors.Is(err, errs.ErrInvalidSetFormat):
+		text = a.text(locale, errWorkoutPlanFormat)
+	case errors.Is(err, errs.ErrWorkoutDateInPast):
+		text = a.text(locale, errWorkoutDateInPast)
+	case errors.Is(err, errs.ErrNoAthletesSelected):
+		text = a.text(locale, errNoAthletesSelected)
+	case errors.Is(err, errs.ErrCoachLinkNotFound):
+		text = a.text(locale, errWorkoutAthleteNotLinked)
+	case errors.Is(err, errs.ErrAssignedWorkoutNotFound):
+		text = a.text(locale, errAssignedWorkoutNotFound)
+	case errors.Is(err, errs.ErrWorkoutCompleted):
+		text = a.text(locale, errWorkoutCompleted)
+	case errors.Is(err, errs.ErrTrainingStarted):
+		text = a.text(locale, errWorkoutTrainingStarted)
+	}
+
+	_, _ = a.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
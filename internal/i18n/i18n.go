@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+
+	DefaultLocale = LocaleRU
+)
+
+var SupportedLocales = []Locale{LocaleRU, LocaleEN}
+
+// ParseLocale приводит language_code из Telegram (ru, en-US, en-GB) к поддерживаемой локали.
+func ParseLocale(code string) (Locale, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if idx := strings.IndexAny(code, "-_"); idx > 0 {
+		code = code[:idx]
+	}
+
+	for _, locale := range SupportedLocales {
+		if string(locale) == code {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+// ResolveLocale выбирает локаль пользователя: ручная настройка важнее языка клиента Telegram.
+func ResolveLocale(override, languageCode string) Locale {
+	if locale, ok := ParseLocale(override); ok {
+		return locale
+	}
+
+	if locale, ok := ParseLocale(languageCode); ok {
+		return locale
+	}
+
+	return DefaultLocale
+}
+
+type Catalog map[Locale]map[string]string
+
+func (c Catalog) Text(locale Locale, key string) string {
+	if text, ok := c[locale][key]; ok {
+		return text
+	}
+
+	if text, ok := c[DefaultLocale][key]; ok {
+		return text
+	}
+
+	return key
+}
+
+func (c Catalog) Textf(locale Locale, key string, args ...any) string {
+	return fmt.Sprintf(c.Text(locale, key), args...)
+}
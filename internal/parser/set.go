@@ -0,0 +1,264 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gymnote/internal/helper"
+)
+
+const (
+	bodyweight       = 1
+	maxRepeatedSets  = 20
+	timesSeparator   = "x"
+	weightSeparator  = "@"
+	pyramidSeparator = "/"
+)
+
+var timesReplacer = strings.NewReplacer("×", timesSeparator, "х", timesSeparator, "Х", timesSeparator, "X", timesSeparator, " ", "")
+
+// ParseSets разбирает список подходов, разделённых ";". Каждый элемент может быть
+// записан сокращённо и развернётся в несколько подходов:
+//
+//	50,10          - один подход
+//	12             - повторения с собственным весом
+//	50x10 / 50×10  - один подход
+//	50x10x3        - 3 подхода по 50x10
+//	50,10 x3       - 3 подхода по 50,10
+//	3×8 @ 60       - 3 подхода по 8 повторений с весом 60
+//	60/70/80 x 8/6/4 - пирамида: 60x8, 70x6, 80x4
+//
+// Заметка в скобках относится ко всем подходам элемента и может стоять перед множителем:
+// "50,10 (легко) x3".
+func (p *parser) ParseSets(s string) ([]Set, error) {
+	var sets []Set
+
+	for _, setData := range strings.Split(s, ";") {
+		setData = strings.TrimSpace(setData)
+		if setData == "" {
+			continue
+		}
+
+		parsed, err := p.parseSet(setData)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, parsed...)
+	}
+
+	if len(sets) == 0 {
+		return nil, errors.New("no sets to process")
+	}
+
+	return sets, nil
+}
+
+func (p *parser) parseSet(setData string) ([]Set, error) {
+	var notes string
+
+	if strings.Contains(setData, "(") {
+		start := strings.Index(setData, "(")
+		end := strings.Index(setData, ")")
+		if start == -1 || end == -1 || end <= start {
+			return nil, errors.New("invalid set notes format")
+		}
+		notes = strings.TrimSpace(setData[start+1 : end])
+
+		// после заметки может идти множитель: "50,10 (легко) x3"
+		suffix := setData[end+1:]
+		if strings.ContainsAny(suffix, "()") {
+			return nil, errors.New("invalid set notes format")
+		}
+		setData = strings.TrimSpace(setData[:start] + suffix)
+	}
+
+	setData = timesReplacer.Replace(setData)
+
+	var (
+		sets []Set
+		err  error
+	)
+
+	switch {
+	case strings.Contains(setData, weightSeparator):
+		sets, err = p.parseSetsAtWeight(setData)
+	case strings.Contains(setData, ","):
+		sets, err = p.parseRepeatedSet(setData)
+	case strings.Contains(setData, timesSeparator):
+		sets, err = p.parseTimesSet(setData)
+	default:
+		var reps uint8
+		reps, err = parseReps(setData)
+		sets = []Set{{Weight: bodyweight, Reps: reps}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	difficulty := p.ParseDifficulty(notes)
+	for i := range sets {
+		sets[i].Notes = notes
+		sets[i].Difficulty = difficulty
+	}
+
+	return sets, nil
+}
+
+// 3x8@60
+func (p *parser) parseSetsAtWeight(setData string) ([]Set, error) {
+	parts := strings.Split(setData, weightSeparator)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid set format")
+	}
+
+	setsAndReps := strings.Split(parts[0], timesSeparator)
+	if len(setsAndReps) != 2 {
+		return nil, errors.New("invalid set format")
+	}
+
+	count, err := parseSetCount(setsAndReps[0])
+	if err != nil {
+		return nil, err
+	}
+
+	reps, err := parseReps(setsAndReps[1])
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := parseWeight(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return repeatSet(Set{Weight: weight, Reps: reps}, count), nil
+}
+
+// 50,10 или 50,10x3
+func (p *parser) parseRepeatedSet(setData string) ([]Set, error) {
+	parts := strings.Split(setData, timesSeparator)
+	if len(parts) > 2 {
+		return nil, errors.New("invalid set format")
+	}
+
+	fields := strings.Split(parts[0], ",")
+	if len(fields) != 2 {
+		return nil, errors.New("invalid set format")
+	}
+
+	weight, err := parseWeight(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	reps, err := parseReps(fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	count := uint8(1)
+	if len(parts) == 2 {
+		count, err = parseSetCount(parts[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return repeatSet(Set{Weight: weight, Reps: reps}, count), nil
+}
+
+// 50x10, 50x10x3 или 60/70/80x8/6/4
+func (p *parser) parseTimesSet(setData string) ([]Set, error) {
+	parts := strings.Split(setData, timesSeparator)
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, errors.New("invalid set format")
+	}
+
+	weightsData := strings.Split(parts[0], pyramidSeparator)
+	repsData := strings.Split(parts[1], pyramidSeparator)
+
+	if len(parts) == 3 {
+		if len(weightsData) != 1 || len(repsData) != 1 {
+			return nil, errors.New("invalid set format: pyramid can't be repeated")
+		}
+
+		count, err := parseSetCount(parts[2])
+		if err != nil {
+			return nil, err
+		}
+
+		weight, err := parseWeight(weightsData[0])
+		if err != nil {
+			return nil, err
+		}
+
+		reps, err := parseReps(repsData[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return repeatSet(Set{Weight: weight, Reps: reps}, count), nil
+	}
+
+	total := max(len(weightsData), len(repsData))
+	if (len(weightsData) != total && len(weightsData) != 1) || (len(repsData) != total && len(repsData) != 1) {
+		return nil, errors.New("invalid set format: weights and reps count mismatch")
+	}
+	if total > maxRepeatedSets {
+		return nil, fmt.Errorf("invalid set format: more than %d sets", maxRepeatedSets)
+	}
+
+	sets := make([]Set, 0, total)
+	for i := range total {
+		weight, err := parseWeight(weightsData[min(i, len(weightsData)-1)])
+		if err != nil {
+			return nil, err
+		}
+
+		reps, err := parseReps(repsData[min(i, len(repsData)-1)])
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, Set{Weight: weight, Reps: reps})
+	}
+
+	return sets, nil
+}
+
+func repeatSet(set Set, count uint8) []Set {
+	sets := make([]Set, 0, count)
+	for range count {
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+func parseWeight(s string) (float32, error) {
+	weight, err := helper.ParseFloat32(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid weight format: %w", err)
+	}
+	return weight, nil
+}
+
+func parseReps(s string) (uint8, error) {
+	reps, err := helper.ParseUint8(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reps format: %w", err)
+	}
+	return reps, nil
+}
+
+func parseSetCount(s string) (uint8, error) {
+	count, err := helper.ParseUint8(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sets count format: %w", err)
+	}
+	if count == 0 || count > maxRepeatedSets {
+		return 0, fmt.Errorf("invalid sets count: must be from 1 to %d", maxRepeatedSets)
+	}
+	return count, nil
+}
@@ -6,18 +6,36 @@ import (
 	"strings"
 	"time"
 
-	"gymnote/internal/helper"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
 )
 
 const (
 	DifficultyEasy   = "легко"
 	DifficultyMedium = "средне"
 	DifficultyHard   = "тяжело"
+	DifficultyNone   = "-"
 )
 
+var difficulties = []string{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
+// difficultyKeywords - слова, по которым в заметке узнаётся сложность подхода.
+// В базе сложность всегда хранится русской константой, независимо от языка заметки.
+var difficultyKeywords = map[i18n.Locale]map[string]string{
+	i18n.LocaleRU: {DifficultyEasy: "легко", DifficultyMedium: "средне", DifficultyHard: "тяжело"},
+	i18n.LocaleEN: {DifficultyEasy: "easy", DifficultyMedium: "medium", DifficultyHard: "hard"},
+}
+
+type Training struct {
+	Date      time.Time
+	Exercises []Exercise
+}
+
 type Exercise struct {
 	Name string
 	Sets []Set
+	// Line номер строки упражнения в загруженном тексте
+	Line int
 }
 
 type Set struct {
@@ -27,6 +45,21 @@ type Set struct {
 	Notes      string
 }
 
+// LineError ошибка в строке загруженного текста, Text - строка или ее часть, к которой она относится.
+type LineError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d %q: %v", e.Line, e.Text, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
 type parser struct{}
 
 func New() *parser {
@@ -42,40 +75,53 @@ func New() *parser {
 // 5. Жим гантелей лежа - 25,10 (нормально); 25,10 (нормально)
 // 6. Разводки гантелей лежа - 15,12 (средне)
 // 7. Разгибание в блоке на трицепс - 42,12 (легко); 50,12 (легко); 50,12 (на коленях, средне)
-
-func (p *parser) ParseExercises(s string) ([]Exercise, time.Time, error) {
+// 8. Жим стоя - 40x10x3; 3×8 @ 45 (тяжело); 30/35/40 x 12/10/8
+//
+// 2024-02-17
+// 1. Присед - 100,5; 100,5; 100,5
+//
+// Каждая строка с датой начинает новую тренировку. Упражнения до первой даты
+// относятся к тренировке с сегодняшней датой, даты без упражнений пропускаются.
+// Строки, начинающиеся с #, - комментарии, например метаданные из /get_trainings.
+
+func (p *parser) ParseTrainings(s string) ([]Training, error) {
 	lines := strings.Split(s, "\n")
-	if len(lines) == 0 {
-		return nil, time.Time{}, errors.New("no data to process")
-	}
-
-	var date time.Time
-	firstLine := strings.TrimSpace(lines[0])
-	parsedTime, ok := p.isValidDate(firstLine)
-	if ok {
-		date = parsedTime
-		lines = lines[1:]
-	} else {
-		date = time.Now()
-	}
 
-	var exercises []Exercise
+	var trainings []Training
+	current := Training{Date: time.Now()}
 
-	for _, line := range lines {
+	for lineIDX, line := range lines {
 		line = strings.TrimSpace(line)
-		if line == "" {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if date, ok := p.isValidDate(line); ok {
+			if len(current.Exercises) > 0 {
+				trainings = append(trainings, current)
+			}
+			current = Training{Date: date}
 			continue
 		}
 
 		exs, err := p.parseExercise(line)
 		if err != nil {
-			return nil, time.Time{}, fmt.Errorf("parse exercise error: %w", err)
+			return nil, &LineError{Line: lineIDX + 1, Text: line, Err: err}
 		}
+		exs.Line = lineIDX + 1
 
-		exercises = append(exercises, exs)
+		current.Exercises = append(current.Exercises, exs)
 	}
 
-	return exercises, date, nil
+	if len(current.Exercises) > 0 {
+		trainings = append(trainings, current)
+	}
+
+	if len(trainings) == 0 {
+		return nil, errs.ErrNoTrainingData
+	}
+
+	return trainings, nil
 }
 
 func (p *parser) parseExercise(line string) (Exercise, error) {
@@ -86,19 +132,13 @@ func (p *parser) parseExercise(line string) (Exercise, error) {
 	}
 
 	nameParts := strings.SplitN(strings.TrimSpace(parts[0]), ".", 2)
+	if len(nameParts) != 2 {
+		return exs, errors.New("invalid exercise number format")
+	}
 	exerciseName := strings.TrimSpace(nameParts[1])
-	setsData := strings.Split(parts[1], ";")
-
-	var sets []Set
-	for _, setData := range setsData {
-		if setData == "" {
-			continue
-		}
-		set, err := p.parseSet(strings.TrimSpace(setData))
-		if err != nil {
-			return exs, err
-		}
-		sets = append(sets, set)
+	sets, err := p.ParseSets(parts[1])
+	if err != nil {
+		return exs, err
 	}
 
 	exs.Name = exerciseName
@@ -107,65 +147,18 @@ func (p *parser) parseExercise(line string) (Exercise, error) {
 	return exs, nil
 }
 
-func (p *parser) parseSet(setData string) (Set, error) {
-	set := Set{}
-
-	if strings.Contains(setData, "(") {
-		start := strings.Index(setData, "(")
-		end := strings.Index(setData, ")")
-		if start == -1 || end == -1 || end <= start {
-			return set, errors.New("invalid set notes format")
-		}
-		set.Notes = strings.TrimSpace(setData[start+1 : end])
-		setData = strings.TrimSpace(setData[:start])
-	}
-
-	fields := strings.Split(setData, ",")
-	if len(fields) == 1 {
-		reps, err := helper.ParseUint8(strings.TrimSpace(fields[0]))
-		if err != nil {
-			return set, fmt.Errorf("invalid reps format: %w", err)
-		}
-		set.Weight = 1
-		set.Reps = reps
-	} else if len(fields) == 2 {
-		weight, err := helper.ParseFloat32(strings.TrimSpace(fields[0]))
-		if err != nil {
-			return set, fmt.Errorf("invalid weight format: %w", err)
-		}
-
-		reps, err := helper.ParseUint8(strings.TrimSpace(fields[1]))
-		if err != nil {
-			return set, fmt.Errorf("invalid reps format: %w", err)
-		}
-
-		set.Weight = weight
-		set.Reps = reps
-	} else {
-		return set, errors.New("invalid set format")
-	}
-
-	set.Difficulty = p.ParseDifficulty(set.Notes)
-
-	return set, nil
-}
-
 func (p *parser) ParseDifficulty(notes string) string {
-	var difficulty string
-
 	n := strings.ToLower(notes)
 
-	if strings.Contains(n, DifficultyEasy) {
-		difficulty = DifficultyEasy
-	} else if strings.Contains(n, DifficultyMedium) {
-		difficulty = DifficultyMedium
-	} else if strings.Contains(n, DifficultyHard) {
-		difficulty = DifficultyHard
-	} else {
-		difficulty = "-"
+	for _, difficulty := range difficulties {
+		for _, locale := range i18n.SupportedLocales {
+			if keyword := difficultyKeywords[locale][difficulty]; keyword != "" && strings.Contains(n, keyword) {
+				return difficulty
+			}
+		}
 	}
 
-	return difficulty
+	return DifficultyNone
 }
 
 func (p *parser) isValidDate(dateStr string) (time.Time, bool) {
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"gymnote/internal/entity"
 )
 
@@ -10,5 +12,10 @@ type Cache interface {
 	Close(_ context.Context) error
 	SaveSession(ctx context.Context, session *entity.TrainingSession) error
 	GetSession(ctx context.Context, userID string) (*entity.TrainingSession, error)
-	DeleteSession(ctx context.Context, userID string) error
+	DeleteSession(ctx context.Context, session *entity.TrainingSession) error
+	GetSessions(ctx context.Context) ([]*entity.TrainingSession, error)
+	CreateSharedSession(ctx context.Context, shared *entity.SharedSession, session *entity.TrainingSession) error
+	GetSharedSession(ctx context.Context, id uuid.UUID) (*entity.SharedSession, error)
+	SaveSharedSession(ctx context.Context, shared *entity.SharedSession) error
+	JoinSharedSession(ctx context.Context, shared *entity.SharedSession, userID string) error
 }
@@ -13,13 +13,54 @@ type DB interface {
 	Close(context.Context) error
 
 	InsertExercise(ctx context.Context, req entity.Exercise) error
-	GetExerciseByName(ctx context.Context, req string) (entity.Exercise, error)
+	GetExerciseByName(ctx context.Context, userID string, name string) (entity.Exercise, error)
 	GetExerciseByID(ctx context.Context, req uuid.UUID) (entity.Exercise, error)
-	GetExercisesByMuscleGroup(ctx context.Context, muscleGroup string) ([]entity.Exercise, error)
+	GetExercisesByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Exercise, error)
+	GetExercisesByMuscleGroup(ctx context.Context, userID string, muscleGroup string) ([]entity.Exercise, error)
+	GetPrivateExercisesByName(ctx context.Context, name string) ([]entity.Exercise, error)
+	UpdateExerciseOwner(ctx context.Context, id uuid.UUID, ownerID string) error
+	SearchExercises(ctx context.Context, userID string, query string, preferred []uuid.UUID, limit int64) ([]entity.Exercise, error)
+	GetUsedExerciseIDs(ctx context.Context, userID string) ([]uuid.UUID, error)
+	UpdateExercise(ctx context.Context, req entity.Exercise) error
+	MergeExercises(ctx context.Context, source, target entity.Exercise) error
+	InsertExerciseAudit(ctx context.Context, req entity.ExerciseAudit) error
+	GetTechnique(ctx context.Context, exerciseID uuid.UUID) (entity.Technique, error)
+	SaveTechnique(ctx context.Context, req entity.Technique) error
 
-	InsertTrainingLogs(ctx context.Context, req entity.TrainingSession) error
-	GetExerciseProgression(ctx context.Context, userID string, exerciseID uuid.UUID, fromDate, toDate time.Time) ([]entity.ExerciseProgression, error)
-	GetLastSetsForExercise(ctx context.Context, userID string, exerciseID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error)
-	InsertTrainingSession(ctx context.Context, req entity.TrainingSession) error
+	GetMuscleGroups(ctx context.Context) ([]entity.MuscleGroup, error)
+
+	InsertGym(ctx context.Context, req entity.Gym) error
+	GetGyms(ctx context.Context, userID string) ([]entity.Gym, error)
+	GetGymByID(ctx context.Context, userID string, id uuid.UUID) (entity.Gym, error)
+	DeleteGym(ctx context.Context, userID string, id uuid.UUID) error
+	GetEquipmentTypes(ctx context.Context) ([]string, error)
+
+	GetExerciseProgression(ctx context.Context, userID string, exerciseID, gymID uuid.UUID, fromDate, toDate time.Time) ([]entity.ExerciseProgression, error)
+	GetLastSetsForExercise(ctx context.Context, userID string, exerciseID, gymID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error)
+	GetExerciseUsage(ctx context.Context, userID string, exerciseIDs []uuid.UUID) (map[uuid.UUID]entity.ExerciseUsage, error)
+	UpdateExerciseWithLogs(ctx context.Context, req entity.Exercise) error
+	SaveFinishedSession(ctx context.Context, req entity.TrainingSession) error
 	GetTrainingSessions(ctx context.Context, userID string, fromDate, toDate time.Time) ([]entity.TrainingSession, error)
+	GetTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) (entity.TrainingSession, error)
+	GetTrainingSessionByID(ctx context.Context, sessionID uuid.UUID) (entity.TrainingSession, error)
+	UpdateTrainingSession(ctx context.Context, req entity.TrainingSession) error
+	UpdateTrainingSessionDetails(ctx context.Context, req entity.TrainingSession) error
+	DeleteTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) error
+
+	GetUserSettings(ctx context.Context, userID string) (entity.UserSettings, error)
+	SaveUserSettings(ctx context.Context, req entity.UserSettings) error
+
+	SaveCoachLink(ctx context.Context, req entity.CoachLink) error
+	GetCoachLink(ctx context.Context, id uuid.UUID) (entity.CoachLink, error)
+	GetActiveCoachLink(ctx context.Context, coachID, athleteID string) (entity.CoachLink, error)
+	GetActiveCoachLinks(ctx context.Context, userID string) ([]entity.CoachLink, error)
+	InsertSessionComment(ctx context.Context, req entity.SessionComment) error
+	GetSessionComments(ctx context.Context, sessionID uuid.UUID) ([]entity.SessionComment, error)
+	SaveAssignedWorkout(ctx context.Context, req entity.AssignedWorkout) error
+	InsertAssignedWorkouts(ctx context.Context, workouts []entity.AssignedWorkout) error
+	GetAssignedWorkout(ctx context.Context, id uuid.UUID) (entity.AssignedWorkout, error)
+	GetUndeliveredWorkouts(ctx context.Context, until time.Time) ([]entity.AssignedWorkout, error)
+	SaveGroup(ctx context.Context, req entity.Group) error
+	GetGroup(ctx context.Context, chatID int64) (entity.Group, error)
+	GetMemberGroups(ctx context.Context, userID string) ([]entity.Group, error)
 }
@@ -0,0 +1,184 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) SaveCoachLink(ctx context.Context, req entity.CoachLink) error {
+	row := NewCoachLinkRow(WithCoachLinkRowRestoreSpec(CoachLinkRowRestoreSpecification{
+		ID:          req.ID().String(),
+		CoachID:     req.CoachID(),
+		CoachName:   req.CoachName(),
+		AthleteID:   req.AthleteID(),
+		AthleteName: req.AthleteName(),
+		Status:      string(req.Status()),
+		CreatedAt:   req.CreatedAt(),
+		AcceptedAt:  req.AcceptedAt(),
+		RevokedAt:   req.RevokedAt(),
+	}))
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.coachColl.ReplaceOne(ctx, bson.M{"id": row.ID}, row, opts); err != nil {
+		return fmt.Errorf("failed to save coach link: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) GetCoachLink(ctx context.Context, id uuid.UUID) (entity.CoachLink, error) {
+	var row CoachLinkRow
+
+	err := m.coachColl.FindOne(ctx, bson.M{"id": id.String()}).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.CoachLink{}, errs.ErrCoachLinkNotFound
+		}
+		return entity.CoachLink{}, fmt.Errorf("failed to get coach link: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+// GetActiveCoachLink возвращает действующую связь тренера со спортсменом.
+func (m *mongodb) GetActiveCoachLink(ctx context.Context, coachID, athleteID string) (entity.CoachLink, error) {
+	var row CoachLinkRow
+	filter := bson.M{"coach_id": coachID, "athlete_id": athleteID, "status": string(entity.CoachLinkActive)}
+
+	err := m.coachColl.FindOne(ctx, filter).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.CoachLink{}, errs.ErrCoachLinkNotFound
+		}
+		return entity.CoachLink{}, fmt.Errorf("failed to get coach link: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+// GetActiveCoachLinks возвращает действующие связи, где пользователь тренер или спортсмен.
+func (m *mongodb) GetActiveCoachLinks(ctx context.Context, userID string) ([]entity.CoachLink, error) {
+	filter := bson.M{
+		"status": string(entity.CoachLinkActive),
+		"$or":    bson.A{bson.M{"coach_id": userID}, bson.M{"athlete_id": userID}},
+	}
+	opts := options.Find().SetSort(bson.M{"accepted_at": 1})
+
+	cursor, err := m.coachColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coach links: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var links []entity.CoachLink
+	for cursor.Next(ctx) {
+		var row CoachLinkRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		links = append(links, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return links, nil
+}
+
+func (m *mongodb) InsertSessionComment(ctx context.Context, req entity.SessionComment) error {
+	row := NewSessionCommentRow(WithSessionCommentRowRestoreSpec(SessionCommentRowRestoreSpecification{
+		ID:        req.ID().String(),
+		SessionID: req.SessionID().String(),
+		AthleteID: req.AthleteID(),
+		CoachID:   req.CoachID(),
+		CoachName: req.CoachName(),
+		Text:      req.Text(),
+		CreatedAt: req.CreatedAt(),
+	}))
+
+	if _, err := m.commentColl.InsertOne(ctx, row); err != nil {
+		return fmt.Errorf("failed to insert session comment: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) GetSessionComments(ctx context.Context, sessionID uuid.UUID) ([]entity.SessionComment, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := m.commentColl.Find(ctx, bson.M{"session_id": sessionID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session comments: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var comments []entity.SessionComment
+	for cursor.Next(ctx) {
+		var row SessionCommentRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		comments = append(comments, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (m *mongodb) ensureCoachIndexes(ctx context.Context) error {
+	if _, err := m.coachColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "coach_id", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys:    bson.D{{Key: "athlete_id", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.commentColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "session_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
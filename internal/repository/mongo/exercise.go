@@ -21,7 +21,9 @@ func (m *mongodb) InsertExercise(ctx context.Context, req entity.Exercise) error
 		CreatedAt:   req.CreatedAt(),
 		Name:        req.Name(),
 		MuscleGroup: req.MuscleGroup(),
+		Secondary:   req.SecondaryMuscles(),
 		Equipment:   req.Equipment(),
+		OwnerID:     req.OwnerID(),
 	}))
 
 	_, err := m.exerciseColl.InsertOne(ctx, row)
@@ -32,11 +34,14 @@ func (m *mongodb) InsertExercise(ctx context.Context, req entity.Exercise) error
 	return nil
 }
 
-func (m *mongodb) GetExerciseByName(ctx context.Context, name string) (entity.Exercise, error) {
+// GetExerciseByName ищет упражнение среди общих и личных упражнений пользователя,
+// при совпадении названий личное упражнение важнее общего.
+func (m *mongodb) GetExerciseByName(ctx context.Context, userID string, name string) (entity.Exercise, error) {
 	var row ExerciseRow
-	filter := bson.M{"name": name}
+	filter := bson.M{"name": name, "owner_id": visibleToFilter(userID), "archived_at": nil}
+	opts := options.FindOne().SetSort(bson.M{"owner_id": -1})
 
-	err := m.exerciseColl.FindOne(ctx, filter).Decode(&row)
+	err := m.exerciseColl.FindOne(ctx, filter, opts).Decode(&row)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return entity.Exercise{}, errs.ErrExerciseNotFound
@@ -62,10 +67,175 @@ func (m *mongodb) GetExerciseByID(ctx context.Context, id uuid.UUID) (entity.Exe
 	return *row.ToEntity(), nil
 }
 
-func (m *mongodb) GetExercisesByMuscleGroup(ctx context.Context, muscleGroup string) ([]entity.Exercise, error) {
-	filter := bson.M{"muscle_group": muscleGroup}
+func (m *mongodb) GetExercisesByIDs(ctx context.Context, ids []uuid.UUID) ([]entity.Exercise, error) {
+	values := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+
+	return m.findExercises(ctx, bson.M{"id": bson.M{"$in": values}}, options.Find())
+}
+
+func (m *mongodb) GetExercisesByMuscleGroup(ctx context.Context, userID string, muscleGroup string) ([]entity.Exercise, error) {
+	filter := bson.M{"muscle_group": muscleGroup, "owner_id": visibleToFilter(userID), "archived_at": nil}
 	opts := options.Find().SetSort(bson.M{"created_at": -1})
 
+	return m.findExercises(ctx, filter, opts)
+}
+
+func (m *mongodb) GetPrivateExercisesByName(ctx context.Context, name string) ([]entity.Exercise, error) {
+	filter := bson.M{"name": name, "owner_id": bson.M{"$nin": bson.A{nil, ""}}, "archived_at": nil}
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	return m.findExercises(ctx, filter, opts)
+}
+
+func (m *mongodb) UpdateExerciseOwner(ctx context.Context, id uuid.UUID, ownerID string) error {
+	update := bson.M{"$set": bson.M{"owner_id": ownerID}}
+	if ownerID == "" {
+		update = bson.M{"$unset": bson.M{"owner_id": ""}}
+	}
+
+	res, err := m.exerciseColl.UpdateOne(ctx, bson.M{"id": id.String()}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise owner: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return errs.ErrExerciseNotFound
+	}
+
+	return nil
+}
+
+// UpdateExercise сохраняет изменяемые администратором поля упражнения.
+func (m *mongodb) UpdateExercise(ctx context.Context, req entity.Exercise) error {
+	update := bson.M{"$set": bson.M{
+		"name":              req.Name(),
+		"muscle_group":      req.MuscleGroup(),
+		"secondary_muscles": newSecondaryMuscleRows(req.SecondaryMuscles()),
+		"equipment":         req.Equipment(),
+		"search_terms":      searchTerms(req.Name()),
+		"archived_at":       req.ArchivedAt(),
+	}}
+
+	res, err := m.exerciseColl.UpdateOne(ctx, bson.M{"id": req.ID().String()}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return errs.ErrExerciseNotFound
+	}
+
+	return nil
+}
+
+// UpdateExerciseWithLogs одной транзакцией сохраняет упражнение и его название и мышечную
+// группу в записанных подходах.
+func (m *mongodb) UpdateExerciseWithLogs(ctx context.Context, req entity.Exercise) error {
+	err := m.withTransaction(ctx, func(ctx context.Context) error {
+		if err := m.UpdateExercise(ctx, req); err != nil {
+			return err
+		}
+
+		return m.updateExerciseLogs(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update exercise with logs: %w", err)
+	}
+
+	return nil
+}
+
+// MergeExercises одной транзакцией переносит на target подходы, избранное, упражнения рейтингов
+// групп и запланированных тренировок дубликата source и сохраняет оба упражнения.
+func (m *mongodb) MergeExercises(ctx context.Context, source, target entity.Exercise) error {
+	err := m.withTransaction(ctx, func(ctx context.Context) error {
+		if err := m.reassignExerciseLogs(ctx, source.ID(), target); err != nil {
+			return err
+		}
+
+		if err := m.reassignExerciseReferences(ctx, source.ID(), target); err != nil {
+			return err
+		}
+
+		if err := m.UpdateExercise(ctx, target); err != nil {
+			return err
+		}
+
+		return m.UpdateExercise(ctx, source)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge exercises: %w", err)
+	}
+
+	return nil
+}
+
+// reassignExerciseReferences заменяет sourceID на target там, где упражнение выбрано пользователем.
+// Если target уже выбран рядом с sourceID, sourceID просто убирается, чтобы не было повторов.
+func (m *mongodb) reassignExerciseReferences(ctx context.Context, sourceID uuid.UUID, target entity.Exercise) error {
+	sourceIDStr, targetIDStr := sourceID.String(), target.ID().String()
+
+	if _, err := m.settingsColl.UpdateMany(ctx,
+		bson.M{"favorite_exercises": bson.M{"$all": bson.A{sourceIDStr, targetIDStr}}},
+		bson.M{"$pull": bson.M{"favorite_exercises": sourceIDStr}},
+	); err != nil {
+		return fmt.Errorf("failed to remove duplicate favorite exercise: %w", err)
+	}
+
+	if _, err := m.settingsColl.UpdateMany(ctx,
+		bson.M{"favorite_exercises": sourceIDStr},
+		bson.M{"$set": bson.M{"favorite_exercises.$": targetIDStr}},
+	); err != nil {
+		return fmt.Errorf("failed to reassign favorite exercise: %w", err)
+	}
+
+	if _, err := m.groupColl.UpdateMany(ctx,
+		bson.M{"lifts.exercise_id": bson.M{"$all": bson.A{sourceIDStr, targetIDStr}}},
+		bson.M{"$pull": bson.M{"lifts": bson.M{"exercise_id": sourceIDStr}}},
+	); err != nil {
+		return fmt.Errorf("failed to remove duplicate group lift: %w", err)
+	}
+
+	if _, err := m.groupColl.UpdateMany(ctx,
+		bson.M{"lifts.exercise_id": sourceIDStr},
+		bson.M{"$set": bson.M{"lifts.$.exercise_id": targetIDStr, "lifts.$.name": target.Name()}},
+	); err != nil {
+		return fmt.Errorf("failed to reassign group lift: %w", err)
+	}
+
+	if _, err := m.workoutColl.UpdateMany(ctx,
+		bson.M{"exercises.exercise_id": sourceIDStr},
+		bson.M{"$set": bson.M{"exercises.$[e].exercise_id": targetIDStr, "exercises.$[e].name": target.Name()}},
+		options.UpdateMany().SetArrayFilters([]any{bson.M{"e.exercise_id": sourceIDStr}}),
+	); err != nil {
+		return fmt.Errorf("failed to reassign planned exercise: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) InsertExerciseAudit(ctx context.Context, req entity.ExerciseAudit) error {
+	row := NewExerciseAuditRow(WithExerciseAuditRowRestoreSpec(ExerciseAuditRowRestoreSpecification{
+		ID:         req.ID().String(),
+		ExerciseID: req.ExerciseID().String(),
+		ActorID:    req.ActorID(),
+		Action:     string(req.Action()),
+		Before:     req.Before(),
+		After:      req.After(),
+		CreatedAt:  req.CreatedAt(),
+	}))
+
+	if _, err := m.auditColl.InsertOne(ctx, row); err != nil {
+		return fmt.Errorf("failed to insert exercise audit: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) findExercises(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]entity.Exercise, error) {
 	cursor, err := m.exerciseColl.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exercises: %w", err)
@@ -92,3 +262,8 @@ func (m *mongodb) GetExercisesByMuscleGroup(ctx context.Context, muscleGroup str
 
 	return exercises, nil
 }
+
+// visibleToFilter отбирает общие упражнения каталога и личные упражнения пользователя.
+func visibleToFilter(userID string) bson.M {
+	return bson.M{"$in": bson.A{nil, "", userID}}
+}
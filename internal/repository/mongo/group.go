@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) SaveGroup(ctx context.Context, req entity.Group) error {
+	row := NewGroupRow(WithGroupRowRestoreSpec(GroupRowRestoreSpecification{
+		ChatID:    req.ChatID(),
+		Title:     req.Title(),
+		Members:   req.Members(),
+		Lifts:     req.Lifts(),
+		CreatedAt: req.CreatedAt(),
+		UpdatedAt: req.UpdatedAt(),
+	}))
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.groupColl.ReplaceOne(ctx, bson.M{"chat_id": row.ChatID}, row, opts); err != nil {
+		return fmt.Errorf("failed to save group: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) GetGroup(ctx context.Context, chatID int64) (entity.Group, error) {
+	var row GroupRow
+
+	err := m.groupColl.FindOne(ctx, bson.M{"chat_id": chatID}).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Group{}, errs.ErrGroupNotFound
+		}
+		return entity.Group{}, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+// GetMemberGroups возвращает группы, в которые вступил пользователь.
+func (m *mongodb) GetMemberGroups(ctx context.Context, userID string) ([]entity.Group, error) {
+	cursor, err := m.groupColl.Find(ctx, bson.M{"members.user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member groups: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var groups []entity.Group
+	for cursor.Next(ctx) {
+		var row GroupRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		groups = append(groups, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (m *mongodb) ensureGroupIndexes(ctx context.Context) error {
+	_, err := m.groupColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"chat_id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.M{"members.user_id": 1},
+			Options: options.Index().SetUnique(false),
+		},
+	})
+
+	return err
+}
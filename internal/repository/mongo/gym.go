@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) InsertGym(ctx context.Context, req entity.Gym) error {
+	row := NewGymRow(WithGymRowRestoreSpec(GymRowRestoreSpecification{
+		ID:        req.ID().String(),
+		UserID:    req.UserID(),
+		Name:      req.Name(),
+		Equipment: req.Equipment(),
+		CreatedAt: req.CreatedAt(),
+	}))
+
+	if _, err := m.gymColl.InsertOne(ctx, row); err != nil {
+		return fmt.Errorf("failed to insert gym: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) GetGyms(ctx context.Context, userID string) ([]entity.Gym, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := m.gymColl.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gyms: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var gyms []entity.Gym
+	for cursor.Next(ctx) {
+		var row GymRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		gyms = append(gyms, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return gyms, nil
+}
+
+func (m *mongodb) GetGymByID(ctx context.Context, userID string, id uuid.UUID) (entity.Gym, error) {
+	var row GymRow
+	filter := bson.M{"id": id.String(), "user_id": userID}
+
+	err := m.gymColl.FindOne(ctx, filter).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Gym{}, errs.ErrGymNotFound
+		}
+		return entity.Gym{}, fmt.Errorf("failed to get gym by id: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+func (m *mongodb) DeleteGym(ctx context.Context, userID string, id uuid.UUID) error {
+	res, err := m.gymColl.DeleteOne(ctx, bson.M{"id": id.String(), "user_id": userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete gym: %w", err)
+	}
+
+	if res.DeletedCount == 0 {
+		return errs.ErrGymNotFound
+	}
+
+	return nil
+}
+
+// GetEquipmentTypes возвращает оборудование, которое встречается в активных упражнениях каталога.
+func (m *mongodb) GetEquipmentTypes(ctx context.Context) ([]string, error) {
+	filter := bson.M{"archived_at": nil, "equipment": bson.M{"$nin": bson.A{nil, ""}}}
+
+	var equipment []string
+	if err := m.exerciseColl.Distinct(ctx, "equipment", filter).Decode(&equipment); err != nil {
+		return nil, fmt.Errorf("failed to get equipment types: %w", err)
+	}
+
+	slices.Sort(equipment)
+
+	return equipment, nil
+}
+
+// gymIDString не сохраняет uuid.Nil, чтобы тренировки без зала не попадали в фильтр по залу.
+func gymIDString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+func (m *mongodb) ensureGymIndexes(ctx context.Context) error {
+	if _, err := m.gymColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.logColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "exercise_id", Value: 1}, {Key: "gym_id", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
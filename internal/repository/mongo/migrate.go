@@ -0,0 +1,182 @@
+package mongodb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/errs"
+)
+
+const (
+	migrationLockID = "lock"
+	// migrationLockTTL после этого времени чужая блокировка считается брошенной упавшим процессом.
+	migrationLockTTL = 10 * time.Minute
+
+	// migrationLockPollMin, migrationLockPollMax пределы паузы между попытками взять занятую блокировку
+	migrationLockPollMin = 500 * time.Millisecond
+	migrationLockPollMax = 15 * time.Second
+)
+
+type migration struct {
+	version int64
+	name    string
+	up      func(m *mongodb, ctx context.Context) error
+}
+
+type migrationRow struct {
+	Version   int64     `bson:"version"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type migrationLockRow struct {
+	ID       string    `bson:"_id"`
+	Owner    string    `bson:"owner"`
+	LockedAt time.Time `bson:"locked_at"`
+}
+
+// Migrate применяет по порядку еще не примененные миграции из migrations. Примененные
+// версии хранятся в schema_migrations, параллельный запуск блокируется через schema_migrations_lock.
+func (m *mongodb) Migrate(ctx context.Context) error {
+	owner := migrationLockOwner()
+
+	if err := m.acquireMigrationLock(ctx, owner); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := m.releaseMigrationLock(context.WithoutCancel(ctx), owner); err != nil {
+			log.Printf("release migration lock err: %v", err)
+		}
+	}()
+
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := slices.Clone(migrations)
+	slices.SortFunc(pending, func(a, b migration) int {
+		return cmp.Compare(a.version, b.version)
+	})
+
+	for _, mg := range pending {
+		if _, ok := applied[mg.version]; ok {
+			continue
+		}
+
+		log.Printf("Applying migration %d_%s", mg.version, mg.name)
+
+		if err := mg.up(m, ctx); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", mg.version, mg.name, err)
+		}
+
+		row := migrationRow{Version: mg.version, Name: mg.name, AppliedAt: time.Now()}
+		if _, err := m.db.Collection(colMigrations).InsertOne(ctx, row); err != nil {
+			return fmt.Errorf("failed to record migration %d_%s: %w", mg.version, mg.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *mongodb) appliedMigrations(ctx context.Context) (map[int64]struct{}, error) {
+	cursor, err := m.db.Collection(colMigrations).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	applied := make(map[int64]struct{})
+	for cursor.Next(ctx) {
+		var row migrationRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		applied[row.Version] = struct{}{}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return applied, nil
+}
+
+// acquireMigrationLock ждет, пока другой процесс закончит миграции или его блокировка устареет.
+// Примененные версии читаются уже после получения блокировки, поэтому сделанное другим процессом
+// повторно не применяется.
+func (m *mongodb) acquireMigrationLock(ctx context.Context, owner string) error {
+	delay := migrationLockPollMin
+
+	for {
+		acquired, err := m.tryAcquireMigrationLock(ctx, owner)
+		if err != nil || acquired {
+			return err
+		}
+
+		log.Printf("Migrations are locked by another process, retrying in %v", delay)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", errs.ErrMigrationLocked, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, migrationLockPollMax)
+	}
+}
+
+func (m *mongodb) tryAcquireMigrationLock(ctx context.Context, owner string) (bool, error) {
+	lockColl := m.db.Collection(colMigrationLock)
+	now := time.Now()
+
+	_, err := lockColl.InsertOne(ctx, migrationLockRow{ID: migrationLockID, Owner: owner, LockedAt: now})
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	filter := bson.M{"_id": migrationLockID, "locked_at": bson.M{"$lt": now.Add(-migrationLockTTL)}}
+	update := bson.M{"$set": bson.M{"owner": owner, "locked_at": now}}
+
+	err = lockColl.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate()).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to take over stale migration lock: %w", err)
+	}
+
+	log.Printf("Took over stale migration lock")
+
+	return true, nil
+}
+
+func (m *mongodb) releaseMigrationLock(ctx context.Context, owner string) error {
+	_, err := m.db.Collection(colMigrationLock).DeleteOne(ctx, bson.M{"_id": migrationLockID, "owner": owner})
+	return err
+}
+
+func migrationLockOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), uuid.NewString())
+}
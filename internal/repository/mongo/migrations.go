@@ -0,0 +1,201 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// migrations упорядочены по version, уже примененные версии не меняются: новые изменения
+// схемы и данных добавляются отдельной миграцией в конец списка.
+var migrations = []migration{
+	{version: 1, name: "indexes", up: (*mongodb).indexesV1},
+	{version: 2, name: "exercises_seed", up: (*mongodb).seedExercises},
+	{version: 3, name: "exercise_search_terms", up: (*mongodb).backfillSearchTerms},
+	{version: 4, name: "muscle_groups_seed", up: (*mongodb).seedMuscleGroups},
+	{version: 5, name: "gyms_indexes", up: (*mongodb).ensureGymIndexes},
+	{version: 6, name: "exercise_techniques_indexes", up: (*mongodb).ensureTechniqueIndexes},
+	{version: 7, name: "coach_indexes", up: (*mongodb).ensureCoachIndexes},
+	{version: 8, name: "assigned_workouts_indexes", up: (*mongodb).ensureWorkoutIndexes},
+	{version: 9, name: "groups_indexes", up: (*mongodb).ensureGroupIndexes},
+}
+
+// indexesV1 индексы миграции версии 1. Функция зафиксирована вместе с версией и не меняется:
+// новые индексы добавляются отдельной миграцией.
+func (m *mongodb) indexesV1(ctx context.Context) error {
+	if _, err := m.logColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "exercise_id", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys:    bson.M{"session_date": 1},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.sessionColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.exerciseColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}, {Key: "owner_id", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys:    bson.M{"search_terms": 1},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.settingsColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"user_id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.muscleColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetUnique(true),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := m.auditColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "exercise_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// exerciseSeed общий каталог упражнений, перенесенный из migrations/clickhouse/20250118171825_exercises_seed.sql.
+var exerciseSeed = []struct {
+	name        string
+	muscleGroup string
+	equipment   string
+}{
+	{name: "Тяга вертикального блока", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Тяга штанги в наклоне", muscleGroup: "Спина", equipment: "Штанга"},
+	{name: "Тяга гантели к поясу одной рукой", muscleGroup: "Спина", equipment: "Гантели"},
+	{name: "Тяга в Хаммере (на спину)", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Подъем штанги на бицепс", muscleGroup: "Руки", equipment: "Штанга"},
+	{name: "Подъем гантели на бицепс", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Шраги с гантелями/гирями", muscleGroup: "Спина", equipment: "Гантели"},
+	{name: "Верхняя тяга в тренажере", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Молотковый подъем с гантелями", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Сгибание рук с гантелями на скамье Скотта", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Сгибание рук на скамье Скотта в блоке", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Тяга горизонтального блока", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Пуловер на спину в блоке", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Тяга Т-грифа", muscleGroup: "Спина", equipment: "Т-гриф"},
+	{name: "Тяга рычажного тренажера", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Горизонтальная тяга на тренажере", muscleGroup: "Спина", equipment: "Тренажер"},
+	{name: "Подъем гантелей на бицепс \"21\"", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Сгибания рук в блоке (обратный хват)", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Сгибание рук на нижнем блоке", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Подъем гантелей на бицепс с колен", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Жим в Хаммере (горизонтальный)", muscleGroup: "Грудь", equipment: "Тренажер"},
+	{name: "Жим штанги лежа", muscleGroup: "Грудь", equipment: "Штанга"},
+	{name: "Жим штанги на наклонной скамье", muscleGroup: "Грудь", equipment: "Штанга"},
+	{name: "Жим штанги головой вниз", muscleGroup: "Грудь", equipment: "Штанга"},
+	{name: "Жим штанги в Смите", muscleGroup: "Грудь", equipment: "Тренажер"},
+	{name: "Наклонный жим штанги в Смите", muscleGroup: "Грудь", equipment: "Тренажер"},
+	{name: "Жим гантелей лежа", muscleGroup: "Грудь", equipment: "Гантели"},
+	{name: "Сведение рук в кроссовере", muscleGroup: "Грудь", equipment: "Кроссовер"},
+	{name: "Разгибания на трицепс в блоке (канат)", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Разгибания на трицепс в блоке (EZ-гриф)", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Жим гантели из-за головы сидя", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Разведение гантелей лежа", muscleGroup: "Грудь", equipment: "Гантели"},
+	{name: "Разведение гантелей на наклонной скамье", muscleGroup: "Грудь", equipment: "Гантели"},
+	{name: "Бабочка", muscleGroup: "Грудь", equipment: "Тренажер"},
+	{name: "Отжимания на брусьях в тренажере", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Жим гантелей на наклонной скамье", muscleGroup: "Грудь", equipment: "Гантели"},
+	{name: "Отжимания на брусьях", muscleGroup: "Грудь", equipment: "Собственный вес"},
+	{name: "Жим на грудь в тренажере", muscleGroup: "Грудь", equipment: "Тренажер"},
+	{name: "Французский жим со штангой", muscleGroup: "Руки", equipment: "Штанга"},
+	{name: "Французский жим в блоке", muscleGroup: "Руки", equipment: "Тренажер"},
+	{name: "Разгибание одной руки с гантелью (за головой)", muscleGroup: "Руки", equipment: "Гантели"},
+	{name: "Жим штанги стоя (армейский)", muscleGroup: "Плечи", equipment: "Штанга"},
+	{name: "Махи гантелями в стороны", muscleGroup: "Плечи", equipment: "Гантели"},
+	{name: "Разведение гантелей в наклоне", muscleGroup: "Плечи", equipment: "Гантели"},
+	{name: "Махи в дельта-машине", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Жим на плечи в тренажере", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Обратная бабочка", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Жим штанги в Смите из-за головы", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Жим ногами", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Выпады с гантелями в движении", muscleGroup: "Ноги", equipment: "Гантели"},
+	{name: "Икры (сидя)", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Жим гантелей сидя", muscleGroup: "Плечи", equipment: "Гантели"},
+	{name: "Тяга штанги к подбородку", muscleGroup: "Плечи", equipment: "Штанга"},
+	{name: "Отведение руки в сторону в кроссовере (средняя)", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Отведение руки в сторону в наклоне на блоке", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Тяга к лицу в блоке", muscleGroup: "Плечи", equipment: "Тренажер"},
+	{name: "Разгибание ног в тренажере", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Сгибание ног в тренажере", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Разведение ног в тренажере", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Сведение ног в тренажере", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Гакк-приседания", muscleGroup: "Ноги", equipment: "Тренажер"},
+	{name: "Подъемы на носки в гакк-тренажере", muscleGroup: "Ноги", equipment: "Тренажер"},
+}
+
+// seedExercises добавляет в каталог отсутствующие общие упражнения, уже существующие
+// по названию не трогает.
+func (m *mongodb) seedExercises(ctx context.Context) error {
+	now := time.Now()
+
+	for _, seed := range exerciseSeed {
+		row := NewExerciseRow(WithExerciseRowRestoreSpec(ExerciseRowRestoreSpecification{
+			ID:          uuid.NewString(),
+			CreatedAt:   now,
+			Name:        seed.name,
+			MuscleGroup: seed.muscleGroup,
+			Equipment:   seed.equipment,
+		}))
+
+		filter := bson.M{"name": seed.name, "owner_id": bson.M{"$in": bson.A{nil, ""}}}
+		update := bson.M{"$setOnInsert": row}
+
+		if _, err := m.exerciseColl.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true)); err != nil {
+			return fmt.Errorf("failed to seed exercise %q: %w", seed.name, err)
+		}
+	}
+
+	return nil
+}
@@ -11,24 +11,45 @@ import (
 type ExerciseOption func(o *ExerciseRow)
 type TrainingSessionOption func(o *TrainingSessionRow)
 type SetOption func(o *SetRow)
+type UserSettingsOption func(o *UserSettingsRow)
+type ExerciseAuditOption func(o *ExerciseAuditRow)
+type MuscleGroupOption func(o *MuscleGroupRow)
+type GymOption func(o *GymRow)
+type TechniqueOption func(o *TechniqueRow)
+type CoachLinkOption func(o *CoachLinkRow)
+type SessionCommentOption func(o *SessionCommentRow)
+type AssignedWorkoutOption func(o *AssignedWorkoutRow)
+type GroupOption func(o *GroupRow)
 
 type ExerciseRow struct {
-	ID          string    `bson:"id"`
-	CreatedAt   time.Time `bson:"created_at"`
-	Name        string    `bson:"name"`
-	MuscleGroup string    `bson:"muscle_group"`
-	Equipment   string    `bson:"equipment"`
+	ID          string               `bson:"id"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	Name        string               `bson:"name"`
+	MuscleGroup string               `bson:"muscle_group"`
+	Secondary   []SecondaryMuscleRow `bson:"secondary_muscles,omitempty"`
+	Equipment   string               `bson:"equipment"`
+	OwnerID     string               `bson:"owner_id,omitempty"`
+	SearchTerms []string             `bson:"search_terms"`
+	ArchivedAt  *time.Time           `bson:"archived_at,omitempty"`
 }
 
 func (e *ExerciseRow) ToEntity() *entity.Exercise {
 	id, _ := uuid.Parse(e.ID)
+	secondary := make([]entity.SecondaryMuscle, 0, len(e.Secondary))
+	for _, muscle := range e.Secondary {
+		secondary = append(secondary, entity.SecondaryMuscle{MuscleGroup: muscle.MuscleGroup, Weight: muscle.Weight})
+	}
+
 	return entity.NewExercise(
 		entity.WithExerciseRestoreSpec(entity.ExerciseRestoreSpecification{
-			ID:          id,
-			Name:        e.Name,
-			MuscleGroup: e.MuscleGroup,
-			Equipment:   e.Equipment,
-			CreatedAt:   e.CreatedAt,
+			ID:               id,
+			Name:             e.Name,
+			MuscleGroup:      e.MuscleGroup,
+			SecondaryMuscles: secondary,
+			Equipment:        e.Equipment,
+			OwnerID:          e.OwnerID,
+			ArchivedAt:       e.ArchivedAt,
+			CreatedAt:        e.CreatedAt,
 		}),
 	)
 }
@@ -48,7 +69,10 @@ type ExerciseRowRestoreSpecification struct {
 	CreatedAt   time.Time
 	Name        string
 	MuscleGroup string
+	Secondary   []entity.SecondaryMuscle
 	Equipment   string
+	OwnerID     string
+	ArchivedAt  *time.Time
 }
 
 func WithExerciseRowRestoreSpec(e ExerciseRowRestoreSpecification) ExerciseOption {
@@ -57,16 +81,40 @@ func WithExerciseRowRestoreSpec(e ExerciseRowRestoreSpecification) ExerciseOptio
 		o.CreatedAt = e.CreatedAt
 		o.Name = e.Name
 		o.MuscleGroup = e.MuscleGroup
+		o.Secondary = newSecondaryMuscleRows(e.Secondary)
 		o.Equipment = e.Equipment
+		o.OwnerID = e.OwnerID
+		o.SearchTerms = searchTerms(e.Name)
+		o.ArchivedAt = e.ArchivedAt
 	}
 }
 
+type SecondaryMuscleRow struct {
+	MuscleGroup string  `bson:"muscle_group"`
+	Weight      float32 `bson:"weight"`
+}
+
+func newSecondaryMuscleRows(muscles []entity.SecondaryMuscle) []SecondaryMuscleRow {
+	rows := make([]SecondaryMuscleRow, 0, len(muscles))
+	for _, muscle := range muscles {
+		rows = append(rows, SecondaryMuscleRow{MuscleGroup: muscle.MuscleGroup, Weight: muscle.Weight})
+	}
+
+	return rows
+}
+
 type TrainingSessionRow struct {
 	ID        string    `bson:"id"`
 	UserID    string    `bson:"user_id"`
 	Date      time.Time `bson:"date"`
 	Notes     string    `bson:"notes"`
+	GymID     string    `bson:"gym_id,omitempty"`
 	CreatedAt time.Time `bson:"created_at"`
+
+	StartedAt  time.Time `bson:"started_at,omitempty"`
+	FinishedAt time.Time `bson:"finished_at,omitempty"`
+	Bodyweight float32   `bson:"bodyweight,omitempty"`
+	Rating     uint8     `bson:"rating,omitempty"`
 }
 
 func NewTrainingSessionRow(opts ...TrainingSessionOption) *TrainingSessionRow {
@@ -84,7 +132,13 @@ type TrainingSessionRowRestoreSpecification struct {
 	UserID    string
 	Date      time.Time
 	Notes     string
+	GymID     string
 	CreatedAt time.Time
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Bodyweight float32
+	Rating     uint8
 }
 
 func WithTrainingSessionRowRestoreSpec(e TrainingSessionRowRestoreSpecification) TrainingSessionOption {
@@ -94,6 +148,11 @@ func WithTrainingSessionRowRestoreSpec(e TrainingSessionRowRestoreSpecification)
 		o.Date = e.Date
 		o.CreatedAt = e.CreatedAt
 		o.Notes = e.Notes
+		o.GymID = e.GymID
+		o.StartedAt = e.StartedAt
+		o.FinishedAt = e.FinishedAt
+		o.Bodyweight = e.Bodyweight
+		o.Rating = e.Rating
 	}
 }
 
@@ -102,8 +161,14 @@ type trainingSessionRowWithLogs struct {
 	UserID    string    `bson:"user_id"`
 	Date      time.Time `bson:"date"`
 	Notes     string    `bson:"notes,omitempty"`
+	GymID     string    `bson:"gym_id,omitempty"`
 	CreatedAt time.Time `bson:"created_at,omitempty"`
 	Logs      []SetRow  `bson:"logs"`
+
+	StartedAt  time.Time `bson:"started_at,omitempty"`
+	FinishedAt time.Time `bson:"finished_at,omitempty"`
+	Bodyweight float32   `bson:"bodyweight,omitempty"`
+	Rating     uint8     `bson:"rating,omitempty"`
 }
 
 type SetRow struct {
@@ -120,7 +185,9 @@ type SetRow struct {
 	Difficulty     string    `bson:"difficulty"`
 	Notes          string    `bson:"notes"`
 	MuscleGroup    string    `bson:"muscle_group"`
+	GymID          string    `bson:"gym_id,omitempty"`
 	CreatedAt      time.Time `bson:"created_at"`
+	CompletedAt    time.Time `bson:"completed_at,omitempty"`
 }
 
 func NewSetRow(opts ...SetOption) *SetRow {
@@ -148,7 +215,9 @@ type SetRowRestoreSpecification struct {
 	Difficulty     string
 	Notes          string
 	MuscleGroup    string
+	GymID          string
 	CreatedAt      time.Time
+	CompletedAt    time.Time
 }
 
 func WithSetRestoreSpec(s SetRowRestoreSpecification) SetOption {
@@ -166,6 +235,609 @@ func WithSetRestoreSpec(s SetRowRestoreSpecification) SetOption {
 		o.Difficulty = s.Difficulty
 		o.Notes = s.Notes
 		o.MuscleGroup = s.MuscleGroup
+		o.GymID = s.GymID
 		o.CreatedAt = s.CreatedAt
+		o.CompletedAt = s.CompletedAt
+	}
+}
+
+type UserSettingsRow struct {
+	UserID            string    `bson:"user_id"`
+	Locale            string    `bson:"locale"`
+	FavoriteExercises []string  `bson:"favorite_exercises,omitempty"`
+	GymID             string    `bson:"gym_id,omitempty"`
+	AnalyticsByGym    bool      `bson:"analytics_by_gym,omitempty"`
+	IdleSessionAction string    `bson:"idle_session_action,omitempty"`
+	UpdatedAt         time.Time `bson:"updated_at"`
+}
+
+func (u *UserSettingsRow) ToEntity() *entity.UserSettings {
+	favorites := make([]uuid.UUID, 0, len(u.FavoriteExercises))
+	for _, id := range u.FavoriteExercises {
+		if exerciseID, err := uuid.Parse(id); err == nil {
+			favorites = append(favorites, exerciseID)
+		}
+	}
+
+	gymID, _ := uuid.Parse(u.GymID)
+
+	return entity.NewUserSettings(entity.WithUserSettingsRestoreSpec(entity.UserSettingsRestoreSpecification{
+		UserID:            u.UserID,
+		Locale:            u.Locale,
+		FavoriteExercises: favorites,
+		GymID:             gymID,
+		AnalyticsByGym:    u.AnalyticsByGym,
+		UpdatedAt:         u.UpdatedAt,
+		IdleSessionAction: entity.IdleSessionAction(u.IdleSessionAction),
+	}))
+}
+
+func NewUserSettingsRow(opts ...UserSettingsOption) *UserSettingsRow {
+	settings := &UserSettingsRow{}
+
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	return settings
+}
+
+type UserSettingsRowRestoreSpecification struct {
+	UserID            string
+	Locale            string
+	FavoriteExercises []uuid.UUID
+	GymID             uuid.UUID
+	AnalyticsByGym    bool
+	IdleSessionAction entity.IdleSessionAction
+	UpdatedAt         time.Time
+}
+
+func WithUserSettingsRowRestoreSpec(s UserSettingsRowRestoreSpecification) UserSettingsOption {
+	return func(o *UserSettingsRow) {
+		o.UserID = s.UserID
+		o.Locale = s.Locale
+		o.FavoriteExercises = make([]string, 0, len(s.FavoriteExercises))
+		for _, id := range s.FavoriteExercises {
+			o.FavoriteExercises = append(o.FavoriteExercises, id.String())
+		}
+		if s.GymID != uuid.Nil {
+			o.GymID = s.GymID.String()
+		}
+		o.AnalyticsByGym = s.AnalyticsByGym
+		o.IdleSessionAction = string(s.IdleSessionAction)
+		o.UpdatedAt = s.UpdatedAt
+	}
+}
+
+type ExerciseAuditRow struct {
+	ID         string    `bson:"id"`
+	ExerciseID string    `bson:"exercise_id"`
+	ActorID    string    `bson:"actor_id"`
+	Action     string    `bson:"action"`
+	Before     string    `bson:"before"`
+	After      string    `bson:"after"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func NewExerciseAuditRow(opts ...ExerciseAuditOption) *ExerciseAuditRow {
+	audit := &ExerciseAuditRow{}
+
+	for _, opt := range opts {
+		opt(audit)
+	}
+
+	return audit
+}
+
+type ExerciseAuditRowRestoreSpecification struct {
+	ID         string
+	ExerciseID string
+	ActorID    string
+	Action     string
+	Before     string
+	After      string
+	CreatedAt  time.Time
+}
+
+func WithExerciseAuditRowRestoreSpec(a ExerciseAuditRowRestoreSpecification) ExerciseAuditOption {
+	return func(o *ExerciseAuditRow) {
+		o.ID = a.ID
+		o.ExerciseID = a.ExerciseID
+		o.ActorID = a.ActorID
+		o.Action = a.Action
+		o.Before = a.Before
+		o.After = a.After
+		o.CreatedAt = a.CreatedAt
+	}
+}
+
+type MuscleGroupRow struct {
+	Name   string            `bson:"name"`
+	Emoji  string            `bson:"emoji"`
+	Order  int               `bson:"order"`
+	Titles map[string]string `bson:"titles"`
+}
+
+func (m *MuscleGroupRow) ToEntity() *entity.MuscleGroup {
+	return entity.NewMuscleGroup(entity.WithMuscleGroupRestoreSpec(entity.MuscleGroupRestoreSpecification{
+		Name:   m.Name,
+		Emoji:  m.Emoji,
+		Order:  m.Order,
+		Titles: m.Titles,
+	}))
+}
+
+func NewMuscleGroupRow(opts ...MuscleGroupOption) *MuscleGroupRow {
+	group := &MuscleGroupRow{}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+type MuscleGroupRowRestoreSpecification struct {
+	Name   string
+	Emoji  string
+	Order  int
+	Titles map[string]string
+}
+
+func WithMuscleGroupRowRestoreSpec(m MuscleGroupRowRestoreSpecification) MuscleGroupOption {
+	return func(o *MuscleGroupRow) {
+		o.Name = m.Name
+		o.Emoji = m.Emoji
+		o.Order = m.Order
+		o.Titles = m.Titles
+	}
+}
+
+type GymRow struct {
+	ID        string    `bson:"id"`
+	UserID    string    `bson:"user_id"`
+	Name      string    `bson:"name"`
+	Equipment []string  `bson:"equipment"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (g *GymRow) ToEntity() *entity.Gym {
+	id, _ := uuid.Parse(g.ID)
+
+	return entity.NewGym(entity.WithGymRestoreSpec(entity.GymRestoreSpecification{
+		ID:        id,
+		UserID:    g.UserID,
+		Name:      g.Name,
+		Equipment: g.Equipment,
+		CreatedAt: g.CreatedAt,
+	}))
+}
+
+func NewGymRow(opts ...GymOption) *GymRow {
+	gym := &GymRow{}
+
+	for _, opt := range opts {
+		opt(gym)
+	}
+
+	return gym
+}
+
+type GymRowRestoreSpecification struct {
+	ID        string
+	UserID    string
+	Name      string
+	Equipment []string
+	CreatedAt time.Time
+}
+
+func WithGymRowRestoreSpec(g GymRowRestoreSpecification) GymOption {
+	return func(o *GymRow) {
+		o.ID = g.ID
+		o.UserID = g.UserID
+		o.Name = g.Name
+		o.Equipment = g.Equipment
+		o.CreatedAt = g.CreatedAt
+	}
+}
+
+type TechniqueMediaRow struct {
+	Type   string `bson:"type"`
+	FileID string `bson:"file_id,omitempty"`
+	Path   string `bson:"path,omitempty"`
+}
+
+type TechniqueRow struct {
+	ExerciseID  string              `bson:"exercise_id"`
+	Description string              `bson:"description"`
+	Cues        []string            `bson:"cues"`
+	Mistakes    []string            `bson:"mistakes"`
+	Media       []TechniqueMediaRow `bson:"media"`
+	UpdatedAt   time.Time           `bson:"updated_at"`
+}
+
+func (t *TechniqueRow) ToEntity() *entity.Technique {
+	exerciseID, _ := uuid.Parse(t.ExerciseID)
+
+	media := make([]entity.TechniqueMedia, 0, len(t.Media))
+	for _, m := range t.Media {
+		media = append(media, entity.TechniqueMedia{Type: entity.MediaType(m.Type), FileID: m.FileID, Path: m.Path})
+	}
+
+	return entity.NewTechnique(entity.WithTechniqueRestoreSpec(entity.TechniqueRestoreSpecification{
+		ExerciseID:  exerciseID,
+		Description: t.Description,
+		Cues:        t.Cues,
+		Mistakes:    t.Mistakes,
+		Media:       media,
+		UpdatedAt:   t.UpdatedAt,
+	}))
+}
+
+func NewTechniqueRow(opts ...TechniqueOption) *TechniqueRow {
+	technique := &TechniqueRow{}
+
+	for _, opt := range opts {
+		opt(technique)
+	}
+
+	return technique
+}
+
+type TechniqueRowRestoreSpecification struct {
+	ExerciseID  string
+	Description string
+	Cues        []string
+	Mistakes    []string
+	Media       []entity.TechniqueMedia
+	UpdatedAt   time.Time
+}
+
+func WithTechniqueRowRestoreSpec(t TechniqueRowRestoreSpecification) TechniqueOption {
+	return func(o *TechniqueRow) {
+		o.ExerciseID = t.ExerciseID
+		o.Description = t.Description
+		o.Cues = t.Cues
+		o.Mistakes = t.Mistakes
+		o.Media = make([]TechniqueMediaRow, 0, len(t.Media))
+		for _, m := range t.Media {
+			o.Media = append(o.Media, TechniqueMediaRow{Type: string(m.Type), FileID: m.FileID, Path: m.Path})
+		}
+		o.UpdatedAt = t.UpdatedAt
+	}
+}
+
+type CoachLinkRow struct {
+	ID          string     `bson:"id"`
+	CoachID     string     `bson:"coach_id"`
+	CoachName   string     `bson:"coach_name"`
+	AthleteID   string     `bson:"athlete_id,omitempty"`
+	AthleteName string     `bson:"athlete_name,omitempty"`
+	Status      string     `bson:"status"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	AcceptedAt  *time.Time `bson:"accepted_at,omitempty"`
+	RevokedAt   *time.Time `bson:"revoked_at,omitempty"`
+}
+
+func (l *CoachLinkRow) ToEntity() *entity.CoachLink {
+	id, _ := uuid.Parse(l.ID)
+
+	var acceptedAt, revokedAt time.Time
+	if l.AcceptedAt != nil {
+		acceptedAt = *l.AcceptedAt
+	}
+	if l.RevokedAt != nil {
+		revokedAt = *l.RevokedAt
+	}
+
+	return entity.NewCoachLink(entity.WithCoachLinkRestoreSpec(entity.CoachLinkRestoreSpecification{
+		ID:          id,
+		CoachID:     l.CoachID,
+		CoachName:   l.CoachName,
+		AthleteID:   l.AthleteID,
+		AthleteName: l.AthleteName,
+		Status:      entity.CoachLinkStatus(l.Status),
+		CreatedAt:   l.CreatedAt,
+		AcceptedAt:  acceptedAt,
+		RevokedAt:   revokedAt,
+	}))
+}
+
+func NewCoachLinkRow(opts ...CoachLinkOption) *CoachLinkRow {
+	link := &CoachLinkRow{}
+
+	for _, opt := range opts {
+		opt(link)
+	}
+
+	return link
+}
+
+type CoachLinkRowRestoreSpecification struct {
+	ID          string
+	CoachID     string
+	CoachName   string
+	AthleteID   string
+	AthleteName string
+	Status      string
+	CreatedAt   time.Time
+	AcceptedAt  time.Time
+	RevokedAt   time.Time
+}
+
+func WithCoachLinkRowRestoreSpec(l CoachLinkRowRestoreSpecification) CoachLinkOption {
+	return func(o *CoachLinkRow) {
+		o.ID = l.ID
+		o.CoachID = l.CoachID
+		o.CoachName = l.CoachName
+		o.AthleteID = l.AthleteID
+		o.AthleteName = l.AthleteName
+		o.Status = l.Status
+		o.CreatedAt = l.CreatedAt
+		if !l.AcceptedAt.IsZero() {
+			o.AcceptedAt = &l.AcceptedAt
+		}
+		if !l.RevokedAt.IsZero() {
+			o.RevokedAt = &l.RevokedAt
+		}
+	}
+}
+
+type SessionCommentRow struct {
+	ID        string    `bson:"id"`
+	SessionID string    `bson:"session_id"`
+	AthleteID string    `bson:"athlete_id"`
+	CoachID   string    `bson:"coach_id"`
+	CoachName string    `bson:"coach_name"`
+	Text      string    `bson:"text"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (c *SessionCommentRow) ToEntity() *entity.SessionComment {
+	id, _ := uuid.Parse(c.ID)
+	sessionID, _ := uuid.Parse(c.SessionID)
+
+	return entity.NewSessionComment(entity.WithSessionCommentRestoreSpec(entity.SessionCommentRestoreSpecification{
+		ID:        id,
+		SessionID: sessionID,
+		AthleteID: c.AthleteID,
+		CoachID:   c.CoachID,
+		CoachName: c.CoachName,
+		Text:      c.Text,
+		CreatedAt: c.CreatedAt,
+	}))
+}
+
+func NewSessionCommentRow(opts ...SessionCommentOption) *SessionCommentRow {
+	comment := &SessionCommentRow{}
+
+	for _, opt := range opts {
+		opt(comment)
+	}
+
+	return comment
+}
+
+type SessionCommentRowRestoreSpecification struct {
+	ID        string
+	SessionID string
+	AthleteID string
+	CoachID   string
+	CoachName string
+	Text      string
+	CreatedAt time.Time
+}
+
+func WithSessionCommentRowRestoreSpec(c SessionCommentRowRestoreSpecification) SessionCommentOption {
+	return func(o *SessionCommentRow) {
+		o.ID = c.ID
+		o.SessionID = c.SessionID
+		o.AthleteID = c.AthleteID
+		o.CoachID = c.CoachID
+		o.CoachName = c.CoachName
+		o.Text = c.Text
+		o.CreatedAt = c.CreatedAt
+	}
+}
+
+type AssignedWorkoutRow struct {
+	ID          string               `bson:"id"`
+	CoachID     string               `bson:"coach_id"`
+	CoachName   string               `bson:"coach_name"`
+	AthleteID   string               `bson:"athlete_id"`
+	Date        time.Time            `bson:"date"`
+	Exercises   []PlannedExerciseRow `bson:"exercises"`
+	Status      string               `bson:"status"`
+	SessionID   string               `bson:"session_id,omitempty"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	DeliveredAt *time.Time           `bson:"delivered_at,omitempty"`
+	CompletedAt *time.Time           `bson:"completed_at,omitempty"`
+}
+
+type PlannedExerciseRow struct {
+	ExerciseID string          `bson:"exercise_id"`
+	Name       string          `bson:"name"`
+	Sets       []PlannedSetRow `bson:"sets"`
+}
+
+type PlannedSetRow struct {
+	Weight float32 `bson:"weight"`
+	Reps   uint8   `bson:"reps"`
+}
+
+func (w *AssignedWorkoutRow) ToEntity() *entity.AssignedWorkout {
+	id, _ := uuid.Parse(w.ID)
+
+	var sessionID uuid.UUID
+	if w.SessionID != "" {
+		sessionID, _ = uuid.Parse(w.SessionID)
+	}
+
+	var deliveredAt, completedAt time.Time
+	if w.DeliveredAt != nil {
+		deliveredAt = *w.DeliveredAt
+	}
+	if w.CompletedAt != nil {
+		completedAt = *w.CompletedAt
+	}
+
+	exercises := make([]entity.PlannedExercise, 0, len(w.Exercises))
+	for _, e := range w.Exercises {
+		exerciseID, _ := uuid.Parse(e.ExerciseID)
+		sets := make([]entity.PlannedSet, 0, len(e.Sets))
+		for _, set := range e.Sets {
+			sets = append(sets, entity.PlannedSet{Weight: set.Weight, Reps: set.Reps})
+		}
+		exercises = append(exercises, entity.PlannedExercise{ExerciseID: exerciseID, Name: e.Name, Sets: sets})
+	}
+
+	return entity.NewAssignedWorkout(entity.WithAssignedWorkoutRestoreSpec(entity.AssignedWorkoutRestoreSpecification{
+		ID:          id,
+		CoachID:     w.CoachID,
+		CoachName:   w.CoachName,
+		AthleteID:   w.AthleteID,
+		Date:        w.Date,
+		Exercises:   exercises,
+		Status:      entity.AssignedWorkoutStatus(w.Status),
+		SessionID:   sessionID,
+		CreatedAt:   w.CreatedAt,
+		DeliveredAt: deliveredAt,
+		CompletedAt: completedAt,
+	}))
+}
+
+func NewAssignedWorkoutRow(opts ...AssignedWorkoutOption) *AssignedWorkoutRow {
+	workout := &AssignedWorkoutRow{}
+
+	for _, opt := range opts {
+		opt(workout)
+	}
+
+	return workout
+}
+
+type AssignedWorkoutRowRestoreSpecification struct {
+	ID          string
+	CoachID     string
+	CoachName   string
+	AthleteID   string
+	Date        time.Time
+	Exercises   []entity.PlannedExercise
+	Status      string
+	SessionID   uuid.UUID
+	CreatedAt   time.Time
+	DeliveredAt time.Time
+	CompletedAt time.Time
+}
+
+func WithAssignedWorkoutRowRestoreSpec(w AssignedWorkoutRowRestoreSpecification) AssignedWorkoutOption {
+	return func(o *AssignedWorkoutRow) {
+		o.ID = w.ID
+		o.CoachID = w.CoachID
+		o.CoachName = w.CoachName
+		o.AthleteID = w.AthleteID
+		o.Date = w.Date
+		o.Status = w.Status
+		o.CreatedAt = w.CreatedAt
+
+		o.Exercises = make([]PlannedExerciseRow, 0, len(w.Exercises))
+		for _, e := range w.Exercises {
+			sets := make([]PlannedSetRow, 0, len(e.Sets))
+			for _, set := range e.Sets {
+				sets = append(sets, PlannedSetRow{Weight: set.Weight, Reps: set.Reps})
+			}
+			o.Exercises = append(o.Exercises, PlannedExerciseRow{ExerciseID: e.ExerciseID.String(), Name: e.Name, Sets: sets})
+		}
+
+		if w.SessionID != uuid.Nil {
+			o.SessionID = w.SessionID.String()
+		}
+		if !w.DeliveredAt.IsZero() {
+			o.DeliveredAt = &w.DeliveredAt
+		}
+		if !w.CompletedAt.IsZero() {
+			o.CompletedAt = &w.CompletedAt
+		}
+	}
+}
+
+type GroupRow struct {
+	ChatID    int64            `bson:"chat_id"`
+	Title     string           `bson:"title"`
+	Members   []GroupMemberRow `bson:"members"`
+	Lifts     []GroupLiftRow   `bson:"lifts"`
+	CreatedAt time.Time        `bson:"created_at"`
+	UpdatedAt time.Time        `bson:"updated_at"`
+}
+
+type GroupMemberRow struct {
+	UserID      string    `bson:"user_id"`
+	Name        string    `bson:"name"`
+	AnnouncePRs bool      `bson:"announce_prs"`
+	JoinedAt    time.Time `bson:"joined_at"`
+}
+
+type GroupLiftRow struct {
+	ExerciseID string `bson:"exercise_id"`
+	Name       string `bson:"name"`
+}
+
+func (g *GroupRow) ToEntity() *entity.Group {
+	members := make([]entity.GroupMember, 0, len(g.Members))
+	for _, m := range g.Members {
+		members = append(members, entity.GroupMember{UserID: m.UserID, Name: m.Name, AnnouncePRs: m.AnnouncePRs, JoinedAt: m.JoinedAt})
+	}
+
+	lifts := make([]entity.GroupLift, 0, len(g.Lifts))
+	for _, l := range g.Lifts {
+		exerciseID, _ := uuid.Parse(l.ExerciseID)
+		lifts = append(lifts, entity.GroupLift{ExerciseID: exerciseID, Name: l.Name})
+	}
+
+	return entity.NewGroup(entity.WithGroupRestoreSpec(entity.GroupRestoreSpecification{
+		ChatID:    g.ChatID,
+		Title:     g.Title,
+		Members:   members,
+		Lifts:     lifts,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}))
+}
+
+func NewGroupRow(opts ...GroupOption) *GroupRow {
+	group := &GroupRow{}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+type GroupRowRestoreSpecification struct {
+	ChatID    int64
+	Title     string
+	Members   []entity.GroupMember
+	Lifts     []entity.GroupLift
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func WithGroupRowRestoreSpec(g GroupRowRestoreSpecification) GroupOption {
+	return func(o *GroupRow) {
+		o.ChatID = g.ChatID
+		o.Title = g.Title
+		o.CreatedAt = g.CreatedAt
+		o.UpdatedAt = g.UpdatedAt
+
+		o.Members = make([]GroupMemberRow, 0, len(g.Members))
+		for _, m := range g.Members {
+			o.Members = append(o.Members, GroupMemberRow{UserID: m.UserID, Name: m.Name, AnnouncePRs: m.AnnouncePRs, JoinedAt: m.JoinedAt})
+		}
+
+		o.Lifts = make([]GroupLiftRow, 0, len(g.Lifts))
+		for _, l := range g.Lifts {
+			o.Lifts = append(o.Lifts, GroupLiftRow{ExerciseID: l.ExerciseID.String(), Name: l.Name})
+		}
 	}
 }
@@ -2,9 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
-	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
@@ -16,13 +17,37 @@ var (
 	colExercises = "exercises"
 	colSessions  = "training_sessions"
 	colLogs      = "training_logs"
+	colSettings  = "user_settings"
+	colAudit     = "exercise_audit"
+	colMuscles   = "muscle_groups"
+	colGyms      = "gyms"
+	colTechnique = "exercise_techniques"
+	colCoachLink = "coach_links"
+	colComments  = "session_comments"
+	colWorkouts  = "assigned_workouts"
+	colGroups    = "groups"
+
+	colMigrations    = "schema_migrations"
+	colMigrationLock = "schema_migrations_lock"
 )
 
+// errCodeIllegalOperation код ошибки Mongo без реплики, где транзакции недоступны.
+const errCodeIllegalOperation = 20
+
 type mongodb struct {
 	db           *mongo.Database
 	exerciseColl *mongo.Collection
 	sessionColl  *mongo.Collection
 	logColl      *mongo.Collection
+	settingsColl *mongo.Collection
+	auditColl    *mongo.Collection
+	muscleColl   *mongo.Collection
+	gymColl      *mongo.Collection
+	techColl     *mongo.Collection
+	coachColl    *mongo.Collection
+	commentColl  *mongo.Collection
+	workoutColl  *mongo.Collection
+	groupColl    *mongo.Collection
 	cfg          *config.DBConfig
 }
 
@@ -47,10 +72,15 @@ func New(ctx context.Context, cfg *config.DBConfig) (*mongodb, error) {
 		exerciseColl: db.Collection(colExercises),
 		sessionColl:  db.Collection(colSessions),
 		logColl:      db.Collection(colLogs),
-	}
-
-	if err := m.ensureIndexes(ctx); err != nil {
-		return nil, fmt.Errorf("mongo ensure indexes err: %w", err)
+		settingsColl: db.Collection(colSettings),
+		auditColl:    db.Collection(colAudit),
+		muscleColl:   db.Collection(colMuscles),
+		gymColl:      db.Collection(colGyms),
+		techColl:     db.Collection(colTechnique),
+		coachColl:    db.Collection(colCoachLink),
+		commentColl:  db.Collection(colComments),
+		workoutColl:  db.Collection(colWorkouts),
+		groupColl:    db.Collection(colGroups),
 	}
 
 	return m, nil
@@ -60,45 +90,24 @@ func (m *mongodb) Close(ctx context.Context) error {
 	return m.db.Client().Disconnect(ctx)
 }
 
-func (m *mongodb) ensureIndexes(ctx context.Context) error {
-	if _, err := m.logColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
-		{
-			Keys:    bson.M{"id": 1},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "exercise_id", Value: 1}},
-			Options: options.Index().SetUnique(false),
-		},
-		{
-			Keys:    bson.M{"session_date": 1},
-			Options: options.Index().SetUnique(false),
-		},
-	}); err != nil {
-		return err
+// withTransaction выполняет fn в транзакции. Mongo без реплики транзакции не поддерживает,
+// там fn выполняется без нее.
+func (m *mongodb) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := m.db.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start mongo session: %w", err)
 	}
+	defer session.EndSession(ctx)
 
-	if _, err := m.sessionColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
-		{
-			Keys:    bson.M{"id": 1},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}},
-			Options: options.Index().SetUnique(false),
-		},
-	}); err != nil {
-		return err
-	}
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
 
-	if _, err := m.exerciseColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
-		{
-			Keys:    bson.M{"id": 1},
-			Options: options.Index().SetUnique(true),
-		},
-	}); err != nil {
-		return err
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.HasErrorCode(errCodeIllegalOperation) {
+		log.Println("Mongo transactions are unavailable, writing without transaction")
+		err = fn(ctx)
 	}
 
-	return nil
+	return err
 }
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+)
+
+// defaultMuscleGroups справочник, которым заполняется пустая коллекция мышечных групп.
+// Названия совпадают с muscle_group уже сохраненных упражнений и подходов.
+var defaultMuscleGroups = []MuscleGroupRowRestoreSpecification{
+	{Name: "Спина", Emoji: "💪", Order: 10, Titles: map[string]string{"ru": "Спина", "en": "Back"}},
+	{Name: "Грудь", Emoji: "🏋️", Order: 20, Titles: map[string]string{"ru": "Грудь", "en": "Chest"}},
+	{Name: "Ноги", Emoji: "🦵", Order: 30, Titles: map[string]string{"ru": "Ноги", "en": "Legs"}},
+	{Name: "Руки", Emoji: "💪", Order: 40, Titles: map[string]string{"ru": "Руки", "en": "Arms"}},
+	{Name: "Плечи", Emoji: "🤷", Order: 50, Titles: map[string]string{"ru": "Плечи", "en": "Shoulders"}},
+}
+
+func (m *mongodb) GetMuscleGroups(ctx context.Context) ([]entity.MuscleGroup, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "name", Value: 1}})
+
+	cursor, err := m.muscleColl.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muscle groups: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var groups []entity.MuscleGroup
+	for cursor.Next(ctx) {
+		var row MuscleGroupRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		groups = append(groups, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (m *mongodb) seedMuscleGroups(ctx context.Context) error {
+	count, err := m.muscleColl.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to count muscle groups: %w", err)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(defaultMuscleGroups))
+	for _, spec := range defaultMuscleGroups {
+		rows = append(rows, NewMuscleGroupRow(WithMuscleGroupRowRestoreSpec(spec)))
+	}
+
+	if _, err := m.muscleColl.InsertMany(ctx, rows); err != nil {
+		return fmt.Errorf("failed to insert muscle groups: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+const minSearchTermLength = 2
+
+var searchReplacer = strings.NewReplacer("ё", "е")
+
+// searchTerms строит префиксные n-граммы каждого слова названия: "Жим лежа" ->
+// [жи, жим, ле, леж, лежа]. По ним работает поиск по мере набора запроса.
+func searchTerms(name string) []string {
+	seen := make(map[string]struct{})
+	var terms []string
+
+	for _, word := range searchWords(name) {
+		runes := []rune(word)
+		for i := min(minSearchTermLength, len(runes)); i <= len(runes); i++ {
+			term := string(runes[:i])
+			if _, ok := seen[term]; ok {
+				continue
+			}
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+func searchWords(s string) []string {
+	s = searchReplacer.Replace(strings.ToLower(s))
+
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchExercises ищет упражнения, в названии которых есть слова запроса как префиксы слов.
+// Слова короче minSearchTermLength не учитываются, так как по ним нет n-грамм. Из упражнений
+// preferred возвращаются все совпавшие, из остальных - первые limit по алфавиту, чтобы
+// ранжирование по частоте использования не теряло нужные пользователю упражнения.
+func (m *mongodb) SearchExercises(ctx context.Context, userID string, query string, preferred []uuid.UUID, limit int64) ([]entity.Exercise, error) {
+	words := slices.DeleteFunc(searchWords(query), func(word string) bool {
+		return utf8.RuneCountInString(word) < minSearchTermLength
+	})
+	if len(words) == 0 {
+		return nil, errs.ErrSearchQueryTooShort
+	}
+
+	filter := bson.M{"search_terms": bson.M{"$all": words}, "owner_id": visibleToFilter(userID), "archived_at": nil}
+
+	var exercises []entity.Exercise
+	if len(preferred) > 0 {
+		ids := make(bson.A, 0, len(preferred))
+		for _, id := range preferred {
+			ids = append(ids, id.String())
+		}
+
+		preferredFilter := bson.M{"id": bson.M{"$in": ids}}
+		for k, v := range filter {
+			preferredFilter[k] = v
+		}
+
+		found, err := m.findExercises(ctx, preferredFilter, options.Find())
+		if err != nil {
+			return nil, err
+		}
+		exercises = found
+	}
+
+	found, err := m.findExercises(ctx, filter, options.Find().SetSort(bson.M{"name": 1}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exercise := range found {
+		if !slices.ContainsFunc(exercises, func(e entity.Exercise) bool { return e.ID() == exercise.ID() }) {
+			exercises = append(exercises, exercise)
+		}
+	}
+
+	return exercises, nil
+}
+
+// backfillSearchTerms заполняет search_terms у упражнений, добавленных до появления поиска.
+func (m *mongodb) backfillSearchTerms(ctx context.Context) error {
+	cursor, err := m.exerciseColl.Find(ctx, bson.M{"search_terms": bson.M{"$exists": false}})
+	if err != nil {
+		return fmt.Errorf("failed to find exercises without search terms: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	for cursor.Next(ctx) {
+		var row ExerciseRow
+		if err := cursor.Decode(&row); err != nil {
+			return fmt.Errorf("decode error: %w", err)
+		}
+
+		update := bson.M{"$set": bson.M{"search_terms": searchTerms(row.Name)}}
+		if _, err := m.exerciseColl.UpdateOne(ctx, bson.M{"id": row.ID}, update); err != nil {
+			return fmt.Errorf("failed to update search terms: %w", err)
+		}
+	}
+
+	return cursor.Err()
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,35 +10,183 @@ import (
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"gymnote/internal/entity"
+	"gymnote/internal/errs"
 )
 
-func (m *mongodb) InsertTrainingSession(ctx context.Context, req entity.TrainingSession) error {
-	row := NewTrainingSessionRow(WithTrainingSessionRowRestoreSpec(TrainingSessionRowRestoreSpecification{
+// SaveFinishedSession атомарно сохраняет завершенную тренировку. Тренировка и подходы
+// заменяются по ID, поэтому повторное сохранение той же тренировки не создает дублей.
+func (m *mongodb) SaveFinishedSession(ctx context.Context, req entity.TrainingSession) error {
+	err := m.withTransaction(ctx, func(ctx context.Context) error {
+		return m.upsertTrainingSession(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save finished session: %w", err)
+	}
+
+	return nil
+}
+
+// upsertTrainingSession заменяет тренировку и ее подходы по ID и удаляет подходы, которых в ней больше нет.
+func (m *mongodb) upsertTrainingSession(ctx context.Context, req entity.TrainingSession) error {
+	sessionID := req.ID().String()
+
+	if _, err := m.sessionColl.ReplaceOne(ctx, bson.M{"id": sessionID}, newTrainingSessionRow(req), options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to upsert training session: %w", err)
+	}
+
+	rows := newSetRows(req)
+	ids := make([]string, 0, len(rows))
+	models := make([]mongo.WriteModel, 0, len(rows))
+	for _, doc := range rows {
+		row := doc.(*SetRow)
+		ids = append(ids, row.ID)
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"id": row.ID}).SetReplacement(row).SetUpsert(true))
+	}
+
+	if _, err := m.logColl.DeleteMany(ctx, bson.M{"session_id": sessionID, "id": bson.M{"$nin": ids}}); err != nil {
+		return fmt.Errorf("failed to delete stale training logs: %w", err)
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	if _, err := m.logColl.BulkWrite(ctx, models); err != nil {
+		return fmt.Errorf("failed to upsert training logs: %w", err)
+	}
+
+	return nil
+}
+
+func newTrainingSessionRow(req entity.TrainingSession) *TrainingSessionRow {
+	return NewTrainingSessionRow(WithTrainingSessionRowRestoreSpec(TrainingSessionRowRestoreSpecification{
 		ID:        req.ID().String(),
 		UserID:    req.UserID(),
 		Date:      req.Date(),
 		Notes:     req.Notes(),
+		GymID:     gymIDString(req.GymID()),
 		CreatedAt: req.CreatedAt(),
-	}))
-
-	_, err := m.sessionColl.InsertOne(ctx, row)
-	if err != nil {
-		return fmt.Errorf("failed to insert training session: %w", err)
-	}
 
-	return nil
+		StartedAt:  req.StartedAt(),
+		FinishedAt: req.FinishedAt(),
+		Bodyweight: req.Bodyweight(),
+		Rating:     req.Rating(),
+	}))
 }
 
 func (m *mongodb) GetTrainingSessions(ctx context.Context, userID string, fromDate, toDate time.Time) ([]entity.TrainingSession, error) {
-	matchStage := bson.D{{Key: "$match", Value: bson.D{
+	return m.findTrainingSessions(ctx, bson.D{
 		{Key: "user_id", Value: userID},
 		{Key: "date", Value: bson.D{
 			{Key: "$gte", Value: fromDate},
 			{Key: "$lte", Value: toDate},
 		}},
-	}}}
+	})
+}
+
+func (m *mongodb) GetTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) (entity.TrainingSession, error) {
+	sessions, err := m.findTrainingSessions(ctx, bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "id", Value: sessionID.String()},
+	})
+	if err != nil {
+		return entity.TrainingSession{}, err
+	}
+
+	if len(sessions) == 0 {
+		return entity.TrainingSession{}, errs.ErrSessionNotFound
+	}
+
+	return sessions[0], nil
+}
+
+// GetTrainingSessionByID ищет тренировку без привязки к пользователю, доступ проверяет вызывающий.
+func (m *mongodb) GetTrainingSessionByID(ctx context.Context, sessionID uuid.UUID) (entity.TrainingSession, error) {
+	sessions, err := m.findTrainingSessions(ctx, bson.D{{Key: "id", Value: sessionID.String()}})
+	if err != nil {
+		return entity.TrainingSession{}, err
+	}
+
+	if len(sessions) == 0 {
+		return entity.TrainingSession{}, errs.ErrSessionNotFound
+	}
+
+	return sessions[0], nil
+}
+
+// UpdateTrainingSession атомарно перезаписывает тренировку пользователя вместе с подходами,
+// чтобы номера, дата и состав подходов совпадали с сессией.
+func (m *mongodb) UpdateTrainingSession(ctx context.Context, req entity.TrainingSession) error {
+	err := m.withTransaction(ctx, func(ctx context.Context) error {
+		err := m.sessionColl.FindOne(ctx, bson.M{"id": req.ID().String(), "user_id": req.UserID()}).Err()
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errs.ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return m.upsertTrainingSession(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update training session: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTrainingSessionDetails сохраняет вес тела, заметку и оценку, не трогая подходы.
+func (m *mongodb) UpdateTrainingSessionDetails(ctx context.Context, req entity.TrainingSession) error {
+	filter := bson.M{"id": req.ID().String(), "user_id": req.UserID()}
+	update := bson.M{"$set": bson.M{
+		"notes":      req.Notes(),
+		"bodyweight": req.Bodyweight(),
+		"rating":     req.Rating(),
+	}}
+
+	res, err := m.sessionColl.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update training session details: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// DeleteTrainingSession атомарно удаляет тренировку и ее подходы. Подходы удаляются первыми,
+// чтобы без транзакции сбой не оставил подходов без тренировки.
+func (m *mongodb) DeleteTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	err := m.withTransaction(ctx, func(ctx context.Context) error {
+		if _, err := m.logColl.DeleteMany(ctx, bson.M{"session_id": sessionID.String(), "user_id": userID}); err != nil {
+			return fmt.Errorf("failed to delete training logs: %w", err)
+		}
+
+		res, err := m.sessionColl.DeleteOne(ctx, bson.M{"id": sessionID.String(), "user_id": userID})
+		if err != nil {
+			return err
+		}
+
+		if res.DeletedCount == 0 {
+			return errs.ErrSessionNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete training session: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) findTrainingSessions(ctx context.Context, match bson.D) ([]entity.TrainingSession, error) {
+	matchStage := bson.D{{Key: "$match", Value: match}}
 
 	lookupStage := bson.D{{Key: "$lookup", Value: bson.D{
 		{Key: "from", Value: colLogs},
@@ -84,6 +233,7 @@ func (m *mongodb) GetTrainingSessions(ctx context.Context, userID string, fromDa
 
 	for _, raw := range rawSessions {
 		sessionID, _ := uuid.Parse(raw.ID)
+		gymID, _ := uuid.Parse(raw.GymID)
 
 		var exercises []*entity.SessionExercise
 		exercisesMap := make(map[string]*entity.SessionExercise)
@@ -122,6 +272,8 @@ func (m *mongodb) GetTrainingSessions(ctx context.Context, userID string, fromDa
 				Difficulty: log.Difficulty,
 				Notes:      log.Notes,
 				CreatedAt:  log.CreatedAt,
+
+				CompletedAt: log.CompletedAt,
 			}))
 
 			exercisesMap[exKey].AddSet(set)
@@ -138,7 +290,13 @@ func (m *mongodb) GetTrainingSessions(ctx context.Context, userID string, fromDa
 			Date:      raw.Date,
 			Exercises: sessionExercises,
 			Notes:     raw.Notes,
+			GymID:     gymID,
 			CreatedAt: raw.CreatedAt,
+
+			StartedAt:  raw.StartedAt,
+			FinishedAt: raw.FinishedAt,
+			Bodyweight: raw.Bodyweight,
+			Rating:     raw.Rating,
 		}))
 
 		sessions = append(sessions, session)
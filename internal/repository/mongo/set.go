@@ -13,7 +13,7 @@ import (
 	"gymnote/internal/entity"
 )
 
-func (m *mongodb) InsertTrainingLogs(ctx context.Context, req entity.TrainingSession) error {
+func newSetRows(req entity.TrainingSession) []any {
 	docsToWrite := make([]any, 0, req.SetCount())
 	for _, exs := range req.Exercises() {
 		for _, set := range exs.Sets() {
@@ -26,6 +26,7 @@ func (m *mongodb) InsertTrainingLogs(ctx context.Context, req entity.TrainingSes
 				ExerciseNumber: exs.Number(),
 				SetNumber:      set.Number(),
 				MuscleGroup:    exs.MuscleGroup(),
+				GymID:          gymIDString(req.GymID()),
 				ExerciseID:     set.ExerciseID().String(),
 				Number:         set.Number(),
 				Weight:         set.Weight(),
@@ -33,27 +34,30 @@ func (m *mongodb) InsertTrainingLogs(ctx context.Context, req entity.TrainingSes
 				Difficulty:     set.Difficulty(),
 				Notes:          set.Notes(),
 				CreatedAt:      set.CreatedAt(),
+				CompletedAt:    set.CompletedAt(),
 			})))
 		}
 	}
 
-	if _, err := m.logColl.InsertMany(ctx, docsToWrite); err != nil {
-		return fmt.Errorf("insert exercise error: %w", err)
-	}
-
-	return nil
+	return docsToWrite
 }
 
-func (m *mongodb) GetExerciseProgression(ctx context.Context, userID string, exerciseID uuid.UUID, fromDate, toDate time.Time) ([]entity.ExerciseProgression, error) {
+// GetExerciseProgression возвращает прогрессию упражнения, gymID ограничивает подходы залом, uuid.Nil - все залы.
+func (m *mongodb) GetExerciseProgression(ctx context.Context, userID string, exerciseID, gymID uuid.UUID, fromDate, toDate time.Time) ([]entity.ExerciseProgression, error) {
+	match := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "exercise_id", Value: exerciseID.String()},
+		{Key: "session_date", Value: bson.D{
+			{Key: "$gte", Value: fromDate},
+			{Key: "$lte", Value: toDate},
+		}},
+	}
+	if gymID != uuid.Nil {
+		match = append(match, bson.E{Key: "gym_id", Value: gymID.String()})
+	}
+
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.D{
-			{Key: "user_id", Value: userID},
-			{Key: "exercise_id", Value: exerciseID.String()},
-			{Key: "session_date", Value: bson.D{
-				{Key: "$gte", Value: fromDate},
-				{Key: "$lte", Value: toDate},
-			}},
-		}}},
+		{{Key: "$match", Value: match}},
 
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: bson.D{
@@ -109,12 +113,24 @@ func (m *mongodb) GetExerciseProgression(ctx context.Context, userID string, exe
 	return result, nil
 }
 
-func (m *mongodb) GetLastSetsForExercise(ctx context.Context, userID string, exerciseID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error) {
+// GetLastSetsForExercise возвращает подходы за последние limitDays тренировок, gymID ограничивает их залом, uuid.Nil - все залы.
+func (m *mongodb) GetLastSetsForExercise(ctx context.Context, userID string, exerciseID, gymID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error) {
+	match := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "exercise_id", Value: exerciseID.String()},
+	}
+	conditions := bson.A{
+		bson.D{{Key: "$eq", Value: bson.A{"$user_id", "$$user_id"}}},
+		bson.D{{Key: "$eq", Value: bson.A{"$exercise_id", "$$exercise_id"}}},
+		bson.D{{Key: "$in", Value: bson.A{"$session_date", "$$dates"}}},
+	}
+	if gymID != uuid.Nil {
+		match = append(match, bson.E{Key: "gym_id", Value: gymID.String()})
+		conditions = append(conditions, bson.D{{Key: "$eq", Value: bson.A{"$gym_id", gymID.String()}}})
+	}
+
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.D{
-			{Key: "user_id", Value: userID},
-			{Key: "exercise_id", Value: exerciseID.String()},
-		}}},
+		{{Key: "$match", Value: match}},
 
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: "$session_date"},
@@ -148,11 +164,7 @@ func (m *mongodb) GetLastSetsForExercise(ctx context.Context, userID string, exe
 
 					{Key: "$expr", Value: bson.D{
 
-						{Key: "$and", Value: bson.A{
-							bson.D{{Key: "$eq", Value: bson.A{"$user_id", "$$user_id"}}},
-							bson.D{{Key: "$eq", Value: bson.A{"$exercise_id", "$$exercise_id"}}},
-							bson.D{{Key: "$in", Value: bson.A{"$session_date", "$$dates"}}},
-						}},
+						{Key: "$and", Value: conditions},
 					}},
 				}}},
 
@@ -215,3 +227,117 @@ func (m *mongodb) GetLastSetsForExercise(ctx context.Context, userID string, exe
 
 	return result, nil
 }
+
+// GetUsedExerciseIDs возвращает упражнения, которые есть в тренировках пользователя.
+func (m *mongodb) GetUsedExerciseIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	var values []string
+	if err := m.logColl.Distinct(ctx, "exercise_id", bson.M{"user_id": userID}).Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to get used exercises: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		if id, err := uuid.Parse(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (m *mongodb) GetExerciseUsage(ctx context.Context, userID string, exerciseIDs []uuid.UUID) (map[uuid.UUID]entity.ExerciseUsage, error) {
+	ids := make(bson.A, 0, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		ids = append(ids, id.String())
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "user_id", Value: userID},
+			{Key: "exercise_id", Value: bson.D{{Key: "$in", Value: ids}}},
+		}}},
+
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "exercise_id", Value: "$exercise_id"},
+				{Key: "session_id", Value: "$session_id"},
+			}},
+			{Key: "session_date", Value: bson.D{{Key: "$max", Value: "$session_date"}}},
+		}}},
+
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.exercise_id"},
+			{Key: "sessions", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "last_used_at", Value: bson.D{{Key: "$max", Value: "$session_date"}}},
+		}}},
+	}
+
+	cursor, err := m.logColl.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate exercise usage: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	result := make(map[uuid.UUID]entity.ExerciseUsage, len(exerciseIDs))
+
+	for cursor.Next(ctx) {
+		var usage struct {
+			ExerciseID string    `bson:"_id"`
+			Sessions   int       `bson:"sessions"`
+			LastUsedAt time.Time `bson:"last_used_at"`
+		}
+
+		if err := cursor.Decode(&usage); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+
+		id, err := uuid.Parse(usage.ExerciseID)
+		if err != nil {
+			continue
+		}
+
+		result[id] = entity.ExerciseUsage{Sessions: usage.Sessions, LastUsedAt: usage.LastUsedAt}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return result, nil
+}
+
+// updateExerciseLogs обновляет денормализованные название и мышечную группу упражнения в подходах.
+func (m *mongodb) updateExerciseLogs(ctx context.Context, req entity.Exercise) error {
+	filter := bson.M{"exercise_id": req.ID().String()}
+	update := bson.M{"$set": bson.M{
+		"exercise_name": req.Name(),
+		"muscle_group":  req.MuscleGroup(),
+	}}
+
+	if _, err := m.logColl.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update exercise logs: %w", err)
+	}
+
+	return nil
+}
+
+// reassignExerciseLogs переносит все подходы упражнения sourceID на упражнение target.
+func (m *mongodb) reassignExerciseLogs(ctx context.Context, sourceID uuid.UUID, target entity.Exercise) error {
+	filter := bson.M{"exercise_id": sourceID.String()}
+	update := bson.M{"$set": bson.M{
+		"exercise_id":   target.ID().String(),
+		"exercise_name": target.Name(),
+		"muscle_group":  target.MuscleGroup(),
+	}}
+
+	if _, err := m.logColl.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to reassign exercise logs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) GetTechnique(ctx context.Context, exerciseID uuid.UUID) (entity.Technique, error) {
+	var row TechniqueRow
+	filter := bson.M{"exercise_id": exerciseID.String()}
+
+	err := m.techColl.FindOne(ctx, filter).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Technique{}, errs.ErrTechniqueNotFound
+		}
+		return entity.Technique{}, fmt.Errorf("failed to get technique: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+func (m *mongodb) SaveTechnique(ctx context.Context, req entity.Technique) error {
+	row := NewTechniqueRow(WithTechniqueRowRestoreSpec(TechniqueRowRestoreSpecification{
+		ExerciseID:  req.ExerciseID().String(),
+		Description: req.Description(),
+		Cues:        req.Cues(),
+		Mistakes:    req.Mistakes(),
+		Media:       req.Media(),
+		UpdatedAt:   req.UpdatedAt(),
+	}))
+
+	filter := bson.M{"exercise_id": row.ExerciseID}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := m.techColl.ReplaceOne(ctx, filter, row, opts); err != nil {
+		return fmt.Errorf("failed to save technique: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) ensureTechniqueIndexes(ctx context.Context) error {
+	_, err := m.techColl.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"exercise_id": 1},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return err
+}
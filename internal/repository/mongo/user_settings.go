@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) GetUserSettings(ctx context.Context, userID string) (entity.UserSettings, error) {
+	var row UserSettingsRow
+	filter := bson.M{"user_id": userID}
+
+	err := m.settingsColl.FindOne(ctx, filter).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.UserSettings{}, errs.ErrUserSettingsNotFound
+		}
+		return entity.UserSettings{}, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+func (m *mongodb) SaveUserSettings(ctx context.Context, req entity.UserSettings) error {
+	row := NewUserSettingsRow(WithUserSettingsRowRestoreSpec(UserSettingsRowRestoreSpecification{
+		UserID:            req.UserID(),
+		Locale:            req.Locale(),
+		FavoriteExercises: req.FavoriteExercises(),
+		GymID:             req.GymID(),
+		AnalyticsByGym:    req.AnalyticsByGym(),
+		IdleSessionAction: req.IdleSessionAction(),
+		UpdatedAt:         req.UpdatedAt(),
+	}))
+
+	filter := bson.M{"user_id": row.UserID}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := m.settingsColl.ReplaceOne(ctx, filter, row, opts); err != nil {
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (m *mongodb) SaveAssignedWorkout(ctx context.Context, req entity.AssignedWorkout) error {
+	row := newAssignedWorkoutRow(req)
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.workoutColl.ReplaceOne(ctx, bson.M{"id": row.ID}, row, opts); err != nil {
+		return fmt.Errorf("failed to save assigned workout: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) InsertAssignedWorkouts(ctx context.Context, workouts []entity.AssignedWorkout) error {
+	if len(workouts) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(workouts))
+	for _, workout := range workouts {
+		rows = append(rows, newAssignedWorkoutRow(workout))
+	}
+
+	if _, err := m.workoutColl.InsertMany(ctx, rows); err != nil {
+		return fmt.Errorf("failed to insert assigned workouts: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongodb) GetAssignedWorkout(ctx context.Context, id uuid.UUID) (entity.AssignedWorkout, error) {
+	var row AssignedWorkoutRow
+
+	err := m.workoutColl.FindOne(ctx, bson.M{"id": id.String()}).Decode(&row)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.AssignedWorkout{}, errs.ErrAssignedWorkoutNotFound
+		}
+		return entity.AssignedWorkout{}, fmt.Errorf("failed to get assigned workout: %w", err)
+	}
+
+	return *row.ToEntity(), nil
+}
+
+// GetUndeliveredWorkouts возвращает не начатые планы с датой до until, о которых спортсмен еще не знает.
+func (m *mongodb) GetUndeliveredWorkouts(ctx context.Context, until time.Time) ([]entity.AssignedWorkout, error) {
+	filter := bson.M{
+		"status":       string(entity.AssignedWorkoutPending),
+		"delivered_at": bson.M{"$exists": false},
+		"date":         bson.M{"$lt": until},
+	}
+	opts := options.Find().SetSort(bson.M{"date": 1})
+
+	cursor, err := m.workoutColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undelivered workouts: %w", err)
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("close cursor err: %v", err)
+		}
+	}()
+
+	var workouts []entity.AssignedWorkout
+	for cursor.Next(ctx) {
+		var row AssignedWorkoutRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+		workouts = append(workouts, *row.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return workouts, nil
+}
+
+func newAssignedWorkoutRow(req entity.AssignedWorkout) *AssignedWorkoutRow {
+	return NewAssignedWorkoutRow(WithAssignedWorkoutRowRestoreSpec(AssignedWorkoutRowRestoreSpecification{
+		ID:          req.ID().String(),
+		CoachID:     req.CoachID(),
+		CoachName:   req.CoachName(),
+		AthleteID:   req.AthleteID(),
+		Date:        req.Date(),
+		Exercises:   req.Exercises(),
+		Status:      string(req.Status()),
+		SessionID:   req.SessionID(),
+		CreatedAt:   req.CreatedAt(),
+		DeliveredAt: req.DeliveredAt(),
+		CompletedAt: req.CompletedAt(),
+	}))
+}
+
+func (m *mongodb) ensureWorkoutIndexes(ctx context.Context) error {
+	_, err := m.workoutColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"id": 1},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys:    bson.D{{Key: "athlete_id", Value: 1}, {Key: "date", Value: 1}},
+			Options: options.Index().SetUnique(false),
+		},
+	})
+
+	return err
+}
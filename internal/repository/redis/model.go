@@ -14,7 +14,20 @@ type TrainingSessionRow struct {
 	Date      time.Time            `json:"date"`
 	Exercises []SessionExerciseRow `json:"exercises"`
 	Notes     string               `json:"notes"`
+	GymID     uuid.UUID            `json:"gym_id,omitempty"`
 	CreatedAt time.Time            `json:"created_at"`
+	StartedAt time.Time            `json:"started_at,omitempty"`
+
+	LastActivityAt time.Time `json:"last_activity_at,omitempty"`
+	RemindedAt     time.Time `json:"reminded_at,omitempty"`
+
+	ActiveExerciseID uuid.UUID `json:"active_exercise_id,omitempty"`
+
+	Version uint64 `json:"version"`
+
+	DashboardMessageID int `json:"dashboard_message_id,omitempty"`
+
+	AssignmentID uuid.UUID `json:"assignment_id,omitempty"`
 }
 
 func (ts *TrainingSessionRow) ToEntity() *entity.TrainingSession {
@@ -22,6 +35,12 @@ func (ts *TrainingSessionRow) ToEntity() *entity.TrainingSession {
 	for _, exc := range ts.Exercises {
 		exercises = append(exercises, *exc.ToEntity())
 	}
+
+	// тренировки, сохраненные до выбора активного упражнения, продолжаются с последнего
+	activeExerciseID := ts.ActiveExerciseID
+	if activeExerciseID == uuid.Nil && len(exercises) > 0 {
+		activeExerciseID = exercises[len(exercises)-1].ID()
+	}
 	return entity.NewTrainingSession(entity.WithTrainingSessionRestoreSpec(
 		entity.TrainingSessionRestoreSpecification{
 			ID:        ts.ID,
@@ -29,7 +48,20 @@ func (ts *TrainingSessionRow) ToEntity() *entity.TrainingSession {
 			Date:      ts.Date,
 			Notes:     ts.Notes,
 			Exercises: exercises,
+			GymID:     ts.GymID,
 			CreatedAt: ts.CreatedAt,
+			StartedAt: ts.StartedAt,
+
+			LastActivityAt: ts.LastActivityAt,
+			RemindedAt:     ts.RemindedAt,
+
+			ActiveExerciseID: activeExerciseID,
+
+			Version: ts.Version,
+
+			DashboardMessageID: ts.DashboardMessageID,
+
+			AssignmentID: ts.AssignmentID,
 		},
 	))
 }
@@ -45,19 +77,34 @@ func NewTrainingSessionRow(session *entity.TrainingSession) *TrainingSessionRow
 		Date:      session.Date(),
 		Exercises: exercises,
 		Notes:     session.Notes(),
+		GymID:     session.GymID(),
 		CreatedAt: session.CreatedAt(),
+		StartedAt: session.StartedAt(),
+
+		LastActivityAt: session.LastActivityAt(),
+		RemindedAt:     session.RemindedAt(),
+
+		ActiveExerciseID: session.ActiveExerciseID(),
+
+		Version: session.Version(),
+
+		DashboardMessageID: session.DashboardMessageID(),
+
+		AssignmentID: session.AssignmentID(),
 	}
 }
 
 type SessionExerciseRow struct {
-	ID                  uuid.UUID `json:"id"`
-	ExerciseID          uuid.UUID `json:"exercise_id"`
-	ExerciseName        string    `json:"exercise_name"`
-	ExerciseMuscleGroup string    `json:"exercise_muscle_group"`
-	ExerciseEquipment   string    `json:"exercise_equipment"`
-	ExerciseCreatedAt   time.Time `json:"exercise_created_at"`
-	Number              uint8     `json:"number"`
-	Sets                []SetRow  `json:"sets"`
+	ID                  uuid.UUID            `json:"id"`
+	ExerciseID          uuid.UUID            `json:"exercise_id"`
+	ExerciseName        string               `json:"exercise_name"`
+	ExerciseMuscleGroup string               `json:"exercise_muscle_group"`
+	ExerciseSecondary   []SecondaryMuscleRow `json:"exercise_secondary_muscles,omitempty"`
+	ExerciseEquipment   string               `json:"exercise_equipment"`
+	ExerciseOwnerID     string               `json:"exercise_owner_id,omitempty"`
+	ExerciseCreatedAt   time.Time            `json:"exercise_created_at"`
+	Number              uint8                `json:"number"`
+	Sets                []SetRow             `json:"sets"`
 }
 
 func (e *SessionExerciseRow) ToEntity() *entity.SessionExercise {
@@ -65,17 +112,29 @@ func (e *SessionExerciseRow) ToEntity() *entity.SessionExercise {
 	for _, s := range e.Sets {
 		sets = append(sets, *s.ToEntity())
 	}
+	secondary := make([]entity.SecondaryMuscle, 0, len(e.ExerciseSecondary))
+	for _, muscle := range e.ExerciseSecondary {
+		secondary = append(secondary, entity.SecondaryMuscle{MuscleGroup: muscle.MuscleGroup, Weight: muscle.Weight})
+	}
+	// у упражнений, сохраненных до появления ID, он выдается при чтении
+	id := e.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
 	return entity.NewSessionExercise(
 		entity.NewExercise(entity.WithExerciseRestoreSpec(entity.ExerciseRestoreSpecification{
-			ID:          e.ExerciseID,
-			Name:        e.ExerciseName,
-			MuscleGroup: e.ExerciseMuscleGroup,
-			Equipment:   e.ExerciseEquipment,
-			CreatedAt:   e.ExerciseCreatedAt,
+			ID:               e.ExerciseID,
+			Name:             e.ExerciseName,
+			MuscleGroup:      e.ExerciseMuscleGroup,
+			SecondaryMuscles: secondary,
+			Equipment:        e.ExerciseEquipment,
+			OwnerID:          e.ExerciseOwnerID,
+			CreatedAt:        e.ExerciseCreatedAt,
 		})),
 		sets,
 		entity.WithSessionExerciseRestoreSpec(entity.SessionExerciseRestoreSpecification{
-			ID:     e.ID,
+			ID:     id,
 			Number: e.Number,
 		}))
 }
@@ -85,18 +144,29 @@ func NewSessionExerciseRow(exercise *entity.SessionExercise) *SessionExerciseRow
 	for _, s := range exercise.Sets() {
 		sets = append(sets, *NewSetRow(&s))
 	}
+	secondary := make([]SecondaryMuscleRow, 0, len(exercise.Exercise.SecondaryMuscles()))
+	for _, muscle := range exercise.Exercise.SecondaryMuscles() {
+		secondary = append(secondary, SecondaryMuscleRow{MuscleGroup: muscle.MuscleGroup, Weight: muscle.Weight})
+	}
 	return &SessionExerciseRow{
 		ID:                  exercise.ID(),
 		Number:              exercise.Number(),
 		ExerciseID:          exercise.Exercise.ID(),
 		ExerciseName:        exercise.Exercise.Name(),
 		ExerciseMuscleGroup: exercise.Exercise.MuscleGroup(),
+		ExerciseSecondary:   secondary,
 		ExerciseEquipment:   exercise.Exercise.Equipment(),
+		ExerciseOwnerID:     exercise.Exercise.OwnerID(),
 		ExerciseCreatedAt:   exercise.Exercise.CreatedAt(),
 		Sets:                sets,
 	}
 }
 
+type SecondaryMuscleRow struct {
+	MuscleGroup string  `json:"muscle_group"`
+	Weight      float32 `json:"weight"`
+}
+
 type SetRow struct {
 	ID         uuid.UUID `json:"id"`
 	UserID     string    `json:"user_id"`
@@ -108,6 +178,11 @@ type SetRow struct {
 	Notes      string    `json:"notes"`
 	MessageID  int       `json:"message_id"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+
+	PlannedWeight float32 `json:"planned_weight,omitempty"`
+	PlannedReps   uint8   `json:"planned_reps,omitempty"`
 }
 
 func (s *SetRow) ToEntity() *entity.Set {
@@ -122,6 +197,11 @@ func (s *SetRow) ToEntity() *entity.Set {
 		Notes:      s.Notes,
 		CreatedAt:  s.CreatedAt,
 		MessageID:  s.MessageID,
+
+		CompletedAt: s.CompletedAt,
+
+		PlannedWeight: s.PlannedWeight,
+		PlannedReps:   s.PlannedReps,
 	}))
 }
 
@@ -137,5 +217,116 @@ func NewSetRow(set *entity.Set) *SetRow {
 		Notes:      set.Notes(),
 		MessageID:  set.MessageID(),
 		CreatedAt:  set.CreatedAt(),
+
+		CompletedAt: set.CompletedAt(),
+
+		PlannedWeight: set.PlannedWeight(),
+		PlannedReps:   set.PlannedReps(),
+	}
+}
+
+type SharedSessionRow struct {
+	ID           uuid.UUID               `json:"id"`
+	OwnerID      string                  `json:"owner_id"`
+	Date         time.Time               `json:"date"`
+	Exercises    []SessionExerciseRow    `json:"exercises"`
+	Participants []SessionParticipantRow `json:"participants"`
+	CreatedAt    time.Time               `json:"created_at"`
+
+	Version uint64 `json:"version"`
+}
+
+func (ss *SharedSessionRow) ToEntity() *entity.SharedSession {
+	exercises := make([]entity.SessionExercise, 0, len(ss.Exercises))
+	for _, exc := range ss.Exercises {
+		exercises = append(exercises, *exc.ToEntity())
+	}
+	participants := make([]entity.SessionParticipant, 0, len(ss.Participants))
+	for _, p := range ss.Participants {
+		participants = append(participants, p.ToEntity())
+	}
+	return entity.NewSharedSession(entity.WithSharedSessionRestoreSpec(
+		entity.SharedSessionRestoreSpecification{
+			ID:           ss.ID,
+			OwnerID:      ss.OwnerID,
+			Date:         ss.Date,
+			Exercises:    exercises,
+			Participants: participants,
+			CreatedAt:    ss.CreatedAt,
+			Version:      ss.Version,
+		},
+	))
+}
+
+func NewSharedSessionRow(session *entity.SharedSession) *SharedSessionRow {
+	exercises := make([]SessionExerciseRow, 0, len(session.Exercises()))
+	for _, exc := range session.Exercises() {
+		exercises = append(exercises, *NewSessionExerciseRow(&exc))
+	}
+	participants := make([]SessionParticipantRow, 0, len(session.Participants()))
+	for _, p := range session.Participants() {
+		participants = append(participants, NewSessionParticipantRow(p))
+	}
+	return &SharedSessionRow{
+		ID:           session.ID(),
+		OwnerID:      session.OwnerID(),
+		Date:         session.Date(),
+		Exercises:    exercises,
+		Participants: participants,
+		CreatedAt:    session.CreatedAt(),
+
+		Version: session.Version(),
+	}
+}
+
+type SessionParticipantRow struct {
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	SessionID    uuid.UUID `json:"session_id"`
+	GymID        uuid.UUID `json:"gym_id,omitempty"`
+	AssignmentID uuid.UUID `json:"assignment_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	StartedAt    time.Time `json:"started_at,omitempty"`
+
+	LastActivityAt time.Time `json:"last_activity_at,omitempty"`
+	RemindedAt     time.Time `json:"reminded_at,omitempty"`
+
+	ActiveExerciseID   uuid.UUID `json:"active_exercise_id,omitempty"`
+	DashboardMessageID int       `json:"dashboard_message_id,omitempty"`
+}
+
+func (p *SessionParticipantRow) ToEntity() entity.SessionParticipant {
+	return entity.SessionParticipant{
+		UserID:       p.UserID,
+		Name:         p.Name,
+		SessionID:    p.SessionID,
+		GymID:        p.GymID,
+		AssignmentID: p.AssignmentID,
+		CreatedAt:    p.CreatedAt,
+		StartedAt:    p.StartedAt,
+
+		LastActivityAt: p.LastActivityAt,
+		RemindedAt:     p.RemindedAt,
+
+		ActiveExerciseID:   p.ActiveExerciseID,
+		DashboardMessageID: p.DashboardMessageID,
+	}
+}
+
+func NewSessionParticipantRow(p entity.SessionParticipant) SessionParticipantRow {
+	return SessionParticipantRow{
+		UserID:       p.UserID,
+		Name:         p.Name,
+		SessionID:    p.SessionID,
+		GymID:        p.GymID,
+		AssignmentID: p.AssignmentID,
+		CreatedAt:    p.CreatedAt,
+		StartedAt:    p.StartedAt,
+
+		LastActivityAt: p.LastActivityAt,
+		RemindedAt:     p.RemindedAt,
+
+		ActiveExerciseID:   p.ActiveExerciseID,
+		DashboardMessageID: p.DashboardMessageID,
 	}
 }
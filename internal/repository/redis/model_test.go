@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// legacySession тренировка в кэше до появления ID упражнений и активного упражнения.
+const legacySession = `{
+	"id": "0b6f2f4e-5d3c-4b8e-9a51-7f1d2c3b4a5e",
+	"user_id": "1",
+	"date": "2025-01-18T10:00:00Z",
+	"exercises": [
+		{"exercise_id": "4c1e9a0b-7d2f-4e3a-8b6c-5a9d0e1f2b3c", "exercise_name": "Жим лежа", "number": 1, "sets": []},
+		{"exercise_id": "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b", "exercise_name": "Присед", "number": 2, "sets": []}
+	],
+	"version": 3
+}`
+
+func TestLegacySessionRowToEntity(t *testing.T) {
+	var row TrainingSessionRow
+	if err := json.Unmarshal([]byte(legacySession), &row); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	session := row.ToEntity()
+
+	exercises := session.Exercises()
+	if exercises[0].ID() == uuid.Nil || exercises[1].ID() == uuid.Nil || exercises[0].ID() == exercises[1].ID() {
+		t.Fatalf("exercise ids = %v, %v, want distinct non-nil ids", exercises[0].ID(), exercises[1].ID())
+	}
+
+	if active := session.ActiveExercise(); active == nil || active.Number() != 2 {
+		t.Fatalf("active exercise = %v, want the last one", active)
+	}
+
+	if err := session.SelectExercise(1); err != nil {
+		t.Fatalf("select exercise: %v", err)
+	}
+	if active := session.ActiveExercise(); active.Number() != 1 {
+		t.Fatalf("active exercise number = %d after select, want 1", active.Number())
+	}
+}
@@ -3,28 +3,90 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 
 	"gymnote/internal/entity"
+	"gymnote/internal/errs"
 )
 
-const KEY_PREFIX = "training:"
+const (
+	KEY_PREFIX = "training:"
 
+	scanBatchSize = 100
+)
+
+// saveSessionScript записывает тренировку, только если версия в кэше совпадает с ожидаемой.
+// Отсутствующая тренировка имеет версию 0, новая не создается, пока пользователь участвует
+// в совместной тренировке (KEYS[2]).
+var saveSessionScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+local version = 0
+if current then
+	version = tonumber(cjson.decode(current)['version']) or 0
+end
+if version ~= tonumber(ARGV[1]) then
+	return 0
+end
+if version == 0 and redis.call('EXISTS', KEYS[2]) == 1 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2])
+return 1
+`)
+
+// deleteSessionScript удаляет тренировку, только если версия в кэше совпадает с ожидаемой.
+var deleteSessionScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return 1
+end
+if (tonumber(cjson.decode(current)['version']) or 0) ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// SaveSession сохраняет тренировку со следующей версией. Если тренировку успели изменить
+// или удалить после чтения либо пользователь вступил в совместную, возвращается ErrSessionConflict.
 func (r *cache) SaveSession(ctx context.Context, session *entity.TrainingSession) error {
-	data, err := json.Marshal(NewTrainingSessionRow(session))
+	if session.SharedSessionID() != uuid.Nil {
+		return r.saveSharedSessionView(ctx, session)
+	}
+
+	row := NewTrainingSessionRow(session)
+	row.Version = session.Version() + 1
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	saved, err := saveSessionScript.Run(ctx, r.redisClient, []string{
+		KEY_PREFIX + session.UserID(),
+		SHARED_MEMBER_KEY_PREFIX + session.UserID(),
+	}, session.Version(), data).Int()
 	if err != nil {
 		return err
 	}
+	if saved == 0 {
+		return errs.ErrSessionConflict
+	}
 
-	return r.redisClient.Set(ctx, KEY_PREFIX+session.UserID(), data, 0).Err()
+	session.SetVersion(row.Version)
+
+	return nil
 }
 
+// GetSession возвращает текущую тренировку пользователя, в совместной тренировке - его часть.
 func (r *cache) GetSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
 	data, err := r.redisClient.Get(ctx, KEY_PREFIX+userID).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, nil
+			return r.getSharedSessionView(ctx, userID)
 		}
 		return nil, err
 	}
@@ -38,6 +100,55 @@ func (r *cache) GetSession(ctx context.Context, userID string) (*entity.Training
 	return session.ToEntity(), nil
 }
 
-func (r *cache) DeleteSession(ctx context.Context, userID string) error {
-	return r.redisClient.Del(ctx, KEY_PREFIX+userID).Err()
+// GetSessions возвращает все текущие тренировки, включая тренировки участников совместных,
+// ключи перебираются через SCAN.
+func (r *cache) GetSessions(ctx context.Context) ([]*entity.TrainingSession, error) {
+	var sessions []*entity.TrainingSession
+
+	iter := r.redisClient.Scan(ctx, 0, KEY_PREFIX+"*", scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		session, err := r.GetSession(ctx, strings.TrimPrefix(iter.Val(), KEY_PREFIX))
+		if err != nil {
+			return nil, err
+		}
+		if session != nil {
+			sessions = append(sessions, session)
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	shared, err := r.getSharedSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sharedSession := range shared {
+		for _, participant := range sharedSession.Participants() {
+			if view, ok := sharedSession.View(participant.UserID); ok {
+				sessions = append(sessions, view)
+			}
+		}
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession удаляет прочитанную версию тренировки, из совместной тренировки уходит только
+// участник. Если тренировку успели изменить, возвращается ErrSessionConflict.
+func (r *cache) DeleteSession(ctx context.Context, session *entity.TrainingSession) error {
+	if session.SharedSessionID() != uuid.Nil {
+		return r.deleteSharedSessionView(ctx, session)
+	}
+
+	deleted, err := deleteSessionScript.Run(ctx, r.redisClient, []string{KEY_PREFIX + session.UserID()}, session.Version()).Int()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errs.ErrSessionConflict
+	}
+
+	return nil
 }
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func newTestSession(userID string) *entity.TrainingSession {
+	return entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(entity.TrainingSessionInitSpecification{
+		UserID: userID,
+		Date:   time.Now(),
+	}))
+}
+
+func TestSaveSessionWhileInSharedSession(t *testing.T) {
+	const (
+		ownerID   = "1"
+		partnerID = "2"
+	)
+
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	c := &cache{redisClient: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	t.Cleanup(func() { c.Close(ctx) })
+
+	session := newTestSession(ownerID)
+	if err := c.SaveSession(ctx, session); err != nil {
+		t.Fatalf("save owner session: %v", err)
+	}
+
+	shared := entity.NewSharedSession(entity.WithSharedSessionInitSpec(entity.SharedSessionInitSpecification{
+		Session:   session,
+		OwnerName: "Owner",
+	}))
+	if err := c.CreateSharedSession(ctx, shared, session); err != nil {
+		t.Fatalf("create shared session: %v", err)
+	}
+	if _, err := shared.Join(partnerID, "Partner", uuid.Nil, time.Now()); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	if err := c.JoinSharedSession(ctx, shared, partnerID); err != nil {
+		t.Fatalf("save joined shared session: %v", err)
+	}
+
+	// новая тренировка, прочитанная до вступления, не должна затереть участие в совместной
+	for _, userID := range []string{ownerID, partnerID} {
+		if err := c.SaveSession(ctx, newTestSession(userID)); !errors.Is(err, errs.ErrSessionConflict) {
+			t.Errorf("user %s: SaveSession error = %v, want %v", userID, err, errs.ErrSessionConflict)
+		}
+		if mr.Exists(KEY_PREFIX + userID) {
+			t.Errorf("user %s: personal session is saved next to the shared one", userID)
+		}
+
+		view, err := c.GetSession(ctx, userID)
+		if err != nil {
+			t.Fatalf("user %s: get session: %v", userID, err)
+		}
+		if view == nil || view.SharedSessionID() != shared.ID() {
+			t.Errorf("user %s: session is not a view of the shared session", userID)
+		}
+	}
+
+	if err := c.SaveSession(ctx, newTestSession("3")); err != nil {
+		t.Errorf("save session of user outside shared session: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+const (
+	SHARED_KEY_PREFIX = "shared_training:"
+	// SHARED_MEMBER_KEY_PREFIX ссылка участника на совместную тренировку, в которой он тренируется
+	SHARED_MEMBER_KEY_PREFIX = "shared_member:"
+)
+
+// CreateSharedSession превращает текущую тренировку владельца в совместную. Если тренировку
+// успели изменить после чтения, возвращается ErrSessionConflict.
+func (r *cache) CreateSharedSession(ctx context.Context, shared *entity.SharedSession, session *entity.TrainingSession) error {
+	sessionKey := KEY_PREFIX + session.UserID()
+	sharedKey := SHARED_KEY_PREFIX + shared.ID().String()
+
+	row := NewSharedSessionRow(shared)
+	row.Version = shared.Version() + 1
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	err = r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		version, err := storedVersion(ctx, tx, sessionKey)
+		if err != nil {
+			return err
+		}
+		if version != session.Version() {
+			return errs.ErrSessionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, sessionKey)
+			pipe.Set(ctx, sharedKey, data, 0)
+			pipe.Set(ctx, SHARED_MEMBER_KEY_PREFIX+session.UserID(), shared.ID().String(), 0)
+			return nil
+		})
+		return err
+	}, sessionKey)
+	if errors.Is(err, redis.TxFailedErr) {
+		return errs.ErrSessionConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	shared.SetVersion(row.Version)
+
+	return nil
+}
+
+func (r *cache) GetSharedSession(ctx context.Context, id uuid.UUID) (*entity.SharedSession, error) {
+	data, err := r.redisClient.Get(ctx, SHARED_KEY_PREFIX+id.String()).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var session SharedSessionRow
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+
+	return session.ToEntity(), nil
+}
+
+// SaveSharedSession сохраняет совместную тренировку со следующей версией. Если ее успели
+// изменить после чтения, возвращается ErrSessionConflict.
+func (r *cache) SaveSharedSession(ctx context.Context, shared *entity.SharedSession) error {
+	return r.casSharedSession(ctx, shared, shared.Version())
+}
+
+// getSharedSessionView возвращает тренировку пользователя из совместной, nil если он в ней не участвует.
+func (r *cache) getSharedSessionView(ctx context.Context, userID string) (*entity.TrainingSession, error) {
+	sharedID, err := r.redisClient.Get(ctx, SHARED_MEMBER_KEY_PREFIX+userID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	id, err := uuid.Parse(sharedID)
+	if err != nil {
+		return nil, nil
+	}
+
+	shared, err := r.GetSharedSession(ctx, id)
+	if err != nil || shared == nil {
+		return nil, err
+	}
+
+	view, ok := shared.View(userID)
+	if !ok {
+		return nil, nil
+	}
+
+	return view, nil
+}
+
+// saveSharedSessionView переносит изменения тренировки участника в совместную тренировку.
+func (r *cache) saveSharedSessionView(ctx context.Context, view *entity.TrainingSession) error {
+	shared, err := r.readSharedSession(ctx, view)
+	if err != nil {
+		return err
+	}
+
+	if !shared.Merge(view) {
+		return errs.ErrSessionConflict
+	}
+
+	if err := r.casSharedSession(ctx, shared, view.Version()); err != nil {
+		return err
+	}
+
+	view.SetVersion(shared.Version())
+
+	return nil
+}
+
+// deleteSharedSessionView убирает участника из совместной тренировки, последний участник удаляет ее.
+func (r *cache) deleteSharedSessionView(ctx context.Context, view *entity.TrainingSession) error {
+	shared, err := r.readSharedSession(ctx, view)
+	if err != nil {
+		return err
+	}
+
+	shared.Leave(view.UserID())
+
+	return r.casSharedSession(ctx, shared, view.Version(), view.UserID())
+}
+
+// readSharedSession читает совместную тренировку той версии, из которой собрана тренировка участника.
+func (r *cache) readSharedSession(ctx context.Context, view *entity.TrainingSession) (*entity.SharedSession, error) {
+	shared, err := r.GetSharedSession(ctx, view.SharedSessionID())
+	if err != nil {
+		return nil, err
+	}
+	if shared == nil || shared.Version() != view.Version() {
+		return nil, errs.ErrSessionConflict
+	}
+
+	return shared, nil
+}
+
+// JoinSharedSession сохраняет совместную тренировку с новым участником userID. Проверка, что у
+// участника нет своей тренировки, делается в той же транзакции, что и запись: если тренировка
+// появилась, возвращается ErrTrainingStarted.
+func (r *cache) JoinSharedSession(ctx context.Context, shared *entity.SharedSession, userID string) error {
+	sessionKey := KEY_PREFIX + userID
+	memberKey := SHARED_MEMBER_KEY_PREFIX + userID
+
+	return r.casSharedSessionIf(ctx, shared, shared.Version(), func(tx *redis.Tx) error {
+		exists, err := tx.Exists(ctx, sessionKey).Result()
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			return errs.ErrTrainingStarted
+		}
+
+		sharedID, err := tx.Get(ctx, memberKey).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if sharedID != "" && sharedID != shared.ID().String() {
+			return errs.ErrTrainingStarted
+		}
+
+		return nil
+	}, []string{sessionKey, memberKey})
+}
+
+// casSharedSession записывает совместную тренировку, только если версия в кэше совпадает
+// с expected. Тренировка без участников удаляется, ссылки left участников удаляются.
+func (r *cache) casSharedSession(ctx context.Context, shared *entity.SharedSession, expected uint64, left ...string) error {
+	return r.casSharedSessionIf(ctx, shared, expected, nil, nil, left...)
+}
+
+// casSharedSessionIf работает как casSharedSession, дополнительно проверяя check под WATCH
+// ключей совместной тренировки и watched.
+func (r *cache) casSharedSessionIf(ctx context.Context, shared *entity.SharedSession, expected uint64, check func(tx *redis.Tx) error, watched []string, left ...string) error {
+	key := SHARED_KEY_PREFIX + shared.ID().String()
+
+	row := NewSharedSessionRow(shared)
+	row.Version = expected + 1
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	err = r.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		version, err := storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if version != expected {
+			return errs.ErrSessionConflict
+		}
+
+		if check != nil {
+			if err := check(tx); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if shared.IsEmpty() {
+				pipe.Del(ctx, key)
+			} else {
+				pipe.Set(ctx, key, data, 0)
+			}
+			for _, participant := range shared.Participants() {
+				pipe.Set(ctx, SHARED_MEMBER_KEY_PREFIX+participant.UserID, shared.ID().String(), 0)
+			}
+			for _, userID := range left {
+				pipe.Del(ctx, SHARED_MEMBER_KEY_PREFIX+userID)
+			}
+			return nil
+		})
+		return err
+	}, append([]string{key}, watched...)...)
+	if errors.Is(err, redis.TxFailedErr) {
+		return errs.ErrSessionConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	shared.SetVersion(row.Version)
+
+	return nil
+}
+
+// getSharedSessions возвращает все совместные тренировки, ключи перебираются через SCAN.
+func (r *cache) getSharedSessions(ctx context.Context) ([]*entity.SharedSession, error) {
+	var sessions []*entity.SharedSession
+
+	iter := r.redisClient.Scan(ctx, 0, SHARED_KEY_PREFIX+"*", scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		data, err := r.redisClient.Get(ctx, iter.Val()).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var row SharedSessionRow
+		if err := json.Unmarshal([]byte(data), &row); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, row.ToEntity())
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// storedVersion версия записи в кэше, отсутствующая запись имеет версию 0.
+func storedVersion(ctx context.Context, tx *redis.Tx, key string) (uint64, error) {
+	data, err := tx.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var row struct {
+		Version uint64 `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		return 0, err
+	}
+
+	return row.Version, nil
+}
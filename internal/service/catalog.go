@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (s *service) GetExercise(ctx context.Context, exerciseID uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := s.db.GetExerciseByID(ctx, exerciseID)
+	if err != nil {
+		log.Printf("Error getting exercise by ID '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	return &exercise, nil
+}
+
+// RenameExercise меняет название упражнения вместе с названием в уже записанных подходах.
+func (s *service) RenameExercise(ctx context.Context, actorID string, exerciseID uuid.UUID, name string) (*entity.Exercise, error) {
+	exercise, err := s.getActiveExercise(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.db.GetExerciseByName(ctx, exercise.OwnerID(), name)
+	if err == nil && existing.ID() != exercise.ID() {
+		log.Printf("Exercise '%s' already exists\n", name)
+		return nil, errs.ErrExerciseAlreadyExists
+	}
+	if err != nil && !errors.Is(err, errs.ErrExerciseNotFound) {
+		log.Printf("Error checking existing exercise: %v\n", err)
+		return nil, fmt.Errorf("failed to check existing exercise: %w", err)
+	}
+
+	before := exercise.Name()
+	exercise.Rename(name)
+
+	if err := s.saveExerciseWithLogs(ctx, exercise); err != nil {
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, exercise.ID(), entity.ExerciseAuditRename, before, name)
+
+	return exercise, nil
+}
+
+func (s *service) ChangeExerciseMuscleGroup(ctx context.Context, actorID string, exerciseID uuid.UUID, muscleGroup string) (*entity.Exercise, error) {
+	exercise, err := s.getActiveExercise(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateMuscleGroups(ctx, muscleGroup); err != nil {
+		return nil, err
+	}
+
+	before := exercise.MuscleGroup()
+	exercise.SetMuscleGroup(muscleGroup)
+
+	if err := s.saveExerciseWithLogs(ctx, exercise); err != nil {
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, exercise.ID(), entity.ExerciseAuditMuscleGroup, before, muscleGroup)
+
+	return exercise, nil
+}
+
+func (s *service) ChangeExerciseEquipment(ctx context.Context, actorID string, exerciseID uuid.UUID, equipment string) (*entity.Exercise, error) {
+	exercise, err := s.getActiveExercise(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := exercise.Equipment()
+	exercise.SetEquipment(equipment)
+
+	if err := s.db.UpdateExercise(ctx, *exercise); err != nil {
+		log.Printf("Error updating exercise '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, exercise.ID(), entity.ExerciseAuditEquipment, before, equipment)
+
+	return exercise, nil
+}
+
+// ArchiveExercise скрывает упражнение из клавиатур и поиска, история подходов сохраняется.
+func (s *service) ArchiveExercise(ctx context.Context, actorID string, exerciseID uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := s.getActiveExercise(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercise.Archive()
+
+	if err := s.db.UpdateExercise(ctx, *exercise); err != nil {
+		log.Printf("Error archiving exercise '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, exercise.ID(), entity.ExerciseAuditArchive, exercise.Name(), "")
+
+	return exercise, nil
+}
+
+// MergeExercises переносит историю и ссылки на дубликат sourceID на targetID и архивирует дубликат.
+func (s *service) MergeExercises(ctx context.Context, actorID string, sourceID, targetID uuid.UUID) (*entity.Exercise, error) {
+	if sourceID == targetID {
+		return nil, errs.ErrInvalidExerciseMerge
+	}
+
+	source, err := s.getActiveExercise(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getActiveExercise(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !target.IsVisibleTo(source.OwnerID()) {
+		return nil, errs.ErrInvalidExerciseMerge
+	}
+
+	source.Archive()
+
+	// залы фильтруют упражнения по оборудованию, поэтому оборудование дубликата не теряется,
+	// если у упражнения оно не указано
+	if target.Equipment() == "" {
+		target.SetEquipment(source.Equipment())
+	}
+
+	if err := s.db.MergeExercises(ctx, *source, *target); err != nil {
+		log.Printf("Error merging exercise '%v' into '%v': %v\n", sourceID, targetID, err)
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, sourceID, entity.ExerciseAuditMerge, source.Name(), targetID.String())
+
+	return target, nil
+}
+
+func (s *service) getActiveExercise(ctx context.Context, exerciseID uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := s.GetExercise(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exercise.IsArchived() {
+		return nil, errs.ErrExerciseArchived
+	}
+
+	return exercise, nil
+}
+
+func (s *service) saveExerciseWithLogs(ctx context.Context, exercise *entity.Exercise) error {
+	if err := s.db.UpdateExerciseWithLogs(ctx, *exercise); err != nil {
+		log.Printf("Error updating exercise '%v' with logs: %v\n", exercise.ID(), err)
+		return err
+	}
+
+	return nil
+}
+
+// auditExercise записывает изменение в журнал. Само изменение к этому моменту уже сохранено,
+// поэтому ошибка записи журнала только логируется.
+func (s *service) auditExercise(ctx context.Context, actorID string, exerciseID uuid.UUID, action entity.ExerciseAuditAction, before, after string) {
+	audit := entity.NewExerciseAudit(entity.WithExerciseAuditInitSpec(entity.ExerciseAuditInitSpecification{
+		ExerciseID: exerciseID,
+		ActorID:    actorID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}))
+
+	if err := s.db.InsertExerciseAudit(ctx, *audit); err != nil {
+		log.Printf("Error writing audit for exercise '%v': %v\n", exerciseID, err)
+	}
+}
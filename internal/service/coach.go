@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+const maxCommentLength = 1000
+
+// CreateCoachInvite создает приглашение, по которому спортсмен открывает тренеру доступ.
+func (s *service) CreateCoachInvite(ctx context.Context, coachID, coachName string) (*entity.CoachLink, error) {
+	link := entity.NewCoachLink(entity.WithCoachLinkInitSpec(entity.CoachLinkInitSpecification{
+		CoachID:   coachID,
+		CoachName: coachName,
+	}))
+
+	if err := s.db.SaveCoachLink(ctx, *link); err != nil {
+		log.Printf("Error saving coach invite for user '%s': %v\n", coachID, err)
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// GetCoachInvite возвращает приглашение, на которое спортсмен еще не ответил.
+func (s *service) GetCoachInvite(ctx context.Context, inviteID uuid.UUID) (*entity.CoachLink, error) {
+	link, err := s.db.GetCoachLink(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.Status() != entity.CoachLinkPending {
+		return nil, errs.ErrCoachLinkNotFound
+	}
+
+	return &link, nil
+}
+
+// AcceptCoachInvite фиксирует согласие спортсмена, приглашение используется один раз.
+func (s *service) AcceptCoachInvite(ctx context.Context, inviteID uuid.UUID, athleteID, athleteName string) (*entity.CoachLink, error) {
+	link, err := s.GetCoachInvite(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.CoachID() == athleteID {
+		return nil, errs.ErrCoachSelfLink
+	}
+
+	if _, err := s.db.GetActiveCoachLink(ctx, link.CoachID(), athleteID); err == nil {
+		return nil, errs.ErrCoachLinkExists
+	} else if !errors.Is(err, errs.ErrCoachLinkNotFound) {
+		return nil, err
+	}
+
+	link.Accept(athleteID, athleteName, time.Now())
+
+	if err := s.db.SaveCoachLink(ctx, *link); err != nil {
+		log.Printf("Error accepting coach invite '%s': %v\n", inviteID, err)
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// RevokeCoachLink отзывает доступ, сделать это может и тренер, и спортсмен.
+func (s *service) RevokeCoachLink(ctx context.Context, userID string, linkID uuid.UUID) (*entity.CoachLink, error) {
+	link, err := s.db.GetCoachLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !link.IsActive() || !link.IsParticipant(userID) {
+		return nil, errs.ErrCoachLinkNotFound
+	}
+
+	link.Revoke(time.Now())
+
+	if err := s.db.SaveCoachLink(ctx, link); err != nil {
+		log.Printf("Error revoking coach link '%s': %v\n", linkID, err)
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// GetCoachLinks возвращает действующие связи, где пользователь тренер или спортсмен.
+func (s *service) GetCoachLinks(ctx context.Context, userID string) ([]entity.CoachLink, error) {
+	return s.db.GetActiveCoachLinks(ctx, userID)
+}
+
+func (s *service) GetAthleteTrainingSessions(ctx context.Context, coachID, athleteID string, fromDate, toDate *time.Time) ([]entity.TrainingSession, error) {
+	if _, err := s.db.GetActiveCoachLink(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	return s.GetTrainingSessions(ctx, athleteID, fromDate, toDate)
+}
+
+func (s *service) GetAthleteExerciseProgression(ctx context.Context, coachID, athleteID string, exerciseID uuid.UUID) ([]entity.ExerciseProgression, error) {
+	if _, err := s.db.GetActiveCoachLink(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	return s.GetExerciseProgression(ctx, athleteID, exerciseID)
+}
+
+// GetAthleteTrainingSession возвращает тренировку спортсмена, если у тренера есть к нему доступ.
+func (s *service) GetAthleteTrainingSession(ctx context.Context, coachID string, sessionID uuid.UUID) (*entity.TrainingSession, error) {
+	session, err := s.db.GetTrainingSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.GetActiveCoachLink(ctx, coachID, session.UserID()); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// AddSessionComment сохраняет комментарий тренера к тренировке спортсмена.
+func (s *service) AddSessionComment(ctx context.Context, coachID string, sessionID uuid.UUID, text string) (*entity.SessionComment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxCommentLength {
+		return nil, errs.ErrInvalidComment
+	}
+
+	session, err := s.db.GetTrainingSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.db.GetActiveCoachLink(ctx, coachID, session.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	comment := entity.NewSessionComment(entity.WithSessionCommentInitSpec(entity.SessionCommentInitSpecification{
+		SessionID: sessionID,
+		AthleteID: session.UserID(),
+		CoachID:   coachID,
+		CoachName: link.CoachName(),
+		Text:      text,
+	}))
+
+	if err := s.db.InsertSessionComment(ctx, *comment); err != nil {
+		log.Printf("Error saving comment for session '%s': %v\n", sessionID, err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetSessionComments возвращает комментарии к тренировке пользователя или его спортсмена.
+func (s *service) GetSessionComments(ctx context.Context, userID string, sessionID uuid.UUID) ([]entity.SessionComment, error) {
+	session, err := s.db.GetTrainingSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.UserID() != userID {
+		if _, err := s.db.GetActiveCoachLink(ctx, userID, session.UserID()); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.db.GetSessionComments(ctx, sessionID)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+)
+
+// usageHalfLife через сколько дней без упражнения его частота учитывается вдвое слабее.
+const usageHalfLife = 14 * 24 * time.Hour
+
+func (s *service) GetFavoriteExercises(ctx context.Context, userID string) ([]entity.Exercise, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites := settings.FavoriteExercises()
+	if len(favorites) == 0 {
+		return nil, nil
+	}
+
+	exercises, err := s.db.GetExercisesByIDs(ctx, favorites)
+	if err != nil {
+		log.Printf("Error retrieving favorite exercises for user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	exercises = slices.DeleteFunc(exercises, func(e entity.Exercise) bool {
+		return e.IsArchived() || !e.IsVisibleTo(userID)
+	})
+
+	sort.SliceStable(exercises, func(i, j int) bool {
+		return slices.Index(favorites, exercises[i].ID()) < slices.Index(favorites, exercises[j].ID())
+	})
+
+	return exercises, nil
+}
+
+// ToggleFavoriteExercise закрепляет упражнение или снимает закрепление и возвращает новое состояние.
+func (s *service) ToggleFavoriteExercise(ctx context.Context, userID string, exerciseID uuid.UUID) (bool, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	pinned := !settings.IsFavorite(exerciseID)
+	if pinned {
+		settings.PinExercise(exerciseID)
+	} else {
+		settings.UnpinExercise(exerciseID)
+	}
+
+	if err := s.db.SaveUserSettings(ctx, *settings); err != nil {
+		log.Printf("Error saving favorites for user '%s': %v\n", userID, err)
+		return false, err
+	}
+
+	return pinned, nil
+}
+
+// orderExercises ставит первыми закрепленные упражнения, затем выполнявшиеся - по частоте
+// с поправкой на давность, затем остальные в исходном порядке.
+func (s *service) orderExercises(ctx context.Context, userID string, exercises []entity.Exercise) ([]entity.Exercise, error) {
+	if len(exercises) == 0 {
+		return exercises, nil
+	}
+
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(exercises))
+	for _, exercise := range exercises {
+		ids = append(ids, exercise.ID())
+	}
+
+	usage, err := s.db.GetExerciseUsage(ctx, userID, ids)
+	if err != nil {
+		log.Printf("Error retrieving exercise usage for user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	favorites := settings.FavoriteExercises()
+	now := time.Now()
+
+	score := func(id uuid.UUID) float64 {
+		u, ok := usage[id]
+		if !ok {
+			return 0
+		}
+		age := now.Sub(u.LastUsedAt)
+		return float64(u.Sessions) / (1 + age.Hours()/usageHalfLife.Hours())
+	}
+
+	sort.SliceStable(exercises, func(i, j int) bool {
+		fi := slices.Index(favorites, exercises[i].ID())
+		fj := slices.Index(favorites, exercises[j].ID())
+		switch {
+		case fi >= 0 && fj >= 0:
+			return fi < fj
+		case fi >= 0 || fj >= 0:
+			return fi >= 0
+		}
+
+		return score(exercises[i].ID()) > score(exercises[j].ID())
+	})
+
+	return exercises, nil
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/onerm"
+)
+
+const (
+	// leaderboardLookbackDays насколько далеко ищем последний известный вес тела участника.
+	leaderboardLookbackDays = 90
+	// personalRecordLookbackDays с какими тренировками сравниваем новый результат.
+	personalRecordLookbackDays = 365
+)
+
+// JoinGroup добавляет пользователя в рейтинги группы, группа создается при первом вступлении.
+func (s *service) JoinGroup(ctx context.Context, chatID int64, title, userID, name string) (*entity.Group, bool, error) {
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrGroupNotFound) {
+			log.Printf("Error getting group %d: %v\n", chatID, err)
+			return nil, false, err
+		}
+		group = *entity.NewGroup(entity.WithGroupInitSpec(entity.GroupInitSpecification{
+			ChatID: chatID,
+			Title:  title,
+		}))
+	}
+
+	group.SetTitle(title)
+	joined := group.Join(userID, name, time.Now())
+
+	if err := s.db.SaveGroup(ctx, group); err != nil {
+		log.Printf("Error saving group %d: %v\n", chatID, err)
+		return nil, false, err
+	}
+
+	return &group, joined, nil
+}
+
+func (s *service) LeaveGroup(ctx context.Context, chatID int64, userID string) error {
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, errs.ErrGroupNotFound) {
+			return errs.ErrNotGroupMember
+		}
+		return err
+	}
+
+	if !group.Leave(userID, time.Now()) {
+		return errs.ErrNotGroupMember
+	}
+
+	if err := s.db.SaveGroup(ctx, group); err != nil {
+		log.Printf("Error saving group %d: %v\n", chatID, err)
+		return err
+	}
+
+	return nil
+}
+
+// ToggleGroupPRAnnouncements включает или выключает объявления о рекордах участника в группе.
+func (s *service) ToggleGroupPRAnnouncements(ctx context.Context, chatID int64, userID string) (bool, error) {
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, errs.ErrGroupNotFound) {
+			return false, errs.ErrNotGroupMember
+		}
+		return false, err
+	}
+
+	enabled, ok := group.ToggleAnnouncePRs(userID, time.Now())
+	if !ok {
+		return false, errs.ErrNotGroupMember
+	}
+
+	if err := s.db.SaveGroup(ctx, group); err != nil {
+		log.Printf("Error saving group %d: %v\n", chatID, err)
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// SetGroupLifts задает упражнения из общего каталога, по которым группа соревнуется в e1RM.
+func (s *service) SetGroupLifts(ctx context.Context, chatID int64, title string, names []string) (*entity.Group, error) {
+	if len(names) > entity.MaxGroupLifts {
+		return nil, errs.ErrTooManyGroupLifts
+	}
+
+	lifts := make([]entity.GroupLift, 0, len(names))
+	for _, name := range names {
+		exercise, err := s.db.GetExerciseByName(ctx, "", name)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(lifts, func(l entity.GroupLift) bool { return l.ExerciseID == exercise.ID() }) {
+			continue
+		}
+		lifts = append(lifts, entity.GroupLift{ExerciseID: exercise.ID(), Name: exercise.Name()})
+	}
+
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrGroupNotFound) {
+			return nil, err
+		}
+		group = *entity.NewGroup(entity.WithGroupInitSpec(entity.GroupInitSpecification{
+			ChatID: chatID,
+			Title:  title,
+		}))
+	}
+
+	group.SetLifts(lifts, time.Now())
+	if err := s.db.SaveGroup(ctx, group); err != nil {
+		log.Printf("Error saving group %d: %v\n", chatID, err)
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (s *service) GetGroup(ctx context.Context, chatID int64) (*entity.Group, error) {
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// GetGroupLeaderboard считает рейтинги участников за текущую неделю (с понедельника): объем,
+// число тренировок и лучший e1RM в выбранных упражнениях, деленный на вес тела.
+func (s *service) GetGroupLeaderboard(ctx context.Context, chatID int64) (*entity.Leaderboard, error) {
+	group, err := s.db.GetGroup(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	weekStart := startOfWeek(now)
+	leaderboard := &entity.Leaderboard{
+		From:     weekStart,
+		Volume:   []entity.LeaderboardEntry{},
+		Sessions: []entity.LeaderboardEntry{},
+		Lifts:    make([]entity.LiftLeaderboard, 0, len(group.Lifts())),
+	}
+	for _, lift := range group.Lifts() {
+		leaderboard.Lifts = append(leaderboard.Lifts, entity.LiftLeaderboard{Lift: lift, Entries: []entity.LeaderboardEntry{}})
+	}
+
+	for _, member := range group.Members() {
+		sessions, err := s.db.GetTrainingSessions(ctx, member.UserID, now.AddDate(0, 0, -leaderboardLookbackDays), now)
+		if err != nil {
+			log.Printf("Error getting sessions of group member '%s': %v\n", member.UserID, err)
+			return nil, err
+		}
+
+		var (
+			volume     float64
+			count      int
+			bodyweight float32
+			bestAt     time.Time
+			best       = make(map[uuid.UUID]float64)
+		)
+
+		for _, session := range sessions {
+			if session.Bodyweight() > 0 && session.Date().After(bestAt) {
+				bodyweight = session.Bodyweight()
+				bestAt = session.Date()
+			}
+
+			if session.Date().Before(weekStart) {
+				continue
+			}
+
+			volume += float64(session.TotalVolume())
+			count++
+
+			for exerciseID, e1rm := range bestE1RMs(&session) {
+				best[exerciseID] = max(best[exerciseID], e1rm)
+			}
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		leaderboard.Volume = append(leaderboard.Volume, entity.LeaderboardEntry{UserID: member.UserID, Name: member.Name, Value: volume})
+		leaderboard.Sessions = append(leaderboard.Sessions, entity.LeaderboardEntry{UserID: member.UserID, Name: member.Name, Value: float64(count)})
+
+		if bodyweight <= 0 {
+			continue
+		}
+		for i, lift := range leaderboard.Lifts {
+			if e1rm := best[lift.Lift.ExerciseID]; e1rm > 0 {
+				leaderboard.Lifts[i].Entries = append(leaderboard.Lifts[i].Entries, entity.LeaderboardEntry{
+					UserID: member.UserID,
+					Name:   member.Name,
+					Value:  e1rm / float64(bodyweight),
+				})
+			}
+		}
+	}
+
+	sortLeaderboard(leaderboard.Volume)
+	sortLeaderboard(leaderboard.Sessions)
+	for _, lift := range leaderboard.Lifts {
+		sortLeaderboard(lift.Entries)
+	}
+
+	return leaderboard, nil
+}
+
+// GetPersonalRecords возвращает упражнения, в которых тренировка превзошла лучший e1RM за год.
+// Первое выполнение упражнения рекордом не считается.
+func (s *service) GetPersonalRecords(ctx context.Context, session *entity.TrainingSession) ([]entity.PersonalRecord, error) {
+	sessions, err := s.db.GetTrainingSessions(ctx, session.UserID(), session.Date().AddDate(0, 0, -personalRecordLookbackDays), session.Date())
+	if err != nil {
+		log.Printf("Error getting sessions for personal records of user '%s': %v\n", session.UserID(), err)
+		return nil, err
+	}
+
+	previous := make(map[uuid.UUID]float64)
+	for _, past := range sessions {
+		if past.ID() == session.ID() {
+			continue
+		}
+		for exerciseID, e1rm := range bestE1RMs(&past) {
+			previous[exerciseID] = max(previous[exerciseID], e1rm)
+		}
+	}
+
+	var records []entity.PersonalRecord
+	for _, exercise := range session.Exercises() {
+		before := previous[exercise.Exercise.ID()]
+		if before <= 0 {
+			continue
+		}
+
+		var record *entity.PersonalRecord
+		for _, set := range exercise.Sets() {
+			e1rm := onerm.Calculate(float64(set.Weight()), int(set.Reps())).Average
+			if e1rm > before && (record == nil || e1rm > record.E1RM) {
+				record = &entity.PersonalRecord{
+					ExerciseName: exercise.Name(),
+					Weight:       set.Weight(),
+					Reps:         set.Reps(),
+					E1RM:         e1rm,
+					Previous:     before,
+				}
+			}
+		}
+
+		if record != nil {
+			records = append(records, *record)
+		}
+	}
+
+	return records, nil
+}
+
+// GetAnnouncingGroups возвращает группы, в которых пользователь разрешил объявлять его рекорды.
+func (s *service) GetAnnouncingGroups(ctx context.Context, userID string) ([]entity.Group, error) {
+	groups, err := s.db.GetMemberGroups(ctx, userID)
+	if err != nil {
+		log.Printf("Error getting groups of user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	return slices.DeleteFunc(groups, func(g entity.Group) bool {
+		member, ok := g.Member(userID)
+		return !ok || !member.AnnouncePRs
+	}), nil
+}
+
+// bestE1RMs лучший e1RM тренировки по каждому упражнению.
+func bestE1RMs(session *entity.TrainingSession) map[uuid.UUID]float64 {
+	best := make(map[uuid.UUID]float64)
+	for _, exercise := range session.Exercises() {
+		for _, set := range exercise.Sets() {
+			e1rm := onerm.Calculate(float64(set.Weight()), int(set.Reps())).Average
+			best[exercise.Exercise.ID()] = max(best[exercise.Exercise.ID()], e1rm)
+		}
+	}
+
+	return best
+}
+
+func sortLeaderboard(entries []entity.LeaderboardEntry) {
+	slices.SortStableFunc(entries, func(a, b entity.LeaderboardEntry) int {
+		switch {
+		case a.Value > b.Value:
+			return -1
+		case a.Value < b.Value:
+			return 1
+		}
+		return 0
+	})
+}
+
+// startOfWeek полночь понедельника текущей недели.
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (s *service) GetEquipmentTypes(ctx context.Context) ([]string, error) {
+	equipment, err := s.db.GetEquipmentTypes(ctx)
+	if err != nil {
+		log.Printf("Error retrieving equipment types: %v\n", err)
+		return nil, err
+	}
+
+	return equipment, nil
+}
+
+func (s *service) GetGyms(ctx context.Context, userID string) ([]entity.Gym, error) {
+	gyms, err := s.db.GetGyms(ctx, userID)
+	if err != nil {
+		log.Printf("Error retrieving gyms for user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	return gyms, nil
+}
+
+// GetCurrentGym возвращает выбранный зал пользователя или nil, если зал не выбран.
+func (s *service) GetCurrentGym(ctx context.Context, userID string) (*entity.Gym, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.GymID() == uuid.Nil {
+		return nil, nil
+	}
+
+	gym, err := s.db.GetGymByID(ctx, userID, settings.GymID())
+	if err != nil {
+		if errors.Is(err, errs.ErrGymNotFound) {
+			return nil, nil
+		}
+		log.Printf("Error retrieving gym '%s' for user '%s': %v\n", settings.GymID(), userID, err)
+		return nil, err
+	}
+
+	return &gym, nil
+}
+
+// CreateGym сохраняет зал с оборудованием из каталога упражнений и сразу делает его текущим.
+func (s *service) CreateGym(ctx context.Context, userID, name string, equipment []string) (*entity.Gym, error) {
+	known, err := s.GetEquipmentTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range equipment {
+		if item != entity.EquipmentBodyweight && !slices.Contains(known, item) {
+			return nil, errs.ErrUnknownEquipment
+		}
+	}
+
+	gym := entity.NewGym(entity.WithGymInitSpec(entity.GymInitSpecification{
+		UserID:    userID,
+		Name:      name,
+		Equipment: equipment,
+	}))
+
+	if err := s.db.InsertGym(ctx, *gym); err != nil {
+		log.Printf("Error inserting gym '%s' for user '%s': %v\n", name, userID, err)
+		return nil, err
+	}
+
+	if err := s.SelectGym(ctx, userID, gym.ID()); err != nil {
+		return nil, err
+	}
+
+	return gym, nil
+}
+
+func (s *service) DeleteGym(ctx context.Context, userID string, gymID uuid.UUID) error {
+	if err := s.db.DeleteGym(ctx, userID, gymID); err != nil {
+		log.Printf("Error deleting gym '%s' for user '%s': %v\n", gymID, userID, err)
+		return err
+	}
+
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if settings.GymID() != gymID {
+		return nil
+	}
+
+	return s.SelectGym(ctx, userID, uuid.Nil)
+}
+
+// SelectGym делает зал текущим и отмечает им начатую тренировку, uuid.Nil снимает выбор зала.
+func (s *service) SelectGym(ctx context.Context, userID string, gymID uuid.UUID) error {
+	if gymID != uuid.Nil {
+		if _, err := s.db.GetGymByID(ctx, userID, gymID); err != nil {
+			log.Printf("Error retrieving gym '%s' for user '%s': %v\n", gymID, userID, err)
+			return err
+		}
+	}
+
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.SetGym(gymID)
+
+	if err := s.db.SaveUserSettings(ctx, *settings); err != nil {
+		log.Printf("Error saving gym for user '%s': %v\n", userID, err)
+		return err
+	}
+
+	_, err = s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		session.SetGym(gymID)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errs.ErrSessionNotFound) {
+		log.Printf("Error saving session gym for user '%s': %v\n", userID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) SetAnalyticsByGym(ctx context.Context, userID string, enabled bool) error {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.SetAnalyticsByGym(enabled)
+
+	if err := s.db.SaveUserSettings(ctx, *settings); err != nil {
+		log.Printf("Error saving analytics gym filter for user '%s': %v\n", userID, err)
+		return err
+	}
+
+	return nil
+}
+
+// FilterExercisesByGym убирает упражнения, для которых в текущем зале нет оборудования.
+func (s *service) FilterExercisesByGym(ctx context.Context, userID string, exercises []entity.Exercise) ([]entity.Exercise, error) {
+	gym, err := s.GetCurrentGym(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if gym == nil {
+		return exercises, nil
+	}
+
+	return slices.DeleteFunc(exercises, func(e entity.Exercise) bool {
+		return !gym.Supports(e.Equipment())
+	}), nil
+}
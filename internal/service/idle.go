@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+// SweepIdleSessions напоминает о тренировках без действий дольше remindAfter, а через closeAfter
+// после напоминания завершает или удаляет их по настройке пользователя.
+func (s *service) SweepIdleSessions(ctx context.Context, remindAfter, closeAfter time.Duration) ([]entity.IdleSweep, error) {
+	sessions, err := s.cache.GetSessions(ctx)
+	if err != nil {
+		log.Printf("Error getting sessions for idle sweep: %v\n", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	var sweeps []entity.IdleSweep
+
+	for _, session := range sessions {
+		var result entity.IdleSweepResult
+
+		switch {
+		case session.RemindedAt().IsZero() && now.Sub(session.LastActivityAt()) >= remindAfter:
+			session.MarkReminded(now)
+			err = s.cache.SaveSession(ctx, session)
+			result = entity.IdleSweepReminded
+		case !session.RemindedAt().IsZero() && now.Sub(session.RemindedAt()) >= closeAfter:
+			result, err = s.closeIdleSession(ctx, session)
+		default:
+			continue
+		}
+
+		if errors.Is(err, errs.ErrSessionConflict) {
+			// пользователь изменил тренировку во время проверки, она больше не брошена
+			continue
+		}
+		if err != nil {
+			log.Printf("Error sweeping idle session for user '%s': %v\n", session.UserID(), err)
+			continue
+		}
+
+		sweeps = append(sweeps, entity.IdleSweep{Session: *session, Result: result})
+	}
+
+	return sweeps, nil
+}
+
+// closeIdleSession сохраняет заполненные подходы брошенной тренировки, если пользователь
+// не выбрал удаление, а тренировку без подходов удаляет.
+func (s *service) closeIdleSession(ctx context.Context, session *entity.TrainingSession) (entity.IdleSweepResult, error) {
+	settings, err := s.GetUserSettings(ctx, session.UserID())
+	if err != nil {
+		return "", err
+	}
+
+	session.PruneEmptySets()
+
+	if settings.IdleSessionAction() == entity.IdleSessionFinish && !session.IsEmpty() {
+		return entity.IdleSweepFinished, s.finishSession(ctx, session)
+	}
+
+	return entity.IdleSweepDiscarded, s.cache.DeleteSession(ctx, session)
+}
+
+// KeepSession откладывает закрытие тренировки после напоминания.
+func (s *service) KeepSession(ctx context.Context, userID string) error {
+	_, err := s.updateSession(ctx, userID, func(*entity.TrainingSession) error {
+		return nil
+	})
+
+	return err
+}
+
+func (s *service) SetIdleSessionAction(ctx context.Context, userID string, action entity.IdleSessionAction) error {
+	if action != entity.IdleSessionFinish && action != entity.IdleSessionDiscard {
+		return errs.ErrUnknownIdleAction
+	}
+
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.SetIdleSessionAction(action)
+
+	if err := s.db.SaveUserSettings(ctx, *settings); err != nil {
+		log.Printf("Error saving settings for user '%s': %v\n", userID, err)
+		return err
+	}
+
+	return nil
+}
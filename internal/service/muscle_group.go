@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+// muscleGroupsCache хранит справочник мышечных групп: он нужен почти каждой клавиатуре,
+// а меняется только правкой коллекции в базе.
+type muscleGroupsCache struct {
+	mu        sync.Mutex
+	groups    []entity.MuscleGroup
+	expiresAt time.Time
+}
+
+func (s *service) GetMuscleGroups(ctx context.Context) ([]entity.MuscleGroup, error) {
+	s.muscleGroups.mu.Lock()
+	defer s.muscleGroups.mu.Unlock()
+
+	if s.muscleGroups.groups != nil && time.Now().Before(s.muscleGroups.expiresAt) {
+		return s.muscleGroups.groups, nil
+	}
+
+	groups, err := s.db.GetMuscleGroups(ctx)
+	if err != nil {
+		log.Printf("Error retrieving muscle groups: %v\n", err)
+		return nil, err
+	}
+
+	s.muscleGroups.groups = groups
+	s.muscleGroups.expiresAt = time.Now().Add(muscleGroupsCacheTTL)
+
+	return groups, nil
+}
+
+// validateMuscleGroups проверяет, что все группы есть в справочнике.
+func (s *service) validateMuscleGroups(ctx context.Context, names ...string) error {
+	groups, err := s.GetMuscleGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		known[group.Name()] = struct{}{}
+	}
+
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			log.Printf("Unknown muscle group '%s'\n", name)
+			return errs.ErrUnknownMuscleGroup
+		}
+	}
+
+	return nil
+}
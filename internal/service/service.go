@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +18,23 @@ import (
 	"gymnote/internal/repository"
 )
 
+const (
+	// uploadProgressStep через сколько сохраненных тренировок обновляется прогресс загрузки
+	uploadProgressStep = 20
+
+	searchCandidatesLimit = 50
+	searchResultsLimit    = 20
+
+	muscleGroupsCacheTTL = 5 * time.Minute
+
+	maxSessionUpdateAttempts = 10
+	// sessionRetryDelay шаг случайной паузы перед повторной записью тренировки после конфликта
+	sessionRetryDelay = 10 * time.Millisecond
+)
+
 type Parser interface {
-	ParseExercises(s string) ([]parser.Exercise, time.Time, error)
+	ParseTrainings(s string) ([]parser.Training, error)
+	ParseSets(s string) ([]parser.Set, error)
 	ParseDifficulty(notes string) string
 }
 
@@ -24,6 +42,8 @@ type service struct {
 	db     repository.DB
 	cache  repository.Cache
 	parser Parser
+
+	muscleGroups muscleGroupsCache
 }
 
 func New(db repository.DB, cache repository.Cache, parser Parser) *service {
@@ -34,70 +54,86 @@ func New(db repository.DB, cache repository.Cache, parser Parser) *service {
 	}
 }
 
-func (s *service) ParseTraining(ctx context.Context, e entity.Event) (*entity.TrainingSession, error) {
+func (s *service) ParseTrainings(ctx context.Context, e entity.Event, onProgress func(done, total int)) ([]entity.TrainingSession, error) {
 	if e.UserID == "" || e.Text == "" {
 		log.Println("Invalid event data: missing UserID or Text")
 		return nil, errs.ErrInvalidEventData
 	}
 
-	parsedExercises, date, err := s.parser.ParseExercises(e.Text)
+	parsedTrainings, err := s.parser.ParseTrainings(e.Text)
 	if err != nil {
-		log.Printf("Error parsing exercises: %v\n", err)
-		return nil, fmt.Errorf("failed to parse exercises: %w", err)
-	}
-
-	var exercises []entity.SessionExercise
-
-	for exsIDX, parsedExercise := range parsedExercises {
-		sets := make([]entity.Set, 0, len(parsedExercise.Sets))
-
-		exercise, err := s.db.GetExerciseByName(ctx, parsedExercise.Name)
-		if err != nil {
-			log.Printf("Error getting exercise ID for '%s': %v\n", parsedExercise.Name, err)
-			return nil, fmt.Errorf("failed to get exercise ID for '%s': %w", parsedExercise.Name, err)
+		log.Printf("Error parsing trainings: %v\n", err)
+		return nil, fmt.Errorf("failed to parse trainings: %w", err)
+	}
+
+	exercisesByName := make(map[string]entity.Exercise)
+	sessions := make([]entity.TrainingSession, 0, len(parsedTrainings))
+
+	for _, parsedTraining := range parsedTrainings {
+		var exercises []entity.SessionExercise
+
+		for exsIDX, parsedExercise := range parsedTraining.Exercises {
+			sets := make([]entity.Set, 0, len(parsedExercise.Sets))
+
+			exercise, ok := exercisesByName[parsedExercise.Name]
+			if !ok {
+				exercise, err = s.db.GetExerciseByName(ctx, e.UserID, parsedExercise.Name)
+				if errors.Is(err, errs.ErrExerciseNotFound) {
+					return nil, &parser.LineError{Line: parsedExercise.Line, Text: parsedExercise.Name, Err: err}
+				}
+				if err != nil {
+					log.Printf("Error getting exercise ID for '%s': %v\n", parsedExercise.Name, err)
+					return nil, fmt.Errorf("failed to get exercise ID for '%s': %w", parsedExercise.Name, err)
+				}
+				exercisesByName[parsedExercise.Name] = exercise
+			}
+
+			for setIDX, set := range parsedExercise.Sets {
+				sets = append(sets, *entity.NewSet(entity.WithSetInitSpec(
+					entity.SetInitSpecification{
+						ExerciseID: exercise.ID(),
+						UserID:     e.UserID,
+						Number:     uint8(setIDX + 1),
+						Weight:     set.Weight,
+						Reps:       set.Reps,
+						Difficulty: set.Difficulty,
+						Notes:      set.Notes,
+					})),
+				)
+			}
+
+			exercises = append(exercises, *entity.NewSessionExercise(&exercise, sets, entity.WithSessionExerciseInitSpec(
+				entity.SessionExerciseInitSpecification{
+					Number: uint8(exsIDX + 1),
+				},
+			)))
 		}
 
-		for setIDX, set := range parsedExercise.Sets {
-			sets = append(sets, *entity.NewSet(entity.WithSetInitSpec(
-				entity.SetInitSpecification{
-					ExerciseID: exercise.ID(),
-					UserID:     e.UserID,
-					Number:     uint8(setIDX + 1),
-					Weight:     set.Weight,
-					Reps:       set.Reps,
-					Difficulty: set.Difficulty,
-					Notes:      set.Notes,
-				})),
-			)
-		}
-
-		exercises = append(exercises, *entity.NewSessionExercise(&exercise, sets, entity.WithSessionExerciseInitSpec(
-			entity.SessionExerciseInitSpecification{
-				Number: uint8(exsIDX + 1),
+		sessions = append(sessions, *entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(
+			entity.TrainingSessionInitSpecification{
+				UserID:    e.UserID,
+				Date:      parsedTraining.Date,
+				Notes:     "",
+				Exercises: exercises,
 			},
 		)))
 	}
 
-	session := entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(
-		entity.TrainingSessionInitSpecification{
-			UserID:    e.UserID,
-			Date:      date,
-			Notes:     "",
-			Exercises: exercises,
-		},
-	))
-
-	if err := s.db.InsertTrainingSession(ctx, *session); err != nil {
-		log.Printf("Error inserting training session: %v\n", err)
-		return nil, fmt.Errorf("failed to insert training session: %w", err)
-	}
+	// каждая тренировка сохраняется транзакцией вместе с подходами, поэтому прерванная загрузка
+	// не оставляет тренировок без подходов
+	for i, session := range sessions {
+		if err := s.db.SaveFinishedSession(ctx, session); err != nil {
+			log.Printf("Error saving uploaded training session: %v\n", err)
+			return nil, fmt.Errorf("failed to save training session: %w", err)
+		}
 
-	if err := s.db.InsertTrainingLogs(ctx, *session); err != nil {
-		log.Printf("Error inserting training logs: %v\n", err)
-		return nil, fmt.Errorf("failed to insert training logs: %w", err)
+		done := i + 1
+		if onProgress != nil && (done%uploadProgressStep == 0 || done == len(sessions)) {
+			onProgress(done, len(sessions))
+		}
 	}
 
-	return session, nil
+	return sessions, nil
 }
 
 func (s *service) GetExerciseProgression(ctx context.Context, userID string, exerciseID uuid.UUID) ([]entity.ExerciseProgression, error) {
@@ -105,11 +141,21 @@ func (s *service) GetExerciseProgression(ctx context.Context, userID string, exe
 	fromDate := now.AddDate(-1, 0, 0)
 	toDate := now
 
-	return s.db.GetExerciseProgression(ctx, userID, exerciseID, fromDate, toDate)
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.db.GetExerciseProgression(ctx, userID, exerciseID, settings.AnalyticsGymID(), fromDate, toDate)
 }
 
 func (s *service) GetLastSetsForExercise(ctx context.Context, userID string, exerciseID uuid.UUID, limitDays int64) ([]entity.ExerciseProgression, error) {
-	result, err := s.db.GetLastSetsForExercise(ctx, userID, exerciseID, limitDays)
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.GetLastSetsForExercise(ctx, userID, exerciseID, settings.AnalyticsGymID(), limitDays)
 	if err != nil {
 		log.Printf("Error get last sets for exercise %s for user for user '%s': %v\n", exerciseID.String(), userID, err)
 		return nil, err
@@ -118,8 +164,19 @@ func (s *service) GetLastSetsForExercise(ctx context.Context, userID string, exe
 	return result, nil
 }
 
-func (s *service) CreateExercise(ctx context.Context, name, muscleGroup, equipment string) error {
-	_, err := s.db.GetExerciseByName(ctx, name)
+// CreateExercise добавляет упражнение в каталог: с пустым ownerID - общее,
+// иначе личное, видимое только владельцу.
+func (s *service) CreateExercise(ctx context.Context, ownerID, name, muscleGroup, equipment string, secondary []entity.SecondaryMuscle) error {
+	names := []string{muscleGroup}
+	for _, muscle := range secondary {
+		names = append(names, muscle.MuscleGroup)
+	}
+
+	if err := s.validateMuscleGroups(ctx, names...); err != nil {
+		return err
+	}
+
+	_, err := s.db.GetExerciseByName(ctx, ownerID, name)
 	if err == nil {
 		log.Printf("Exercise '%s' already exists\n", name)
 		return errs.ErrExerciseAlreadyExists
@@ -130,9 +187,11 @@ func (s *service) CreateExercise(ctx context.Context, name, muscleGroup, equipme
 	}
 
 	exercise := entity.NewExercise(entity.WithExerciseInitSpec(entity.ExerciseInitSpecification{
-		Name:        name,
-		MuscleGroup: muscleGroup,
-		Equipment:   equipment,
+		Name:             name,
+		MuscleGroup:      muscleGroup,
+		SecondaryMuscles: secondary,
+		Equipment:        equipment,
+		OwnerID:          ownerID,
 	}))
 
 	if err := s.db.InsertExercise(ctx, *exercise); err != nil {
@@ -142,6 +201,48 @@ func (s *service) CreateExercise(ctx context.Context, name, muscleGroup, equipme
 	return nil
 }
 
+func (s *service) GetPrivateExercisesByName(ctx context.Context, name string) ([]entity.Exercise, error) {
+	exercises, err := s.db.GetPrivateExercisesByName(ctx, name)
+	if err != nil {
+		log.Printf("Error retrieving private exercises by name '%s': %v\n", name, err)
+		return nil, err
+	}
+
+	return exercises, nil
+}
+
+// PromoteExercise делает личное упражнение общим, если в каталоге ещё нет общего с таким же названием.
+func (s *service) PromoteExercise(ctx context.Context, exerciseID uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := s.db.GetExerciseByID(ctx, exerciseID)
+	if err != nil {
+		log.Printf("Error getting exercise by ID '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	if exercise.IsPublic() {
+		return nil, errs.ErrExerciseAlreadyPublic
+	}
+
+	_, err = s.db.GetExerciseByName(ctx, "", exercise.Name())
+	if err == nil {
+		log.Printf("Public exercise '%s' already exists\n", exercise.Name())
+		return nil, errs.ErrExerciseAlreadyExists
+	}
+	if !errors.Is(err, errs.ErrExerciseNotFound) {
+		log.Printf("Error checking existing exercise: %v\n", err)
+		return nil, fmt.Errorf("failed to check existing exercise: %w", err)
+	}
+
+	if err := s.db.UpdateExerciseOwner(ctx, exerciseID, ""); err != nil {
+		log.Printf("Error promoting exercise '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	exercise.MakePublic()
+
+	return &exercise, nil
+}
+
 func (s *service) GetTrainingSessions(ctx context.Context, userID string, fromDate, toDate *time.Time) ([]entity.TrainingSession, error) {
 	now := time.Now()
 
@@ -161,13 +262,55 @@ func (s *service) GetTrainingSessions(ctx context.Context, userID string, fromDa
 	return sessions, err
 }
 
-func (s *service) GetExercisesByMuscleGroup(ctx context.Context, muscleGroup string) ([]entity.Exercise, error) {
-	exercises, err := s.db.GetExercisesByMuscleGroup(ctx, muscleGroup)
+func (s *service) GetExercisesByMuscleGroup(ctx context.Context, userID string, muscleGroup string) ([]entity.Exercise, error) {
+	exercises, err := s.db.GetExercisesByMuscleGroup(ctx, userID, muscleGroup)
 	if err != nil {
 		log.Printf("Error retrieving exercises by muscle group '%s': %v\n", muscleGroup, err)
 		return nil, err
 	}
 
+	return s.orderExercises(ctx, userID, exercises)
+}
+
+// SearchExercises ищет упражнения по началу слов названия, упорядочивая их как orderExercises.
+func (s *service) SearchExercises(ctx context.Context, userID string, query string) ([]entity.Exercise, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.db.GetUsedExerciseIDs(ctx, userID)
+	if err != nil {
+		log.Printf("Error retrieving used exercises for user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	// избранные и использованные упражнения ищутся без ограничения, чтобы ранжирование
+	// по использованию видело их при любом числе совпадений по алфавиту
+	preferred := append(slices.Clone(settings.FavoriteExercises()), used...)
+
+	exercises, err := s.db.SearchExercises(ctx, userID, query, preferred, searchCandidatesLimit)
+	if errors.Is(err, errs.ErrSearchQueryTooShort) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("Error searching exercises by query '%s': %v\n", query, err)
+		return nil, err
+	}
+
+	if len(exercises) == 0 {
+		return exercises, nil
+	}
+
+	exercises, err = s.orderExercises(ctx, userID, exercises)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(exercises) > searchResultsLimit {
+		exercises = exercises[:searchResultsLimit]
+	}
+
 	return exercises, nil
 }
 
@@ -178,14 +321,25 @@ func (s *service) StartTraining(ctx context.Context, userID string) (*entity.Tra
 		return nil, errs.ErrTrainingStarted
 	}
 
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	session = entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(entity.TrainingSessionInitSpecification{
 		UserID:    userID,
 		Date:      time.Now(),
 		Exercises: []entity.SessionExercise{},
 		Notes:     "",
+		GymID:     settings.GymID(),
+		StartedAt: time.Now(),
 	}))
 
-	err = s.cache.SaveSession(ctx, session)
+	err = s.saveSession(ctx, session)
+	if errors.Is(err, errs.ErrSessionConflict) {
+		log.Printf("Training session already started for user '%s'\n", userID)
+		return nil, errs.ErrTrainingStarted
+	}
 	if err != nil {
 		log.Printf("Error saving training session for user '%s': %v\n", userID, err)
 		return nil, err
@@ -195,148 +349,304 @@ func (s *service) StartTraining(ctx context.Context, userID string) (*entity.Tra
 }
 
 func (s *service) DeleteExercise(ctx context.Context, userID string, exerciseID uuid.UUID) error {
-	session, err := s.getSession(ctx, userID)
+	_, err := s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		if err := session.DeleteLastExercise(exerciseID); err != nil {
+			log.Printf("Error deleting last occurrence of exercise '%s': %v\n", exerciseID, err)
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (s *service) AddTrainingExercise(ctx context.Context, userID string, exerciseID uuid.UUID) error {
+	exercise, err := s.db.GetExerciseByID(ctx, exerciseID)
 	if err != nil {
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
+		log.Printf("Error getting exercise by ID '%v': %v\n", exerciseID, err)
 		return err
 	}
 
-	if err := session.DeleteLastExercise(exerciseID); err != nil {
-		log.Printf("Error deleting last occurrence of exercise '%s': %v\n", exerciseID, err)
-		return err
+	if !exercise.IsVisibleTo(userID) {
+		log.Printf("Exercise '%v' is private and not visible to user '%s'\n", exerciseID, userID)
+		return errs.ErrExerciseNotFound
 	}
 
-	if err := s.cache.SaveSession(ctx, session); err != nil {
-		log.Printf("Error saving session after deleting exercise '%s': %v\n", exerciseID, err)
-		return err
-	}
+	_, err = s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		addExerciseToSession(session, exercise)
+		return nil
+	})
 
-	return nil
+	return err
 }
 
-func (s *service) AddTrainingExercise(ctx context.Context, userID string, exerciseID uuid.UUID) error {
-	session, err := s.getSession(ctx, userID)
+func (s *service) AddOrUpdateSet(ctx context.Context, userID string, messageID int, input string) ([]entity.Set, error) {
+	parsedSets, err := s.parseSetInput(input)
 	if err != nil {
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
-		return err
+		return nil, err
 	}
 
-	return s.addExerciseToSession(ctx, session, exerciseID)
-}
+	completedAt := time.Now()
+	var added []entity.Set
 
-func (s *service) AddOrUpdateSet(ctx context.Context, userID string, messageID int, weight float32, reps uint8, notes string) error {
-	session, err := s.getSession(ctx, userID)
-	if err != nil {
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
+	_, err = s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		var err error
+		added, err = addParsedSets(session, parsedSets, messageID, completedAt)
+		if err != nil {
+			log.Printf("Error adding sets for user '%s': %v\n", userID, err)
+		}
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return added, nil
+}
+
+// addParsedSets записывает подходы в активное упражнение: подходы заполняют пустые подходы
+// по порядку, включая подходы плана тренера, остальные добавляются следом.
+func addParsedSets(session *entity.TrainingSession, parsedSets []parser.Set, messageID int, completedAt time.Time) ([]entity.Set, error) {
 	activeExercise := session.ActiveExercise()
 	if activeExercise == nil {
-		log.Printf("Exercise not found for user '%s'\n", userID)
-		return errs.ErrExerciseNotFound
+		return nil, errs.ErrExerciseNotFound
 	}
 
-	lastSet := activeExercise.LastSet()
-	if lastSet == nil {
-		log.Printf("Set not found for exercise '%s'\n", activeExercise.Name())
-		return errs.ErrSetNotFound
-	}
+	added := make([]entity.Set, 0, len(parsedSets))
+	for _, parsedSet := range parsedSets {
+		lastSet := activeExercise.LastSet()
+		if lastSet == nil {
+			return nil, errs.ErrSetNotFound
+		}
 
-	if lastSet.Weight() == 0 || lastSet.Reps() == 0 {
-		lastSet.SetWeight(weight)
-		lastSet.SetReps(reps)
-		lastSet.SetNotes(notes)
-		lastSet.SetDifficulty(s.parser.ParseDifficulty(notes))
-		lastSet.SetMessageID(messageID)
-		return s.cache.SaveSession(ctx, session)
-	}
+		if emptySet := activeExercise.FirstEmptySet(); emptySet != nil {
+			emptySet.SetWeight(parsedSet.Weight)
+			emptySet.SetReps(parsedSet.Reps)
+			emptySet.SetNotes(parsedSet.Notes)
+			emptySet.SetDifficulty(parsedSet.Difficulty)
+			emptySet.SetMessageID(messageID)
+			emptySet.Complete(completedAt)
+			added = append(added, *emptySet)
+			continue
+		}
 
-	newSet := entity.NewSet(entity.WithSetInitSpec(
-		entity.SetInitSpecification{
-			UserID:     lastSet.UserID(),
-			ExerciseID: lastSet.ExerciseID(),
-			Number:     lastSet.Number() + 1,
-			Weight:     weight,
-			Reps:       reps,
-			Notes:      notes,
-			Difficulty: s.parser.ParseDifficulty(notes),
-			MessageID:  messageID,
-		},
-	))
+		newSet := entity.NewSet(entity.WithSetInitSpec(
+			entity.SetInitSpecification{
+				UserID:     lastSet.UserID(),
+				ExerciseID: lastSet.ExerciseID(),
+				Number:     lastSet.Number() + 1,
+				Weight:     parsedSet.Weight,
+				Reps:       parsedSet.Reps,
+				Notes:      parsedSet.Notes,
+				Difficulty: parsedSet.Difficulty,
+				MessageID:  messageID,
+
+				CompletedAt: completedAt,
+			},
+		))
 
-	activeExercise.AddSet(newSet)
+		activeExercise.AddSet(newSet)
+		added = append(added, *newSet)
+	}
 
-	return s.cache.SaveSession(ctx, session)
+	return added, nil
 }
 
-func (s *service) UpdateSetFromMessage(ctx context.Context, userID string, messageID int, weight float32, reps uint8, notes string) error {
-	session, err := s.getSession(ctx, userID)
+func (s *service) UpdateSetFromMessage(ctx context.Context, userID string, messageID int, input string) error {
+	parsedSets, err := s.parseSetInput(input)
 	if err != nil {
-		if errors.Is(err, errs.ErrSessionNotFound) {
-			return nil
-		}
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
 		return err
 	}
 
-	set := session.FindSetByMessageID(messageID)
-	if set == nil {
+	_, err = s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		sets := session.FindSetsByMessageID(messageID)
+		if len(sets) == 0 {
+			return errs.ErrSetNotFound
+		}
+
+		if len(sets) != len(parsedSets) {
+			log.Printf("Edited message %d for user '%s' has %d sets instead of %d, skipping\n", messageID, userID, len(parsedSets), len(sets))
+			return errs.ErrSetNotFound
+		}
+
+		for i, set := range sets {
+			set.SetWeight(parsedSets[i].Weight)
+			set.SetReps(parsedSets[i].Reps)
+			set.SetNotes(parsedSets[i].Notes)
+			set.SetDifficulty(parsedSets[i].Difficulty)
+		}
+		return nil
+	})
+	if errors.Is(err, errs.ErrSessionNotFound) || errors.Is(err, errs.ErrSetNotFound) {
 		return nil
 	}
 
-	set.SetWeight(weight)
-	set.SetReps(reps)
-	set.SetNotes(notes)
-	set.SetDifficulty(s.parser.ParseDifficulty(notes))
-
-	return s.cache.SaveSession(ctx, session)
+	return err
 }
 
-func (s *service) EndSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
-	session, err := s.getSession(ctx, userID)
+func (s *service) UndoSets(ctx context.Context, userID string, messageID int) ([]entity.Set, error) {
+	var undone []entity.Set
+
+	_, err := s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		var err error
+		undone, err = session.UndoSets(messageID)
+		return err
+	})
 	if err != nil {
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
 		return nil, err
 	}
 
-	if err := s.db.InsertTrainingSession(ctx, *session); err != nil {
-		log.Printf("Error inserting training session: %v\n", err)
-		return nil, fmt.Errorf("failed to insert training session: %w", err)
+	return undone, nil
+}
+
+func (s *service) DeleteSessionSet(ctx context.Context, userID string, setID uuid.UUID) (*entity.TrainingSession, error) {
+	return s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		return session.RemoveSet(setID)
+	})
+}
+
+func (s *service) SelectSessionExercise(ctx context.Context, userID string, number uint8) (*entity.TrainingSession, error) {
+	return s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		return session.SelectExercise(number)
+	})
+}
+
+func (s *service) MoveSessionExercise(ctx context.Context, userID string, number uint8, offset int) (*entity.TrainingSession, error) {
+	return s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		return session.MoveExercise(number, offset)
+	})
+}
+
+func (s *service) DeleteSessionExercise(ctx context.Context, userID string, number uint8) (*entity.TrainingSession, error) {
+	return s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		return session.DeleteExercise(number)
+	})
+}
+
+// SetDashboardMessage запоминает закрепленное сообщение с ходом тренировки.
+func (s *service) SetDashboardMessage(ctx context.Context, userID string, messageID int) error {
+	_, err := s.updateSession(ctx, userID, func(session *entity.TrainingSession) error {
+		session.SetDashboardMessageID(messageID)
+		return nil
+	})
+
+	return err
+}
+
+// updateSession применяет изменение к текущей тренировке и сохраняет ее в кэш. Если тренировку
+// изменили параллельно, она перечитывается и изменение применяется заново, поэтому update
+// должен зависеть только от переданной тренировки.
+func (s *service) updateSession(ctx context.Context, userID string, update func(session *entity.TrainingSession) error) (*entity.TrainingSession, error) {
+	for attempt := 1; ; attempt++ {
+		session, err := s.getSession(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := update(session); err != nil {
+			return nil, err
+		}
+
+		err = s.saveSession(ctx, session)
+		if retryOnConflict(ctx, err, attempt) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error saving session for user '%s': %v\n", userID, err)
+			return nil, err
+		}
+
+		return session, nil
+	}
+}
+
+// EndSession завершает текущую тренировку. Если ее изменили во время сохранения, тренировка
+// перечитывается и сохраняется заново поверх прежней копии.
+func (s *service) EndSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
+	for attempt := 1; ; attempt++ {
+		session, err := s.getSession(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.finishSession(ctx, session)
+		if retryOnConflict(ctx, err, attempt) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return session, nil
 	}
+}
 
-	if err := s.db.InsertTrainingLogs(ctx, *session); err != nil {
-		log.Printf("Error inserting training logs: %v\n", err)
-		return nil, fmt.Errorf("failed to insert training logs: %w", err)
+// finishSession сохраняет заполненные подходы тренировки в базу одной транзакцией и удаляет
+// тренировку из кэша. Сохранение идемпотентно: при ошибке удаления из кэша повтор не создаст дублей.
+func (s *service) finishSession(ctx context.Context, session *entity.TrainingSession) error {
+	userID := session.UserID()
+	session.PruneEmptySets()
+	session.Finish(time.Now())
+
+	if !session.IsEmpty() {
+		if err := s.db.SaveFinishedSession(ctx, *session); err != nil {
+			log.Printf("Error saving finished training session '%v': %v\n", session.ID(), err)
+			return fmt.Errorf("failed to save training session: %w", err)
+		}
 	}
 
-	err = s.cache.DeleteSession(ctx, userID)
-	if err != nil {
+	if err := s.cache.DeleteSession(ctx, session); err != nil {
 		log.Printf("Error deleting session for user '%s': %v\n", userID, err)
-		return nil, err
+		return err
 	}
 
-	return session, nil
+	if session.AssignmentID() != uuid.Nil && !session.IsEmpty() {
+		s.completeAssignedWorkout(ctx, session)
+	}
+
+	return nil
 }
 
-func (s *service) ClearSession(ctx context.Context, userID string) error {
-	session, err := s.cache.GetSession(ctx, userID)
+// ClearSession удаляет текущую тренировку без сохранения и возвращает ее.
+func (s *service) ClearSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
+	session, err := s.getSession(ctx, userID)
 	if err != nil {
-		log.Printf("Error getting session for user '%s': %v\n", userID, err)
-		return err
+		return nil, err
 	}
-	if session == nil {
-		log.Printf("Session not found for user '%s'\n", userID)
-		return errs.ErrSessionNotFound
+
+	if err := s.cache.DeleteSession(ctx, session); err != nil {
+		log.Printf("Error deleting session for user '%s': %v\n", userID, err)
+		return nil, err
 	}
 
-	return s.cache.DeleteSession(ctx, userID)
+	return session, nil
 }
 
 func (s *service) GetCurrentSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
 	return s.cache.GetSession(ctx, userID)
 }
 
+// saveSession сохраняет текущую тренировку после действия пользователя.
+func (s *service) saveSession(ctx context.Context, session *entity.TrainingSession) error {
+	session.Touch(time.Now())
+	return s.cache.SaveSession(ctx, session)
+}
+
+// retryOnConflict сообщает, стоит ли повторить запись тренировки. Перед повтором делается
+// случайная пауза, растущая с номером попытки, чтобы одновременные записи разошлись.
+func retryOnConflict(ctx context.Context, err error, attempt int) bool {
+	if !errors.Is(err, errs.ErrSessionConflict) || attempt >= maxSessionUpdateAttempts {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(rand.N(sessionRetryDelay * time.Duration(attempt))):
+		return true
+	}
+}
+
 func (s *service) getSession(ctx context.Context, userID string) (*entity.TrainingSession, error) {
 	session, err := s.cache.GetSession(ctx, userID)
 	if err != nil {
@@ -351,16 +661,34 @@ func (s *service) getSession(ctx context.Context, userID string) (*entity.Traini
 	return session, nil
 }
 
-func (s *service) addExerciseToSession(ctx context.Context, session *entity.TrainingSession, exerciseID uuid.UUID) error {
-	exercise, err := s.db.GetExerciseByID(ctx, exerciseID)
+// parseSetInput разбирает сообщение с подходами: первая строка - подходы в любой
+// поддерживаемой записи, остальные строки - общая заметка для подходов без своей.
+func (s *service) parseSetInput(input string) ([]parser.Set, error) {
+	parts := strings.SplitN(strings.TrimSpace(input), "\n", 2)
+
+	parsedSets, err := s.parser.ParseSets(parts[0])
 	if err != nil {
-		log.Printf("Error getting exercise by ID '%v': %v\n", exerciseID, err)
-		return err
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidSetFormat, err)
+	}
+
+	if len(parts) > 1 {
+		notes := strings.TrimSpace(parts[1])
+		for i := range parsedSets {
+			if parsedSets[i].Notes == "" {
+				parsedSets[i].Notes = notes
+				parsedSets[i].Difficulty = s.parser.ParseDifficulty(notes)
+			}
+		}
 	}
 
+	return parsedSets, nil
+}
+
+// addExerciseToSession добавляет упражнение с пустым подходом, который заполнит следующий ввод.
+func addExerciseToSession(session *entity.TrainingSession, exercise entity.Exercise) {
 	sets := []entity.Set{*entity.NewSet(entity.WithSetInitSpec(entity.SetInitSpecification{
 		UserID:     session.UserID(),
-		ExerciseID: exerciseID,
+		ExerciseID: exercise.ID(),
 		Number:     1,
 	}))}
 
@@ -371,10 +699,4 @@ func (s *service) addExerciseToSession(ctx context.Context, session *entity.Trai
 			Number: session.ExerciseCount() + 1,
 		})),
 	)
-
-	err = s.cache.SaveSession(ctx, session)
-	if err != nil {
-		log.Printf("Error saving session after adding exercise: %v\n", err)
-	}
-	return err
 }
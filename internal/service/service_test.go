@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+
+	"gymnote/internal/config"
+	"gymnote/internal/entity"
+	"gymnote/internal/parser"
+	"gymnote/internal/repository"
+	rediscache "gymnote/internal/repository/redis"
+)
+
+// newTestCache поднимает кэш в redis поверх miniredis: запись тренировок идет через те же
+// скрипты и WATCH, что и в работе.
+func newTestCache(t *testing.T) repository.Cache {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	cache, err := rediscache.New(context.Background(), &config.CacheConfig{Address: mr.Addr()})
+	if err != nil {
+		t.Fatalf("connect to miniredis: %v", err)
+	}
+	t.Cleanup(func() { cache.Close(context.Background()) })
+
+	return cache
+}
+
+func newTestSession(t *testing.T, cache repository.Cache, userID string) *entity.TrainingSession {
+	t.Helper()
+
+	exercise := entity.NewExercise(entity.WithExerciseInitSpec(entity.ExerciseInitSpecification{Name: "Жим лежа"}))
+	session := entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(entity.TrainingSessionInitSpecification{
+		UserID: userID,
+		Date:   time.Now(),
+	}))
+	addExerciseToSession(session, *exercise)
+
+	if err := cache.SaveSession(context.Background(), session); err != nil {
+		t.Fatalf("save baseline session: %v", err)
+	}
+
+	return session
+}
+
+// addSetsConcurrently записывает подходы с весами 1..writers параллельно от имени users по кругу.
+func addSetsConcurrently(t *testing.T, s *service, users []string, writers int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.AddOrUpdateSet(context.Background(), users[i%len(users)], i+1, fmt.Sprintf("%d,10", i+1)); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		t.Errorf("AddOrUpdateSet: %v", err)
+	}
+}
+
+func checkWeights(t *testing.T, session *entity.TrainingSession, weights ...int) {
+	t.Helper()
+
+	saved := make(map[float32]bool)
+	for _, set := range session.Exercises()[0].Sets() {
+		saved[set.Weight()] = true
+	}
+
+	if got := int(session.SetCount()); got != len(weights) {
+		t.Errorf("user %s: set count = %d, want %d", session.UserID(), got, len(weights))
+	}
+	for _, weight := range weights {
+		if !saved[float32(weight)] {
+			t.Errorf("user %s: set with weight %d is lost", session.UserID(), weight)
+		}
+	}
+}
+
+func TestAddOrUpdateSetConcurrent(t *testing.T) {
+	const (
+		userID  = "1"
+		writers = 20
+	)
+
+	ctx := context.Background()
+	cache := newTestCache(t)
+	s := New(nil, cache, parser.New())
+	baseline := newTestSession(t, cache, userID).Version()
+
+	addSetsConcurrently(t, s, []string{userID}, writers)
+
+	saved, err := cache.GetSession(ctx, userID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+
+	if got, want := saved.Version(), baseline+writers; got != want {
+		t.Errorf("version = %d, want %d", got, want)
+	}
+
+	weights := make([]int, 0, writers)
+	for i := range writers {
+		weights = append(weights, i+1)
+	}
+	checkWeights(t, saved, weights...)
+}
+
+func TestAddOrUpdateSetSharedConcurrent(t *testing.T) {
+	const (
+		ownerID   = "1"
+		partnerID = "2"
+		writers   = 20
+	)
+
+	ctx := context.Background()
+	cache := newTestCache(t)
+	s := New(nil, cache, parser.New())
+	newTestSession(t, cache, ownerID)
+
+	shared, err := s.ShareSession(ctx, ownerID, "Owner")
+	if err != nil {
+		t.Fatalf("share session: %v", err)
+	}
+	if _, err := shared.Join(partnerID, "Partner", uuid.Nil, time.Now()); err != nil {
+		t.Fatalf("join shared session: %v", err)
+	}
+	if err := cache.JoinSharedSession(ctx, shared, partnerID); err != nil {
+		t.Fatalf("save joined shared session: %v", err)
+	}
+	baseline := shared.Version()
+
+	addSetsConcurrently(t, s, []string{ownerID, partnerID}, writers)
+
+	saved, err := cache.GetSharedSession(ctx, shared.ID())
+	if err != nil {
+		t.Fatalf("get shared session: %v", err)
+	}
+	if got, want := saved.Version(), baseline+writers; got != want {
+		t.Errorf("version = %d, want %d", got, want)
+	}
+
+	for u, userID := range []string{ownerID, partnerID} {
+		view, err := cache.GetSession(ctx, userID)
+		if err != nil {
+			t.Fatalf("get session of user %s: %v", userID, err)
+		}
+
+		var weights []int
+		for i := u; i < writers; i += 2 {
+			weights = append(weights, i+1)
+		}
+		checkWeights(t, view, weights...)
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+	"gymnote/internal/i18n"
+)
+
+func (s *service) GetUserSettings(ctx context.Context, userID string) (*entity.UserSettings, error) {
+	settings, err := s.db.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserSettingsNotFound) {
+			return entity.NewUserSettings(entity.WithUserSettingsInitSpec(entity.UserSettingsInitSpecification{
+				UserID: userID,
+			})), nil
+		}
+		log.Printf("Error getting settings for user '%s': %v\n", userID, err)
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SetUserLocale сохраняет выбранный язык, пустая строка возвращает язык клиента Telegram.
+func (s *service) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	if locale != "" {
+		if _, ok := i18n.ParseLocale(locale); !ok {
+			return errs.ErrUnsupportedLocale
+		}
+	}
+
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.SetLocale(locale)
+
+	if err := s.db.SaveUserSettings(ctx, *settings); err != nil {
+		log.Printf("Error saving settings for user '%s': %v\n", userID, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+// ShareSession открывает текущую тренировку для партнеров. Уже открытая тренировка
+// возвращается как есть.
+func (s *service) ShareSession(ctx context.Context, userID, name string) (*entity.SharedSession, error) {
+	for attempt := 1; ; attempt++ {
+		session, err := s.getSession(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		var shared *entity.SharedSession
+		if session.SharedSessionID() != uuid.Nil {
+			shared, err = s.cache.GetSharedSession(ctx, session.SharedSessionID())
+			if err == nil && shared == nil {
+				err = errs.ErrSessionConflict
+			}
+		} else {
+			shared = entity.NewSharedSession(entity.WithSharedSessionInitSpec(entity.SharedSessionInitSpecification{
+				Session:   session,
+				OwnerName: name,
+			}))
+			err = s.cache.CreateSharedSession(ctx, shared, session)
+		}
+
+		if retryOnConflict(ctx, err, attempt) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error sharing session for user '%s': %v\n", userID, err)
+			return nil, err
+		}
+
+		return shared, nil
+	}
+}
+
+func (s *service) GetSharedSession(ctx context.Context, sharedID uuid.UUID) (*entity.SharedSession, error) {
+	shared, err := s.cache.GetSharedSession(ctx, sharedID)
+	if err != nil {
+		log.Printf("Error getting shared session '%v': %v\n", sharedID, err)
+		return nil, err
+	}
+	if shared == nil {
+		return nil, errs.ErrSharedSessionNotFound
+	}
+
+	return shared, nil
+}
+
+// JoinSharedSession добавляет пользователя в совместную тренировку, если у него нет своей текущей.
+// Это проверяется в кэше вместе с записью, поэтому начатая в это время тренировка не потеряется.
+func (s *service) JoinSharedSession(ctx context.Context, sharedID uuid.UUID, userID, name string) (*entity.SharedSession, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		shared, err := s.GetSharedSession(ctx, sharedID)
+		if err != nil {
+			return nil, err
+		}
+
+		joined, err := shared.Join(userID, name, settings.GymID(), time.Now())
+		if err != nil || !joined {
+			return shared, err
+		}
+
+		err = s.cache.JoinSharedSession(ctx, shared, userID)
+		if retryOnConflict(ctx, err, attempt) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error joining shared session '%v' for user '%s': %v\n", sharedID, userID, err)
+			return nil, err
+		}
+
+		return shared, nil
+	}
+}
+
+// GetSessionPartners возвращает партнеров пользователя по текущей совместной тренировке.
+func (s *service) GetSessionPartners(ctx context.Context, userID string) ([]entity.SessionParticipant, error) {
+	session, err := s.getSession(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session.SharedSessionID() == uuid.Nil {
+		return nil, nil
+	}
+
+	shared, err := s.GetSharedSession(ctx, session.SharedSessionID())
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.Partners(userID), nil
+}
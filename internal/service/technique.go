@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+// GetTechnique возвращает карточку техники, для упражнения без карточки - пустую.
+func (s *service) GetTechnique(ctx context.Context, exerciseID uuid.UUID) (*entity.Technique, error) {
+	technique, err := s.db.GetTechnique(ctx, exerciseID)
+	if err != nil {
+		if errors.Is(err, errs.ErrTechniqueNotFound) {
+			return entity.NewTechnique(entity.WithTechniqueInitSpec(entity.TechniqueInitSpecification{
+				ExerciseID: exerciseID,
+			})), nil
+		}
+		log.Printf("Error getting technique of exercise '%v': %v\n", exerciseID, err)
+		return nil, err
+	}
+
+	return &technique, nil
+}
+
+// UpdateTechniqueText заменяет описание, подсказки и частые ошибки, вложения сохраняются.
+func (s *service) UpdateTechniqueText(ctx context.Context, actorID string, exerciseID uuid.UUID, description string, cues, mistakes []string) (*entity.Technique, error) {
+	technique, err := s.getTechniqueForUpdate(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := technique.Description()
+	technique.SetText(description, cues, mistakes)
+
+	if err := s.saveTechnique(ctx, technique); err != nil {
+		return nil, err
+	}
+
+	s.auditExercise(ctx, actorID, exerciseID, entity.ExerciseAuditTechnique, before, description)
+
+	return technique, nil
+}
+
+func (s *service) AddTechniqueMedia(ctx context.Context, actorID string, exerciseID uuid.UUID, media entity.TechniqueMedia) (*entity.Technique, error) {
+	technique, err := s.getTechniqueForUpdate(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !technique.AddMedia(media) {
+		return nil, errs.ErrTechniqueMediaLimit
+	}
+
+	if err := s.saveTechnique(ctx, technique); err != nil {
+		return nil, err
+	}
+
+	after := strings.TrimSpace(string(media.Type) + " " + media.FileID + media.Path)
+	s.auditExercise(ctx, actorID, exerciseID, entity.ExerciseAuditTechnique, "", after)
+
+	return technique, nil
+}
+
+func (s *service) getTechniqueForUpdate(ctx context.Context, exerciseID uuid.UUID) (*entity.Technique, error) {
+	if _, err := s.getActiveExercise(ctx, exerciseID); err != nil {
+		return nil, err
+	}
+
+	return s.GetTechnique(ctx, exerciseID)
+}
+
+func (s *service) saveTechnique(ctx context.Context, technique *entity.Technique) error {
+	if err := s.db.SaveTechnique(ctx, *technique); err != nil {
+		log.Printf("Error saving technique of exercise '%v': %v\n", technique.ExerciseID(), err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+func (s *service) GetTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) (*entity.TrainingSession, error) {
+	session, err := s.db.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		log.Printf("Error getting training session '%v' for user '%s': %v\n", sessionID, userID, err)
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// UpdateTrainingSet заменяет вес, повторы и заметку подхода одной записью подхода.
+func (s *service) UpdateTrainingSet(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber, setNumber uint8, input string) (*entity.TrainingSession, error) {
+	parsedSets, err := s.parseSetInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsedSets) != 1 {
+		return nil, errs.ErrInvalidSetFormat
+	}
+
+	session, set, err := s.getTrainingSet(ctx, userID, sessionID, exerciseNumber, setNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := parsedSets[0]
+	set.Update(parsed.Weight, parsed.Reps, parsed.Difficulty, parsed.Notes)
+
+	return session, s.saveTrainingSession(ctx, session)
+}
+
+func (s *service) DeleteTrainingSet(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber, setNumber uint8) (*entity.TrainingSession, error) {
+	session, set, err := s.getTrainingSet(ctx, userID, sessionID, exerciseNumber, setNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.DeleteSet(set.ID()); err != nil {
+		return nil, err
+	}
+
+	return session, s.saveTrainingSession(ctx, session)
+}
+
+func (s *service) DeleteTrainingExercise(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber uint8) (*entity.TrainingSession, error) {
+	session, err := s.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.DeleteExercise(exerciseNumber); err != nil {
+		return nil, err
+	}
+
+	return session, s.saveTrainingSession(ctx, session)
+}
+
+// ChangeTrainingDate переносит тренировку на другой день, время начала сохраняется.
+func (s *service) ChangeTrainingDate(ctx context.Context, userID string, sessionID uuid.UUID, date time.Time) (*entity.TrainingSession, error) {
+	session, err := s.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := session.Date()
+	session.SetDate(time.Date(date.Year(), date.Month(), date.Day(),
+		current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location()))
+
+	return session, s.saveTrainingSession(ctx, session)
+}
+
+func (s *service) DeleteTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	if err := s.db.DeleteTrainingSession(ctx, userID, sessionID); err != nil {
+		log.Printf("Error deleting training session '%v' for user '%s': %v\n", sessionID, userID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) RateTrainingSession(ctx context.Context, userID string, sessionID uuid.UUID, rating uint8) (*entity.TrainingSession, error) {
+	session, err := s.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Rate(rating); err != nil {
+		return nil, err
+	}
+
+	return session, s.saveTrainingDetails(ctx, session)
+}
+
+// UpdateTrainingDetails задает вес тела и заметку завершенной тренировки, nil оставляет прежнее значение.
+func (s *service) UpdateTrainingDetails(ctx context.Context, userID string, sessionID uuid.UUID, bodyweight *float32, notes *string) (*entity.TrainingSession, error) {
+	session, err := s.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.SetDetails(bodyweight, notes); err != nil {
+		return nil, err
+	}
+
+	return session, s.saveTrainingDetails(ctx, session)
+}
+
+func (s *service) saveTrainingDetails(ctx context.Context, session *entity.TrainingSession) error {
+	if err := s.db.UpdateTrainingSessionDetails(ctx, *session); err != nil {
+		log.Printf("Error updating training session details '%v': %v\n", session.ID(), err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) getTrainingSet(ctx context.Context, userID string, sessionID uuid.UUID, exerciseNumber, setNumber uint8) (*entity.TrainingSession, *entity.Set, error) {
+	session, err := s.GetTrainingSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exercise, ok := session.FindExercise(exerciseNumber)
+	if !ok {
+		return nil, nil, errs.ErrExerciseNotFound
+	}
+
+	set, ok := exercise.FindSet(setNumber)
+	if !ok {
+		return nil, nil, errs.ErrSetNotFound
+	}
+
+	return session, set, nil
+}
+
+// saveTrainingSession сохраняет правку завершенной тренировки, тренировка без подходов удаляется.
+func (s *service) saveTrainingSession(ctx context.Context, session *entity.TrainingSession) error {
+	if session.IsEmpty() {
+		return s.DeleteTrainingSession(ctx, session.UserID(), session.ID())
+	}
+
+	if err := s.db.UpdateTrainingSession(ctx, *session); err != nil {
+		log.Printf("Error updating training session '%v': %v\n", session.ID(), err)
+		return err
+	}
+
+	return nil
+}
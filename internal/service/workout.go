@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gymnote/internal/entity"
+	"gymnote/internal/errs"
+)
+
+// ParseWorkoutPlans разбирает планы тренировок в формате /upload_training. Дата каждой
+// тренировки становится днем, на который ее назначат, прошедшие даты не принимаются.
+func (s *service) ParseWorkoutPlans(ctx context.Context, coachID, text string) ([]entity.WorkoutPlan, error) {
+	parsedTrainings, err := s.parser.ParseTrainings(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidSetFormat, err)
+	}
+
+	today := startOfDay(time.Now())
+	exercisesByName := make(map[string]entity.Exercise)
+	plans := make([]entity.WorkoutPlan, 0, len(parsedTrainings))
+
+	for _, parsedTraining := range parsedTrainings {
+		date := startOfDay(parsedTraining.Date)
+		if date.Before(today) {
+			return nil, errs.ErrWorkoutDateInPast
+		}
+
+		plan := entity.WorkoutPlan{Date: date}
+		for _, parsedExercise := range parsedTraining.Exercises {
+			exercise, ok := exercisesByName[parsedExercise.Name]
+			if !ok {
+				exercise, err = s.db.GetExerciseByName(ctx, coachID, parsedExercise.Name)
+				if err != nil {
+					log.Printf("Error getting exercise ID for '%s': %v\n", parsedExercise.Name, err)
+					return nil, fmt.Errorf("failed to get exercise ID for '%s': %w", parsedExercise.Name, err)
+				}
+				exercisesByName[parsedExercise.Name] = exercise
+			}
+
+			sets := make([]entity.PlannedSet, 0, len(parsedExercise.Sets))
+			for _, set := range parsedExercise.Sets {
+				sets = append(sets, entity.PlannedSet{Weight: set.Weight, Reps: set.Reps})
+			}
+
+			plan.Exercises = append(plan.Exercises, entity.PlannedExercise{
+				ExerciseID: exercise.ID(),
+				Name:       exercise.Name(),
+				Sets:       sets,
+			})
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// AssignWorkouts назначает каждый план каждому из спортсменов тренера.
+func (s *service) AssignWorkouts(ctx context.Context, coachID string, plans []entity.WorkoutPlan, athleteIDs []string) ([]entity.AssignedWorkout, error) {
+	if len(athleteIDs) == 0 {
+		return nil, errs.ErrNoAthletesSelected
+	}
+
+	workouts := make([]entity.AssignedWorkout, 0, len(plans)*len(athleteIDs))
+	for _, athleteID := range athleteIDs {
+		link, err := s.db.GetActiveCoachLink(ctx, coachID, athleteID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plan := range plans {
+			workouts = append(workouts, *entity.NewAssignedWorkout(entity.WithAssignedWorkoutInitSpec(entity.AssignedWorkoutInitSpecification{
+				CoachID:   coachID,
+				CoachName: link.CoachName(),
+				AthleteID: athleteID,
+				Plan:      plan,
+			})))
+		}
+	}
+
+	if err := s.db.InsertAssignedWorkouts(ctx, workouts); err != nil {
+		log.Printf("Error assigning workouts of coach '%s': %v\n", coachID, err)
+		return nil, err
+	}
+
+	return workouts, nil
+}
+
+// DeliverDueWorkouts отмечает доставленными планы на сегодня и пропущенные дни и возвращает их,
+// чтобы спортсмены получили кнопку начала. Планы от тренеров, потерявших доступ, не доставляются.
+func (s *service) DeliverDueWorkouts(ctx context.Context) ([]entity.AssignedWorkout, error) {
+	now := time.Now()
+
+	workouts, err := s.db.GetUndeliveredWorkouts(ctx, startOfDay(now).AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("Error getting undelivered workouts: %v\n", err)
+		return nil, err
+	}
+
+	var delivered []entity.AssignedWorkout
+	for _, workout := range workouts {
+		_, linkErr := s.db.GetActiveCoachLink(ctx, workout.CoachID(), workout.AthleteID())
+		if linkErr != nil && !errors.Is(linkErr, errs.ErrCoachLinkNotFound) {
+			log.Printf("Error checking coach link for workout '%s': %v\n", workout.ID(), linkErr)
+			continue
+		}
+
+		workout.MarkDelivered(now)
+		if err := s.db.SaveAssignedWorkout(ctx, workout); err != nil {
+			log.Printf("Error marking workout '%s' delivered: %v\n", workout.ID(), err)
+			continue
+		}
+
+		if linkErr == nil {
+			delivered = append(delivered, workout)
+		}
+	}
+
+	return delivered, nil
+}
+
+// GetAssignedWorkout возвращает план, если пользователь его тренер или спортсмен.
+func (s *service) GetAssignedWorkout(ctx context.Context, userID string, workoutID uuid.UUID) (*entity.AssignedWorkout, error) {
+	workout, err := s.db.GetAssignedWorkout(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != workout.AthleteID() && userID != workout.CoachID() {
+		return nil, errs.ErrAssignedWorkoutNotFound
+	}
+
+	return &workout, nil
+}
+
+// StartAssignedWorkout начинает тренировку, заполненную упражнениями и подходами плана.
+// Подходы плана остаются пустыми, пока спортсмен не запишет фактический результат.
+func (s *service) StartAssignedWorkout(ctx context.Context, athleteID string, workoutID uuid.UUID) (*entity.TrainingSession, error) {
+	workout, err := s.db.GetAssignedWorkout(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workout.AthleteID() != athleteID {
+		return nil, errs.ErrAssignedWorkoutNotFound
+	}
+	if workout.IsCompleted() {
+		return nil, errs.ErrWorkoutCompleted
+	}
+
+	if session, err := s.cache.GetSession(ctx, athleteID); err == nil && session != nil {
+		return nil, errs.ErrTrainingStarted
+	}
+
+	settings, err := s.GetUserSettings(ctx, athleteID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises := make([]entity.SessionExercise, 0, len(workout.Exercises()))
+	for i, planned := range workout.Exercises() {
+		exercise, err := s.db.GetExerciseByID(ctx, planned.ExerciseID)
+		if err != nil {
+			log.Printf("Error getting exercise by ID '%v': %v\n", planned.ExerciseID, err)
+			return nil, err
+		}
+
+		sets := make([]entity.Set, 0, len(planned.Sets))
+		for j, set := range planned.Sets {
+			sets = append(sets, *entity.NewSet(entity.WithSetInitSpec(entity.SetInitSpecification{
+				UserID:        athleteID,
+				ExerciseID:    exercise.ID(),
+				Number:        uint8(j + 1),
+				PlannedWeight: set.Weight,
+				PlannedReps:   set.Reps,
+			})))
+		}
+
+		exercises = append(exercises, *entity.NewSessionExercise(&exercise, sets, entity.WithSessionExerciseInitSpec(
+			entity.SessionExerciseInitSpecification{
+				Number: uint8(i + 1),
+			},
+		)))
+	}
+
+	session := entity.NewTrainingSession(entity.WithTrainingSessionInitSpec(entity.TrainingSessionInitSpecification{
+		UserID:       athleteID,
+		Date:         time.Now(),
+		Exercises:    exercises,
+		GymID:        settings.GymID(),
+		StartedAt:    time.Now(),
+		AssignmentID: workout.ID(),
+	}))
+
+	err = s.saveSession(ctx, session)
+	if errors.Is(err, errs.ErrSessionConflict) {
+		return nil, errs.ErrTrainingStarted
+	}
+	if err != nil {
+		log.Printf("Error saving assigned training session for user '%s': %v\n", athleteID, err)
+		return nil, err
+	}
+
+	workout.Start(session.ID())
+	if err := s.db.SaveAssignedWorkout(ctx, workout); err != nil {
+		log.Printf("Error marking workout '%s' started: %v\n", workout.ID(), err)
+	}
+
+	return session, nil
+}
+
+// completeAssignedWorkout отмечает план выполненным после сохранения тренировки по нему.
+func (s *service) completeAssignedWorkout(ctx context.Context, session *entity.TrainingSession) {
+	workout, err := s.db.GetAssignedWorkout(ctx, session.AssignmentID())
+	if err != nil {
+		log.Printf("Error getting assigned workout '%s': %v\n", session.AssignmentID(), err)
+		return
+	}
+
+	workout.Complete(session.ID(), session.FinishedAt())
+	if err := s.db.SaveAssignedWorkout(ctx, workout); err != nil {
+		log.Printf("Error completing assigned workout '%s': %v\n", workout.ID(), err)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}